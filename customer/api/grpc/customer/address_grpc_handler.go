@@ -0,0 +1,123 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	addresshandler "github.com/DuongVu089x/interview/customer/api/handler/address"
+	domainaddress "github.com/DuongVu089x/interview/customer/domain/address"
+	pb "github.com/DuongVu089x/interview/customer/proto/customer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *GrpcHandler) ListAddresses(ctx context.Context, req *pb.ListAddressesRequest) (*pb.ListAddressesResponse, error) {
+	addresses, err := h.addressHandler.GetAddresses(ctx, req.UserId)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to list addresses")
+	}
+
+	response := &pb.ListAddressesResponse{
+		Addresses: make([]*pb.Address, 0, len(addresses.Addresses)),
+	}
+	for _, address := range addresses.Addresses {
+		response.Addresses = append(response.Addresses, toPbAddress(address))
+	}
+	return response, nil
+}
+
+func (h *GrpcHandler) GetAddress(ctx context.Context, req *pb.GetAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressHandler.GetAddress(ctx, req.UserId, req.AddressId)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to get address")
+	}
+	return &pb.AddressResponse{Address: toPbAddress(address)}, nil
+}
+
+func (h *GrpcHandler) CreateAddress(ctx context.Context, req *pb.CreateAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressHandler.CreateAddress(ctx, req.UserId, toAddressRequest(req.Address))
+	if err != nil {
+		return nil, toStatusError(err, "Failed to create address")
+	}
+	return &pb.AddressResponse{Address: toPbAddress(address)}, nil
+}
+
+func (h *GrpcHandler) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressHandler.UpdateAddress(ctx, req.UserId, req.AddressId, toAddressRequest(req.Address))
+	if err != nil {
+		return nil, toStatusError(err, "Failed to update address")
+	}
+	return &pb.AddressResponse{Address: toPbAddress(address)}, nil
+}
+
+func (h *GrpcHandler) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest) (*pb.DeleteAddressResponse, error) {
+	if err := h.addressHandler.DeleteAddress(ctx, req.UserId, req.AddressId); err != nil {
+		return nil, toStatusError(err, "Failed to delete address")
+	}
+	return &pb.DeleteAddressResponse{}, nil
+}
+
+func (h *GrpcHandler) SetDefaultAddress(ctx context.Context, req *pb.SetDefaultAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressHandler.SetDefaultAddress(ctx, req.UserId, req.AddressId)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to set default address")
+	}
+	return &pb.AddressResponse{Address: toPbAddress(address)}, nil
+}
+
+func toStatusError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainaddress.ErrAddressNotFound):
+		return status.Error(codes.NotFound, domainaddress.ErrAddressNotFound.Error())
+	case errors.Is(err, domainaddress.ErrAddressBookFull):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domainaddress.ErrInvalidAddress),
+		errors.Is(err, domainaddress.ErrInvalidAddressID),
+		errors.Is(err, addresshandler.ErrEmptyUserID),
+		errors.Is(err, addresshandler.ErrEmptyAddressID),
+		errors.Is(err, addresshandler.ErrEmptyRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Printf("%s: %v", message, err)
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func toAddressRequest(input *pb.AddressInput) *addresshandler.AddressRequest {
+	if input == nil {
+		return nil
+	}
+	return &addresshandler.AddressRequest{
+		Label:         input.Label,
+		RecipientName: input.RecipientName,
+		Phone:         input.Phone,
+		Line1:         input.Line1,
+		Line2:         input.Line2,
+		City:          input.City,
+		Region:        input.Region,
+		PostalCode:    input.PostalCode,
+		CountryCode:   input.CountryCode,
+		IsDefault:     input.IsDefault,
+	}
+}
+
+func toPbAddress(address *addresshandler.AddressResponse) *pb.Address {
+	return &pb.Address{
+		Id:            address.ID,
+		UserId:        address.UserId,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     address.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     address.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	addresshandler "github.com/DuongVu089x/interview/customer/api/handler/address"
 	customerhandler "github.com/DuongVu089x/interview/customer/api/handler/customer"
 	"github.com/DuongVu089x/interview/customer/component/appctx"
 	pb "github.com/DuongVu089x/interview/customer/proto/customer"
@@ -14,12 +15,14 @@ import (
 
 type GrpcHandler struct {
 	pb.UnimplementedCustomerServiceServer
-	handler *customerhandler.Handler
+	handler        *customerhandler.Handler
+	addressHandler *addresshandler.Handler
 }
 
 func NewGrpcHandler(appCtx appctx.AppContext) *GrpcHandler {
 	return &GrpcHandler{
-		handler: customerhandler.NewHandler(appCtx),
+		handler:        customerhandler.NewHandler(appCtx),
+		addressHandler: addresshandler.NewHandler(appCtx),
 	}
 }
 
@@ -46,6 +49,7 @@ func (h *GrpcHandler) GetCustomer(ctx context.Context, req *pb.GetCustomerReques
 			Name:      customer.Name,
 			Email:     customer.Email,
 			Phone:     customer.Phone,
+			Tier:      customer.Tier,
 			CreatedAt: customer.CreatedAt.Format(time.RFC3339),
 			UpdatedAt: customer.UpdatedAt.Format(time.RFC3339),
 		},
@@ -0,0 +1,92 @@
+package address
+
+import (
+	"time"
+
+	domainaddress "github.com/DuongVu089x/interview/customer/domain/address"
+)
+
+// AddressResponse represents the address data returned to clients
+type AddressResponse struct {
+	ID            string    `json:"id"`
+	UserId        string    `json:"userId"`
+	Label         string    `json:"label,omitempty"`
+	RecipientName string    `json:"recipientName"`
+	Phone         string    `json:"phone,omitempty"`
+	Line1         string    `json:"line1"`
+	Line2         string    `json:"line2,omitempty"`
+	City          string    `json:"city"`
+	Region        string    `json:"region,omitempty"`
+	PostalCode    string    `json:"postalCode,omitempty"`
+	CountryCode   string    `json:"countryCode"`
+	IsDefault     bool      `json:"isDefault"`
+	CreatedAt     time.Time `json:"createdAt,omitempty"`
+	UpdatedAt     time.Time `json:"updatedAt,omitempty"`
+}
+
+// AddressListResponse represents the address book of a customer
+type AddressListResponse struct {
+	Addresses []*AddressResponse `json:"addresses"`
+	Count     int                `json:"count"`
+}
+
+// AddressRequest represents the request body for creating or updating an
+// address; IsDefault makes it the default address of the customer
+type AddressRequest struct {
+	Label         string `json:"label,omitempty"`
+	RecipientName string `json:"recipientName"`
+	Phone         string `json:"phone,omitempty"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+	City          string `json:"city"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty"`
+	CountryCode   string `json:"countryCode"`
+	IsDefault     bool   `json:"isDefault,omitempty"`
+}
+
+// ToAddressResponse converts a domain address to an address response DTO
+func ToAddressResponse(address *domainaddress.Address) *AddressResponse {
+	if address == nil {
+		return nil
+	}
+
+	response := &AddressResponse{
+		UserId:        address.UserID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     address.CreatedAt,
+		UpdatedAt:     address.UpdatedAt,
+	}
+
+	if address.ID != nil {
+		response.ID = address.ID.Hex()
+	}
+
+	return response
+}
+
+// ToDomainAddress converts an address request to a domain address of the user
+func (req *AddressRequest) ToDomainAddress(userId string) *domainaddress.Address {
+	return &domainaddress.Address{
+		UserID:        userId,
+		Label:         req.Label,
+		RecipientName: req.RecipientName,
+		Phone:         req.Phone,
+		Line1:         req.Line1,
+		Line2:         req.Line2,
+		City:          req.City,
+		Region:        req.Region,
+		PostalCode:    req.PostalCode,
+		CountryCode:   req.CountryCode,
+		IsDefault:     req.IsDefault,
+	}
+}
@@ -0,0 +1,141 @@
+package address
+
+import (
+	"context"
+	"errors"
+
+	addressusecase "github.com/DuongVu089x/interview/customer/application/address"
+	"github.com/DuongVu089x/interview/customer/component/appctx"
+	domainaddress "github.com/DuongVu089x/interview/customer/domain/address"
+	addressrepository "github.com/DuongVu089x/interview/customer/repository/address"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrEmptyUserID    = errors.New("customer ID is required")
+	ErrEmptyAddressID = errors.New("address ID is required")
+	ErrEmptyRequest   = errors.New("request data is required")
+)
+
+// Handler serves the address book of customers to the REST and gRPC APIs.
+// Errors are the sentinels above or those of the address domain.
+type Handler struct {
+	appCtx         appctx.AppContext
+	addressUseCase *addressusecase.UseCase
+}
+
+func NewHandler(appCtx appctx.AppContext) *Handler {
+	addressRepo := addressrepository.NewMongoRepository(
+		appCtx.GetMainDBConnection(),
+		appCtx.GetReadMainDBConnection(),
+	)
+
+	return &Handler{
+		appCtx:         appCtx,
+		addressUseCase: addressusecase.NewUseCase(addressRepo),
+	}
+}
+
+// GetAddresses handles listing the addresses of a customer
+func (h *Handler) GetAddresses(ctx context.Context, userId string) (*AddressListResponse, error) {
+	if userId == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	addresses, err := h.addressUseCase.GetAddresses(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddressListResponse{
+		Addresses: make([]*AddressResponse, 0, len(addresses)),
+		Count:     len(addresses),
+	}
+	for _, address := range addresses {
+		response.Addresses = append(response.Addresses, ToAddressResponse(address))
+	}
+	return response, nil
+}
+
+// GetAddress handles retrieving an address of a customer
+func (h *Handler) GetAddress(ctx context.Context, userId, addressId string) (*AddressResponse, error) {
+	if err := validateIDs(userId, addressId); err != nil {
+		return nil, err
+	}
+
+	address, err := h.addressUseCase.GetAddress(ctx, userId, addressId)
+	if err != nil {
+		return nil, err
+	}
+	return ToAddressResponse(address), nil
+}
+
+// CreateAddress handles adding an address to the book of a customer
+func (h *Handler) CreateAddress(ctx context.Context, userId string, req *AddressRequest) (*AddressResponse, error) {
+	if userId == "" {
+		return nil, ErrEmptyUserID
+	}
+	if req == nil {
+		return nil, ErrEmptyRequest
+	}
+
+	address, err := h.addressUseCase.CreateAddress(ctx, req.ToDomainAddress(userId))
+	if err != nil {
+		return nil, err
+	}
+	return ToAddressResponse(address), nil
+}
+
+// UpdateAddress handles replacing an address of a customer
+func (h *Handler) UpdateAddress(ctx context.Context, userId, addressId string, req *AddressRequest) (*AddressResponse, error) {
+	if err := validateIDs(userId, addressId); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, ErrEmptyRequest
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(addressId)
+	if err != nil {
+		return nil, domainaddress.ErrInvalidAddressID
+	}
+	address := req.ToDomainAddress(userId)
+	address.ID = &objectID
+
+	address, err = h.addressUseCase.UpdateAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return ToAddressResponse(address), nil
+}
+
+// DeleteAddress handles removing an address of a customer
+func (h *Handler) DeleteAddress(ctx context.Context, userId, addressId string) error {
+	if err := validateIDs(userId, addressId); err != nil {
+		return err
+	}
+	return h.addressUseCase.DeleteAddress(ctx, userId, addressId)
+}
+
+// SetDefaultAddress handles making an address the default one of a customer
+func (h *Handler) SetDefaultAddress(ctx context.Context, userId, addressId string) (*AddressResponse, error) {
+	if err := validateIDs(userId, addressId); err != nil {
+		return nil, err
+	}
+
+	address, err := h.addressUseCase.SetDefaultAddress(ctx, userId, addressId)
+	if err != nil {
+		return nil, err
+	}
+	return ToAddressResponse(address), nil
+}
+
+func validateIDs(userId, addressId string) error {
+	if userId == "" {
+		return ErrEmptyUserID
+	}
+	if addressId == "" {
+		return ErrEmptyAddressID
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ type CustomerResponse struct {
 	Name      string    `json:"name,omitempty"`
 	Email     string    `json:"email,omitempty"`
 	Phone     string    `json:"phone,omitempty"`
+	Tier      string    `json:"tier,omitempty"`
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 	UpdatedAt time.Time `json:"updatedAt,omitempty"`
 }
@@ -43,6 +44,7 @@ func ToCustomerResponse(customer *domaincustomer.Customer) *CustomerResponse {
 		Name:      customer.Name,
 		Email:     customer.Email,
 		Phone:     customer.Phone,
+		Tier:      customer.Tier,
 		CreatedAt: customer.CreatedAt,
 		UpdatedAt: customer.UpdatedAt,
 	}
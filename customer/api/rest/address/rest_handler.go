@@ -0,0 +1,101 @@
+package address
+
+import (
+	"errors"
+	"net/http"
+
+	addresshandler "github.com/DuongVu089x/interview/customer/api/handler/address"
+	"github.com/DuongVu089x/interview/customer/component/appctx"
+	domainaddress "github.com/DuongVu089x/interview/customer/domain/address"
+	"github.com/labstack/echo/v4"
+)
+
+type RestHandler struct {
+	handler *addresshandler.Handler
+}
+
+func NewRestHandler(appCtx appctx.AppContext) *RestHandler {
+	return &RestHandler{
+		handler: addresshandler.NewHandler(appCtx),
+	}
+}
+
+func (h *RestHandler) HandleGetAddresses(c echo.Context) error {
+	addresses, err := h.handler.GetAddresses(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get addresses")
+	}
+
+	return c.JSON(http.StatusOK, addresses)
+}
+
+func (h *RestHandler) HandleGetAddress(c echo.Context) error {
+	address, err := h.handler.GetAddress(c.Request().Context(), c.Param("id"), c.Param("addressId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get address")
+	}
+
+	return c.JSON(http.StatusOK, address)
+}
+
+func (h *RestHandler) HandleCreateAddress(c echo.Context) error {
+	var req addresshandler.AddressRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	address, err := h.handler.CreateAddress(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return toHTTPError(err, "Failed to create address")
+	}
+
+	return c.JSON(http.StatusCreated, address)
+}
+
+func (h *RestHandler) HandleUpdateAddress(c echo.Context) error {
+	var req addresshandler.AddressRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	address, err := h.handler.UpdateAddress(c.Request().Context(), c.Param("id"), c.Param("addressId"), &req)
+	if err != nil {
+		return toHTTPError(err, "Failed to update address")
+	}
+
+	return c.JSON(http.StatusOK, address)
+}
+
+func (h *RestHandler) HandleDeleteAddress(c echo.Context) error {
+	if err := h.handler.DeleteAddress(c.Request().Context(), c.Param("id"), c.Param("addressId")); err != nil {
+		return toHTTPError(err, "Failed to delete address")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *RestHandler) HandleSetDefaultAddress(c echo.Context) error {
+	address, err := h.handler.SetDefaultAddress(c.Request().Context(), c.Param("id"), c.Param("addressId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to set default address")
+	}
+
+	return c.JSON(http.StatusOK, address)
+}
+
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainaddress.ErrAddressNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, domainaddress.ErrAddressNotFound.Error())
+	case errors.Is(err, domainaddress.ErrAddressBookFull):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, domainaddress.ErrInvalidAddress),
+		errors.Is(err, domainaddress.ErrInvalidAddressID),
+		errors.Is(err, addresshandler.ErrEmptyUserID),
+		errors.Is(err, addresshandler.ErrEmptyAddressID),
+		errors.Is(err, addresshandler.ErrEmptyRequest):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,15 @@
+package address
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, h *RestHandler) {
+	g := e.Group("/customers/:id/addresses")
+	g.GET("", h.HandleGetAddresses)
+	g.POST("", h.HandleCreateAddress)
+	g.GET("/:addressId", h.HandleGetAddress)
+	g.PUT("/:addressId", h.HandleUpdateAddress)
+	g.DELETE("/:addressId", h.HandleDeleteAddress)
+	g.POST("/:addressId/default", h.HandleSetDefaultAddress)
+}
@@ -0,0 +1,142 @@
+package address
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainaddress "github.com/DuongVu089x/interview/customer/domain/address"
+)
+
+type UseCase struct {
+	repo domainaddress.Repository
+}
+
+func NewUseCase(repo domainaddress.Repository) *UseCase {
+	return &UseCase{
+		repo: repo,
+	}
+}
+
+func (u *UseCase) GetAddresses(ctx context.Context, userID string) ([]*domainaddress.Address, error) {
+	addresses, err := u.repo.GetAddresses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses: %w", err)
+	}
+	return addresses, nil
+}
+
+func (u *UseCase) GetAddress(ctx context.Context, userID, id string) (*domainaddress.Address, error) {
+	address, err := u.repo.GetAddress(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+	return address, nil
+}
+
+// CreateAddress adds an address to the book of its user. The first address of
+// a user becomes the default one.
+func (u *UseCase) CreateAddress(ctx context.Context, address *domainaddress.Address) (*domainaddress.Address, error) {
+	address.Normalize()
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+
+	addresses, err := u.repo.GetAddresses(ctx, address.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addresses: %w", err)
+	}
+	if len(addresses) >= domainaddress.MaxAddresses {
+		return nil, domainaddress.ErrAddressBookFull
+	}
+
+	makeDefault := address.IsDefault || len(addresses) == 0
+	address.IsDefault = len(addresses) == 0
+	address.CreatedAt = time.Now()
+	address.UpdatedAt = address.CreatedAt
+
+	created, err := u.repo.CreateAddress(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create address: %w", err)
+	}
+	if makeDefault && len(addresses) > 0 {
+		if err := u.repo.SetDefault(ctx, created.UserID, created.ID.Hex()); err != nil {
+			return nil, fmt.Errorf("failed to set default address: %w", err)
+		}
+		created.IsDefault = true
+	}
+	return created, nil
+}
+
+// UpdateAddress replaces the fields of an address. Orders keep the address
+// they were placed with.
+func (u *UseCase) UpdateAddress(ctx context.Context, address *domainaddress.Address) (*domainaddress.Address, error) {
+	address.Normalize()
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.repo.GetAddress(ctx, address.UserID, address.ID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+
+	address.CreatedAt = existing.CreatedAt
+	address.UpdatedAt = time.Now()
+	if err := u.repo.UpdateAddress(ctx, address); err != nil {
+		return nil, fmt.Errorf("failed to update address: %w", err)
+	}
+
+	if address.IsDefault && !existing.IsDefault {
+		if err := u.repo.SetDefault(ctx, address.UserID, address.ID.Hex()); err != nil {
+			return nil, fmt.Errorf("failed to set default address: %w", err)
+		}
+	}
+	// The default address changes through another address only
+	address.IsDefault = address.IsDefault || existing.IsDefault
+	return address, nil
+}
+
+// DeleteAddress removes an address. When it was the default one, the oldest
+// remaining address becomes the default.
+func (u *UseCase) DeleteAddress(ctx context.Context, userID, id string) error {
+	existing, err := u.repo.GetAddress(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to get address: %w", err)
+	}
+
+	if err := u.repo.DeleteAddress(ctx, userID, id); err != nil {
+		return fmt.Errorf("failed to delete address: %w", err)
+	}
+	if !existing.IsDefault {
+		return nil
+	}
+
+	remaining, err := u.repo.GetAddresses(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get addresses: %w", err)
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	if err := u.repo.SetDefault(ctx, userID, remaining[0].ID.Hex()); err != nil {
+		return fmt.Errorf("failed to set default address: %w", err)
+	}
+	return nil
+}
+
+func (u *UseCase) SetDefaultAddress(ctx context.Context, userID, id string) (*domainaddress.Address, error) {
+	address, err := u.repo.GetAddress(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address: %w", err)
+	}
+	if address.IsDefault {
+		return address, nil
+	}
+
+	if err := u.repo.SetDefault(ctx, userID, id); err != nil {
+		return nil, fmt.Errorf("failed to set default address: %w", err)
+	}
+	address.IsDefault = true
+	return address, nil
+}
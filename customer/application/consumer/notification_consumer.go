@@ -7,6 +7,7 @@ import (
 	notificationusecase "github.com/DuongVu089x/interview/customer/application/notification"
 	"github.com/DuongVu089x/interview/customer/component/appctx"
 	"github.com/DuongVu089x/interview/customer/domain"
+	domainnotification "github.com/DuongVu089x/interview/customer/domain/notification"
 	notificationrepository "github.com/DuongVu089x/interview/customer/repository/notification"
 	userconnrepository "github.com/DuongVu089x/interview/customer/repository/user_connection"
 	"github.com/DuongVu089x/interview/customer/websocket"
@@ -50,20 +51,79 @@ func (c *NotificationConsumer) Setup() error {
 		notificationDispatcher := websocket.NewNotificationDispatcher(wsServer, "/notifications", notificationHandler)
 		notificationUseCase := notificationusecase.NewWriteUseCase(notificationRepository, notificationDispatcher)
 
+		request := &notificationusecase.CreateNotificationRequest{
+			Link:   fmt.Sprintf("localhost:8081/order/%s", payloadMap["order_id"].(string)),
+			UserID: payloadMap["user_id"].(string),
+		}
+
+		switch msg.Value.MessageCode {
+		case "ORDER_CREATED":
+			request.Topic = "order-created"
+			request.Title = "Order Created"
+			request.Description = "Order created successfully"
+		case "ORDER_STATUS_CHANGED":
+			request.Topic = "order-status-changed"
+			request.Title = "Order Updated"
+			request.Description = fmt.Sprintf("Your order is now %v", payloadMap["status"])
+		case "ORDER_CANCELLED":
+			request.Topic = "order-cancelled"
+			request.Title = "Order Cancelled"
+			request.Description = fmt.Sprintf("Your order has been cancelled (%v)", payloadMap["reason"])
+		case "ORDER_PAID":
+			request.Topic = "order-paid"
+			request.Title = "Payment Received"
+			request.Description = "We have received the payment for your order"
+		case "ORDER_EXPIRED":
+			request.Topic = "order-expired"
+			request.Title = "Order Expired"
+			request.Description = "Your order expired because it was not paid in time"
+		case "ORDER_REFUNDED":
+			request.Topic = "order-refunded"
+			request.Title = "Refund Issued"
+			request.Description = fmt.Sprintf("A refund of %v has been issued for your order", payloadMap["amount_text"])
+		default:
+			fmt.Printf("Skipping order message with unknown code: %s\n", msg.Value.MessageCode)
+			return nil
+		}
+
 		ctx := context.Background()
-		return notificationUseCase.CreateNotification(ctx, &notificationusecase.CreateNotificationRequest{
-			Topic:       "order-created",
-			Title:       "Order Created",
-			Description: "Order created successfully",
-			Link:        fmt.Sprintf("localhost:8081/order/%s", payloadMap["order_id"].(string)),
-			UserID:      payloadMap["user_id"].(string),
-		})
+		return notificationUseCase.CreateNotification(ctx, request)
 	})
 
 	if err != nil {
 		return fmt.Errorf("failed to register order notification handler: %w", err)
 	}
 
+	// Register cart update handler; carts are pushed as they are, not stored
+	err = consumer.RegisterHandler("carts-topic", func(msg domain.Message) error {
+		if msg.Value.MessageCode != string(domainnotification.Topic.CART_UPDATE) {
+			fmt.Printf("Skipping cart message with unknown code: %s\n", msg.Value.MessageCode)
+			return nil
+		}
+
+		payloadMap, ok := msg.Value.Payload.(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid cart update payload: %T", msg.Value.Payload)
+		}
+		userID, _ := payloadMap["user_id"].(string)
+		if userID == "" {
+			return fmt.Errorf("cart update without user_id")
+		}
+
+		userConnRepository := userconnrepository.NewMongoRepository(writeDB, readDB)
+		notificationHandler := websocket.NewWebSocketHandler(userConnRepository, wsServer)
+		notificationDispatcher := websocket.NewNotificationDispatcher(wsServer, "/notifications", notificationHandler)
+
+		return notificationDispatcher.DispatchCartUpdate(userID, map[string]any{
+			"reason": payloadMap["reason"],
+			"cart":   payloadMap["cart"],
+		})
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to register cart update handler: %w", err)
+	}
+
 	// Subscribe to all topics after registering handlers
 	if err := consumer.Subscribe(); err != nil {
 		return fmt.Errorf("failed to subscribe to topics: %w", err)
@@ -0,0 +1,57 @@
+package address
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxAddresses bounds the size of a customer's address book
+const MaxAddresses = 20
+
+// Address is an entry of a customer's address book
+type Address struct {
+	ID            *primitive.ObjectID `bson:"_id,omitempty"`
+	UserID        string              `bson:"user_id,omitempty"`
+	Label         string              `bson:"label,omitempty"`
+	RecipientName string              `bson:"recipient_name,omitempty"`
+	Phone         string              `bson:"phone,omitempty"`
+	Line1         string              `bson:"line1,omitempty"`
+	Line2         string              `bson:"line2,omitempty"`
+	City          string              `bson:"city,omitempty"`
+	Region        string              `bson:"region,omitempty"`
+	PostalCode    string              `bson:"postal_code,omitempty"`
+	CountryCode   string              `bson:"country_code,omitempty"`
+	IsDefault     bool                `bson:"is_default"`
+	CreatedAt     time.Time           `bson:"created_at,omitempty"`
+	UpdatedAt     time.Time           `bson:"updated_at,omitempty"`
+}
+
+// Normalize trims the fields and upper-cases the country code
+func (a *Address) Normalize() {
+	for _, field := range []*string{&a.Label, &a.RecipientName, &a.Phone, &a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode} {
+		*field = strings.TrimSpace(*field)
+	}
+	a.CountryCode = strings.ToUpper(strings.TrimSpace(a.CountryCode))
+}
+
+// Validate checks that the address can be delivered to
+func (a *Address) Validate() error {
+	switch {
+	case a.RecipientName == "":
+		return invalid("recipient name is required")
+	case a.Line1 == "":
+		return invalid("address line 1 is required")
+	case a.City == "":
+		return invalid("city is required")
+	case len(a.CountryCode) != 2:
+		return invalid("country code must be an ISO 3166-1 alpha-2 code")
+	}
+	for _, c := range a.CountryCode {
+		if c < 'A' || c > 'Z' {
+			return invalid("country code must be an ISO 3166-1 alpha-2 code")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAddress(t *testing.T) {
+	address := &Address{
+		RecipientName: " Jane Doe ",
+		Line1:         "1 Main Street",
+		City:          "Hanoi",
+		CountryCode:   "vn ",
+	}
+	address.Normalize()
+
+	assert.NoError(t, address.Validate())
+	assert.Equal(t, "Jane Doe", address.RecipientName)
+	assert.Equal(t, "VN", address.CountryCode)
+
+	address.CountryCode = "V1"
+	assert.ErrorIs(t, address.Validate(), ErrInvalidAddress)
+
+	address.CountryCode = "VN"
+	address.Line1 = ""
+	assert.ErrorIs(t, address.Validate(), ErrInvalidAddress)
+}
@@ -0,0 +1,17 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrAddressNotFound  = errors.New("address not found")
+	ErrInvalidAddress   = errors.New("invalid address")
+	ErrAddressBookFull  = errors.New("address book is full")
+	ErrInvalidAddressID = errors.New("invalid address ID")
+)
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidAddress, reason)
+}
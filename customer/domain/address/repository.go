@@ -0,0 +1,16 @@
+package address
+
+import "context"
+
+// Repository defines the interface for address data access. Addresses are
+// always looked up within the book of their user.
+type Repository interface {
+	GetAddress(ctx context.Context, userID, id string) (*Address, error)
+	GetAddresses(ctx context.Context, userID string) ([]*Address, error)
+	CreateAddress(ctx context.Context, address *Address) (*Address, error)
+	UpdateAddress(ctx context.Context, address *Address) error
+	DeleteAddress(ctx context.Context, userID, id string) error
+
+	// SetDefault makes the address the only default one of its user
+	SetDefault(ctx context.Context, userID, id string) error
+}
@@ -12,6 +12,8 @@ type Customer struct {
 	Name      string              `bson:"name,omitempty"`
 	Email     string              `bson:"email,omitempty"`
 	Phone     string              `bson:"phone,omitempty"`
+	// Tier is the loyalty tier of the customer, such as standard or gold
+	Tier      string              `bson:"tier,omitempty"`
 	CreatedAt time.Time           `bson:"created_at,omitempty"`
 	UpdatedAt time.Time           `bson:"updated_at,omitempty"`
 }
@@ -213,6 +213,19 @@ func (m *MongoAdapter[T]) Update(ctx context.Context, collection string, filter
 	return nil
 }
 
+// UpdateMany applies the update to every document matching the filter
+func (m *MongoAdapter[T]) UpdateMany(ctx context.Context, collection string, filter any, update any, opts ...*options.UpdateOptions) error {
+	if isEmptyFilter(filter) {
+		return fmt.Errorf("%w: update requires a non-empty filter", ErrEmptyFilter)
+	}
+
+	_, err := m.db.Collection(collection).UpdateMany(ctx, filter, update, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to update documents: %w", err)
+	}
+	return nil
+}
+
 func (m *MongoAdapter[T]) Delete(ctx context.Context, collection string, filter any, opts ...*options.DeleteOptions) error {
 	if isEmptyFilter(filter) {
 		return fmt.Errorf("%w: delete requires a non-empty filter", ErrEmptyFilter)
@@ -235,6 +248,14 @@ func (m *MongoAdapter[T]) Upsert(ctx context.Context, collection string, filter
 	return nil
 }
 
+// CreateIndexes creates the indexes on the collection, leaving existing identical indexes untouched
+func (m *MongoAdapter[T]) CreateIndexes(ctx context.Context, collection string, models ...mongo.IndexModel) error {
+	if _, err := m.db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	return nil
+}
+
 func (m *MongoAdapter[T]) Incr(ctx context.Context, collection string, filter any, field string, amount int64) error {
 	update := bson.M{
 		"$inc": bson.M{
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	customergrpchandler "github.com/DuongVu089x/interview/customer/api/grpc/customer"
+	"github.com/DuongVu089x/interview/customer/api/rest/address"
 	"github.com/DuongVu089x/interview/customer/api/rest/customer"
 	"github.com/DuongVu089x/interview/customer/api/rest/notification"
 	"github.com/DuongVu089x/interview/customer/application/consumer"
@@ -22,6 +23,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	pb "github.com/DuongVu089x/interview/customer/proto/customer"
+	addressrepository "github.com/DuongVu089x/interview/customer/repository/address"
 	userconnrepository "github.com/DuongVu089x/interview/customer/repository/user_connection"
 	"google.golang.org/grpc"
 )
@@ -151,6 +153,10 @@ func main() {
 		log.Fatalf("Failed to initialize main database: %v", err)
 		return
 	}
+	if err := addressrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create address indexes: %v", err)
+		return
+	}
 
 	readDB, err := initReadDB(cfg)
 	if err != nil {
@@ -197,6 +203,9 @@ func main() {
 	customerHandler := customer.NewRestHandler(appCtx)
 	customer.RegisterRoutes(e, customerHandler)
 
+	addressHandler := address.NewRestHandler(appCtx)
+	address.RegisterRoutes(e, addressHandler)
+
 	// Print routes for debugging
 	middleware.PrintRegisteredRoutes(e)
 
@@ -0,0 +1,129 @@
+package address
+
+import (
+	"context"
+	"errors"
+
+	domainaddress "github.com/DuongVu089x/interview/customer/domain/address"
+	"github.com/DuongVu089x/interview/customer/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter[*domainaddress.Address]
+}
+
+const (
+	databaseName   = "customers"
+	collectionName = "addresses"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainaddress.Repository {
+	baseAdapter := mongodb.NewBaseAdapter[*domainaddress.Address](writeDB, readDB, databaseName)
+	return &MongoRepository{
+		BaseAdapter: baseAdapter,
+	}
+}
+
+// addressFilter matches an address within the book of its user
+func addressFilter(userID, id string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domainaddress.ErrInvalidAddressID
+	}
+	return bson.M{"_id": objectID, "user_id": userID}, nil
+}
+
+// GetAddress reads from the write database so that an address is found right
+// after it was saved
+func (r *MongoRepository) GetAddress(ctx context.Context, userID, id string) (*domainaddress.Address, error) {
+	filter, err := addressFilter(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var address domainaddress.Address
+	if err := r.GetWriteDB().QueryOne(ctx, collectionName, filter, &address); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainaddress.ErrAddressNotFound
+		}
+		return nil, err
+	}
+	return &address, nil
+}
+
+// GetAddresses lists the addresses of a user, the default one first
+func (r *MongoRepository) GetAddresses(ctx context.Context, userID string) ([]*domainaddress.Address, error) {
+	addresses := []*domainaddress.Address{}
+	opts := options.Find().SetSort(bson.D{{Key: "is_default", Value: -1}, {Key: "created_at", Value: 1}})
+	err := r.GetWriteDB().Query(ctx, collectionName, bson.M{"user_id": userID}, &addresses, opts)
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (r *MongoRepository) CreateAddress(ctx context.Context, address *domainaddress.Address) (*domainaddress.Address, error) {
+	addresses, err := r.GetWriteDB().Insert(ctx, collectionName, address)
+	if err != nil {
+		return nil, err
+	}
+	return addresses[0], nil
+}
+
+func (r *MongoRepository) UpdateAddress(ctx context.Context, address *domainaddress.Address) error {
+	filter := bson.M{"_id": address.ID, "user_id": address.UserID}
+	update := bson.M{"$set": bson.M{
+		"label":          address.Label,
+		"recipient_name": address.RecipientName,
+		"phone":          address.Phone,
+		"line1":          address.Line1,
+		"line2":          address.Line2,
+		"city":           address.City,
+		"region":         address.Region,
+		"postal_code":    address.PostalCode,
+		"country_code":   address.CountryCode,
+		"updated_at":     address.UpdatedAt,
+	}}
+	return r.GetWriteDB().Update(ctx, collectionName, filter, update)
+}
+
+func (r *MongoRepository) DeleteAddress(ctx context.Context, userID, id string) error {
+	filter, err := addressFilter(userID, id)
+	if err != nil {
+		return err
+	}
+	return r.GetWriteDB().Delete(ctx, collectionName, filter)
+}
+
+// SetDefault clears the flag on the other addresses of the user before
+// setting it, so that a user has at most one default address
+func (r *MongoRepository) SetDefault(ctx context.Context, userID, id string) error {
+	filter, err := addressFilter(userID, id)
+	if err != nil {
+		return err
+	}
+
+	others := bson.M{"user_id": userID, "_id": bson.M{"$ne": filter["_id"]}, "is_default": true}
+	if err := r.GetWriteDB().UpdateMany(ctx, collectionName, others, bson.M{"$set": bson.M{"is_default": false}}); err != nil {
+		return err
+	}
+	return r.GetWriteDB().Update(ctx, collectionName, filter, bson.M{"$set": bson.M{"is_default": true}})
+}
+
+// EnsureIndexes creates the indexes of the addresses collection: addresses
+// listed by user, and at most one default address per user even when
+// SetDefault runs concurrently
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter[*domainaddress.Address](writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}}},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"is_default": true}),
+		},
+	)
+}
@@ -22,6 +22,31 @@ func NewNotificationDispatcher(wsServer *WSServer, routePath string, webSocketHa
 
 // DispatchToUser sends a notification to all active connections for a specific user
 func (n *NotificationDispatcher) DispatchToUser(userID string, notification *notificationapp.NotificationDTO) error {
+	// Create the notification message
+	outputMsg := &WSOutputMessage{
+		Topic: TOPIC_ANNOUNCEMENT,
+		Content: map[string]any{
+			"topic":       notification.Topic,
+			"title":       notification.Title,
+			"description": notification.Description,
+			"link":        notification.Link,
+		},
+	}
+
+	return n.pushToUser(userID, outputMsg)
+}
+
+// DispatchCartUpdate sends the new content of a user's cart to all active
+// connections of the user, keeping every open page in sync
+func (n *NotificationDispatcher) DispatchCartUpdate(userID string, content map[string]any) error {
+	return n.pushToUser(userID, &WSOutputMessage{
+		Topic:   TOPIC_CART_UPDATE,
+		Content: content,
+	})
+}
+
+// pushToUser sends a message to all active connections for a specific user
+func (n *NotificationDispatcher) pushToUser(userID string, outputMsg *WSOutputMessage) error {
 	// Get the websocket route
 	route := n.wsServer.GetRoute(n.routePath)
 	if route == nil {
@@ -37,17 +62,6 @@ func (n *NotificationDispatcher) DispatchToUser(userID string, notification *not
 		return err
 	}
 
-	// Create the notification message
-	outputMsg := &WSOutputMessage{
-		Topic: TOPIC_ANNOUNCEMENT,
-		Content: map[string]any{
-			"topic":       notification.Topic,
-			"title":       notification.Title,
-			"description": notification.Description,
-			"link":        notification.Link,
-		},
-	}
-
 	// Send notification to each active connection
 	for _, userConn := range userConns {
 		con, err := n.webSocketHandler.GetConnByID(int(userConn.ConnectionLocalID))
@@ -22,13 +22,15 @@ const (
 	TOPIC_ANNOUNCEMENT TopicEnumValue = "ANNOUNCEMENT"
 	// TOPIC_EVENT represents general event messages
 	TOPIC_EVENT TopicEnumValue = "EVENT"
+	// TOPIC_CART_UPDATE represents the new content of the user's cart
+	TOPIC_CART_UPDATE TopicEnumValue = "CART_UPDATE"
 )
 
 // IsValid checks if the topic is a valid enum value
 func (t TopicEnumValue) IsValid() bool {
 	switch t {
 	case TOPIC_NONE, TOPIC_CONNECTED, TOPIC_CONNECTION, TOPIC_PING,
-		TOPIC_AUTHORIZATION, TOPIC_ANNOUNCEMENT, TOPIC_EVENT:
+		TOPIC_AUTHORIZATION, TOPIC_ANNOUNCEMENT, TOPIC_EVENT, TOPIC_CART_UPDATE:
 		return true
 	}
 	return false
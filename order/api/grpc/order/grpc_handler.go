@@ -0,0 +1,264 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/api/validator"
+	orderusecase "github.com/DuongVu089x/interview/order/application/order"
+	"github.com/DuongVu089x/interview/order/application/watch"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+	pb "github.com/DuongVu089x/interview/order/proto/order"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcActor is recorded in the order history when a caller does not name itself
+const grpcActor = "grpc"
+
+// currentEvent marks the first WatchOrder response, which carries the order as
+// it was when the watch started
+const currentEvent = "CURRENT"
+
+// GrpcHandler serves the order API over gRPC with the same use case as the
+// REST handler
+type GrpcHandler struct {
+	pb.UnimplementedOrderServiceServer
+	appCtx       appctx.AppContext
+	orderUseCase *orderusecase.UseCase
+	watchHub     *watch.Hub
+	validator    *validator.CustomValidator
+}
+
+func NewGrpcHandler(appCtx appctx.AppContext, orderUseCase *orderusecase.UseCase, watchHub *watch.Hub) *GrpcHandler {
+	return &GrpcHandler{
+		appCtx:       appCtx,
+		orderUseCase: orderUseCase,
+		watchHub:     watchHub,
+		validator:    validator.NewCustomValidator(),
+	}
+}
+
+func (h *GrpcHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	order, err := h.orderUseCase.GetOrder(h.appCtx.WithContext(ctx), req.OrderId)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to get order")
+	}
+	return &pb.GetOrderResponse{Order: toPbOrder(order)}, nil
+}
+
+func (h *GrpcHandler) ListOrdersByUser(ctx context.Context, req *pb.ListOrdersByUserRequest) (*pb.ListOrdersByUserResponse, error) {
+	request := orderusecase.GetOrdersByUserIDRequest{
+		UserID:   req.UserId,
+		Statuses: req.Statuses,
+		SortBy:   req.SortBy,
+		Order:    req.Order,
+		Cursor:   req.Cursor,
+		Limit:    int(req.Limit),
+	}
+	if err := h.validator.Validate(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response, err := h.orderUseCase.GetOrdersByUserID(h.appCtx.WithContext(ctx), request)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to list orders")
+	}
+
+	orders := make([]*pb.Order, len(response.Orders))
+	for i := range response.Orders {
+		orders[i] = toPbOrder(&response.Orders[i])
+	}
+	return &pb.ListOrdersByUserResponse{
+		Orders:     orders,
+		NextCursor: response.NextCursor,
+	}, nil
+}
+
+func (h *GrpcHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	request := orderusecase.CreateOrderRequest{
+		UserID:      req.UserId,
+		Items:       make([]orderusecase.OrderItemRequest, len(req.Items)),
+		VoucherCode: req.VoucherCode,
+		AddressID:   req.AddressId,
+		Actor:       req.Actor,
+	}
+	for i, item := range req.Items {
+		request.Items[i] = orderusecase.OrderItemRequest{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+		}
+	}
+	if request.Actor == "" {
+		request.Actor = req.UserId
+	}
+	if err := h.validator.Validate(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	order, err := h.orderUseCase.CreateOrder(h.appCtx.WithContext(ctx), request)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to create order")
+	}
+	return &pb.CreateOrderResponse{Order: toPbOrder(order)}, nil
+}
+
+func (h *GrpcHandler) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	request := orderusecase.UpdateOrderStatusRequest{
+		Status:          req.Status,
+		Actor:           req.Actor,
+		ExpectedVersion: req.ExpectedVersion,
+	}
+	if request.Actor == "" {
+		request.Actor = grpcActor
+	}
+	if err := h.validator.Validate(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	order, err := h.orderUseCase.UpdateOrderStatus(h.appCtx.WithContext(ctx), req.OrderId, request)
+	if err != nil {
+		return nil, toStatusError(err, "Failed to update order status")
+	}
+	return &pb.UpdateOrderStatusResponse{Order: toPbOrder(order)}, nil
+}
+
+func (h *GrpcHandler) WatchOrder(req *pb.WatchOrderRequest, stream grpc.ServerStreamingServer[pb.WatchOrderResponse]) error {
+	ctx := stream.Context()
+
+	// Subscribe first so a change made while the order is read is not missed
+	sub := h.watchHub.Subscribe(req.OrderId)
+	defer sub.Close()
+
+	order, err := h.orderUseCase.GetOrder(h.appCtx.WithContext(ctx), req.OrderId)
+	if err != nil {
+		return toStatusError(err, "Failed to get order")
+	}
+
+	current := domainorder.OrderStatus(order.Status)
+	if err := stream.Send(&pb.WatchOrderResponse{
+		OrderId:    order.OrderID,
+		Status:     order.Status,
+		Event:      currentEvent,
+		OccurredAt: order.UpdatedAt.Format(time.RFC3339),
+		Order:      toPbOrder(order),
+	}); err != nil {
+		return err
+	}
+
+	for !current.IsTerminal() {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return status.Error(codes.Unavailable, "order watch fell behind, watch again to resume")
+			}
+			// Events arrive at least once and are not ordered across message
+			// codes, so skip any the order has already moved past
+			if !current.CanReach(update.Status) {
+				continue
+			}
+			current = update.Status
+
+			if err := stream.Send(&pb.WatchOrderResponse{
+				OrderId:    update.OrderID,
+				Status:     string(update.Status),
+				Event:      update.MessageCode,
+				OccurredAt: update.OccurredAt.Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// toStatusError maps use case errors to the gRPC codes matching the HTTP
+// statuses of the REST API
+func toStatusError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainorder.ErrOrderNotFound), errors.Is(err, domainorder.ErrCustomerNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domainorder.ErrInvalidStatus), errors.Is(err, domainorder.ErrInvalidFilter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domainorder.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domainorder.ErrInvalidStatusTransition), errors.Is(err, domainorder.ErrVersionMismatch),
+		errors.Is(err, domaininventory.ErrInsufficientStock), errors.Is(err, domainproduct.ErrProductUnavailable),
+		errors.Is(err, money.ErrCurrencyMismatch), errors.Is(err, domainorder.ErrAddressNotFound),
+		errors.Is(err, domainrule.ErrRulesViolated), domainvoucher.IsRejection(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		log.Printf("%s: %v", message, err)
+		return status.Errorf(codes.Internal, "%s: %v", message, err)
+	}
+}
+
+func toPbOrder(order *orderusecase.OrderResponse) *pb.Order {
+	items := make([]*pb.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &pb.OrderItem{
+			ProductId:        item.ProductID,
+			Name:             item.Name,
+			Quantity:         int32(item.Quantity),
+			Price:            toPbMoney(item.Price),
+			RefundedQuantity: int32(item.RefundedQuantity),
+		}
+	}
+
+	response := &pb.Order{
+		OrderId:        order.OrderID,
+		OrderCode:      order.OrderCode,
+		UserId:         order.UserID,
+		Items:          items,
+		Subtotal:       toPbMoney(order.Subtotal),
+		Discount:       toPbMoney(order.Discount),
+		VoucherCode:    order.VoucherCode,
+		TotalAmount:    toPbMoney(order.TotalAmount),
+		RefundedAmount: toPbMoney(order.RefundedAmount),
+		Status:         order.Status,
+		Version:        order.Version,
+		CreatedAt:      order.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      order.UpdatedAt.Format(time.RFC3339),
+	}
+	if cancellation := order.Cancellation; cancellation != nil {
+		response.Cancellation = &pb.Cancellation{
+			Reason:      cancellation.Reason,
+			Note:        cancellation.Note,
+			CancelledBy: cancellation.CancelledBy,
+			CancelledAt: cancellation.CancelledAt.Format(time.RFC3339),
+		}
+	}
+	if address := order.ShippingAddress; address != nil {
+		response.ShippingAddress = &pb.ShippingAddress{
+			AddressId:     address.AddressID,
+			Label:         address.Label,
+			RecipientName: address.RecipientName,
+			Phone:         address.Phone,
+			Line1:         address.Line1,
+			Line2:         address.Line2,
+			City:          address.City,
+			Region:        address.Region,
+			PostalCode:    address.PostalCode,
+			CountryCode:   address.CountryCode,
+		}
+	}
+	return response
+}
+
+func toPbMoney(amount money.Money) *pb.Money {
+	return &pb.Money{
+		Amount:   amount.Amount,
+		Currency: amount.Currency,
+	}
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import "github.com/labstack/echo/v4"
+
+// HeaderActor identifies who performs a request, such as a support agent or a
+// calling service. It is recorded in the order history.
+const HeaderActor = "X-Actor"
+
+// Actor returns the actor of the request, or fallback when none is given
+func Actor(c echo.Context, fallback string) string {
+	if actor := c.Request().Header.Get(HeaderActor); actor != "" {
+		return actor
+	}
+	return fallback
+}
@@ -10,9 +10,10 @@ import (
 // ConfigureCORS sets up CORS middleware for the Echo server
 func ConfigureCORS() echo.MiddlewareFunc {
 	return middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"http://localhost:3000", "*"},
-		AllowMethods: []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodOptions},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
-		MaxAge:       86400, // 24 hours
+		AllowOrigins:  []string{"http://localhost:3000", "*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, HeaderIdempotencyKey, HeaderActor, "X-Payment-Signature", "If-Match", "If-None-Match"},
+		ExposeHeaders: []string{"ETag", HeaderIdempotentReplayed},
+		MaxAge:        86400, // 24 hours
 	})
 }
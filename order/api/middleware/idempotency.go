@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	domainidempotency "github.com/DuongVu089x/interview/order/domain/idempotency"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// Idempotency returns a middleware that honours the Idempotency-Key header.
+// The first request with a key is executed and its response stored for ttl;
+// retries with the same body receive the stored response, while a different
+// body under the same key is rejected with 422.
+func Idempotency(repo domainidempotency.Repository, ttl time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key is too long")
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			// Keys are scoped to the route, while the hash covers the actual path so
+			// that a key reused for another resource is rejected, not replayed
+			storeKey := fmt.Sprintf("%s:%s:%s", c.Request().Method, c.Path(), key)
+			requestHash := hashRequest(c.Request().Method, c.Request().URL.Path, body)
+
+			existing, err := repo.Reserve(ctx, storeKey, &domainidempotency.Record{
+				RequestHash: requestHash,
+				Status:      domainidempotency.StatusProcessing,
+				CreatedAt:   time.Now(),
+			}, ttl)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check idempotency key")
+			}
+
+			if existing != nil {
+				if existing.RequestHash != requestHash {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request")
+				}
+				if existing.Status != domainidempotency.StatusCompleted {
+					return echo.NewHTTPError(http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+				}
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				return c.Blob(existing.StatusCode, existing.ContentType, existing.Body)
+			}
+
+			// Capture the response while it is written to the client
+			recorded := new(bytes.Buffer)
+			res := c.Response()
+			res.Writer = &bodyRecorder{ResponseWriter: res.Writer, body: recorded}
+
+			defer func() {
+				// Free the key if the handler panics so the client can retry
+				if r := recover(); r != nil {
+					_ = repo.Release(ctx, storeKey)
+					panic(r)
+				}
+			}()
+
+			err = next(c)
+			if err != nil {
+				// Let Echo render the error so that it can be recorded too
+				c.Error(err)
+			}
+
+			// Server errors are not stored so the client can retry them
+			if res.Status >= http.StatusInternalServerError {
+				if releaseErr := repo.Release(ctx, storeKey); releaseErr != nil {
+					fmt.Printf("Error releasing idempotency key %s: %v\n", key, releaseErr)
+				}
+				return nil
+			}
+
+			saveErr := repo.Save(ctx, storeKey, &domainidempotency.Record{
+				RequestHash: requestHash,
+				Status:      domainidempotency.StatusCompleted,
+				StatusCode:  res.Status,
+				ContentType: res.Header().Get(echo.HeaderContentType),
+				Body:        recorded.Bytes(),
+				CreatedAt:   time.Now(),
+			}, ttl)
+			if saveErr != nil {
+				fmt.Printf("Error saving idempotency record %s: %v\n", key, saveErr)
+			}
+			return nil
+		}
+	}
+}
+
+func hashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte(path))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder copies everything written to the response into body
+type bodyRecorder struct {
+	http.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
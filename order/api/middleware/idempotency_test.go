@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	domainidempotency "github.com/DuongVu089x/interview/order/domain/idempotency"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryRepository is an in-memory idempotency store
+type memoryRepository struct {
+	records map[string]*domainidempotency.Record
+}
+
+func (r *memoryRepository) Reserve(ctx context.Context, key string, record *domainidempotency.Record, ttl time.Duration) (*domainidempotency.Record, error) {
+	if existing, ok := r.records[key]; ok {
+		return existing, nil
+	}
+	r.records[key] = record
+	return nil, nil
+}
+
+func (r *memoryRepository) Save(ctx context.Context, key string, record *domainidempotency.Record, ttl time.Duration) error {
+	r.records[key] = record
+	return nil
+}
+
+func (r *memoryRepository) Release(ctx context.Context, key string) error {
+	delete(r.records, key)
+	return nil
+}
+
+func newIdempotentServer(calls *int, status int) *echo.Echo {
+	e := echo.New()
+	repo := &memoryRepository{records: map[string]*domainidempotency.Record{}}
+	e.POST("/order", func(c echo.Context) error {
+		*calls++
+		if status >= http.StatusInternalServerError {
+			return echo.NewHTTPError(status, "boom")
+		}
+		return c.JSON(status, map[string]int{"call": *calls})
+	}, Idempotency(repo, time.Hour))
+	return e
+}
+
+func doRequest(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/order", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	e := newIdempotentServer(&calls, http.StatusCreated)
+
+	first := doRequest(e, "key-1", `{"userId":"u1"}`)
+	second := doRequest(e, "key-1", `{"userId":"u1"}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(HeaderIdempotentReplayed))
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	calls := 0
+	e := newIdempotentServer(&calls, http.StatusCreated)
+
+	doRequest(e, "key-1", `{"userId":"u1"}`)
+	rec := doRequest(e, "key-1", `{"userId":"u2"}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	calls := 0
+	e := newIdempotentServer(&calls, http.StatusInternalServerError)
+
+	doRequest(e, "key-1", `{"userId":"u1"}`)
+	doRequest(e, "key-1", `{"userId":"u1"}`)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyWithoutKey(t *testing.T) {
+	calls := 0
+	e := newIdempotentServer(&calls, http.StatusCreated)
+
+	doRequest(e, "", `{"userId":"u1"}`)
+	doRequest(e, "", `{"userId":"u1"}`)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyRejectsKeyReusedForAnotherResource(t *testing.T) {
+	calls := 0
+	e := echo.New()
+	repo := &memoryRepository{records: map[string]*domainidempotency.Record{}}
+	e.POST("/order/:id/reorder", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]string{"source": c.Param("id")})
+	}, Idempotency(repo, time.Hour))
+
+	for _, path := range []string{"/order/1/reorder", "/order/2/reorder"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"userId":"u1"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIdempotencyKey, "key-1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if path == "/order/2/reorder" {
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		}
+	}
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PrintRegisteredRoutes prints all routes registered on the Echo server
+func PrintRegisteredRoutes(e *echo.Echo) {
+	routes := e.Routes()
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path == routes[j].Path {
+			return routes[i].Method < routes[j].Method
+		}
+		return routes[i].Path < routes[j].Path
+	})
+
+	fmt.Println("Registered routes:")
+	for _, route := range routes {
+		fmt.Printf("  %-7s %s\n", route.Method, route.Path)
+	}
+}
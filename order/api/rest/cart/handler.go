@@ -0,0 +1,166 @@
+package cart
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/DuongVu089x/interview/order/api/middleware"
+	"github.com/DuongVu089x/interview/order/api/validator"
+	cartusecase "github.com/DuongVu089x/interview/order/application/cart"
+	orderusecase "github.com/DuongVu089x/interview/order/application/order"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	"github.com/DuongVu089x/interview/order/config"
+	domaincart "github.com/DuongVu089x/interview/order/domain/cart"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+	cartrepository "github.com/DuongVu089x/interview/order/repository/cart"
+	idempotencyrepository "github.com/DuongVu089x/interview/order/repository/idempotency"
+	productrepository "github.com/DuongVu089x/interview/order/repository/product"
+	cartservice "github.com/DuongVu089x/interview/order/service/cart"
+	productservice "github.com/DuongVu089x/interview/order/service/product"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	appCtx      appctx.AppContext
+	cartUseCase *cartusecase.UseCase
+	validator   *validator.CustomValidator
+
+	// idempotency guards checkout against client retries
+	idempotency echo.MiddlewareFunc
+}
+
+func NewHandler(appCtx appctx.AppContext, cfg *config.Config, orderPlacer cartusecase.OrderPlacer) *Handler {
+	// Initialize cart repository and service
+	cartRepo := cartrepository.NewRedisRepository(appCtx.GetRedisClient())
+	cartService := cartservice.NewCartService(cartRepo, cfg.Cart.TTL)
+
+	// Initialize product catalog repository and service
+	productRepo := productrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	productService := productservice.NewProductService(productRepo)
+
+	// Initialize idempotency store for POST /cart/checkout
+	idempotencyRepo := idempotencyrepository.NewRedisRepository(appCtx.GetRedisClient())
+
+	return &Handler{
+		appCtx:      appCtx,
+		cartUseCase: cartusecase.NewCartUseCase(cartService, productService, orderPlacer, appCtx.GetKafkaProducer()),
+		validator:   validator.NewCustomValidator(),
+		idempotency: middleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL),
+	}
+}
+
+// GetCart handles retrieving the cart of a user, priced with the catalog
+func (h *Handler) GetCart(c echo.Context) error {
+	response, err := h.cartUseCase.GetCart(h.appCtx, c.Param("userId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get cart")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// AddItem handles adding a product to a cart
+func (h *Handler) AddItem(c echo.Context) error {
+	var req cartusecase.AddItemRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.cartUseCase.AddItem(h.appCtx, c.Param("userId"), req)
+	if err != nil {
+		return toHTTPError(err, "Failed to add item")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateItem handles changing the quantity of a product in a cart
+func (h *Handler) UpdateItem(c echo.Context) error {
+	var req cartusecase.UpdateItemRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.cartUseCase.UpdateItem(h.appCtx, c.Param("userId"), c.Param("productId"), req)
+	if err != nil {
+		return toHTTPError(err, "Failed to update item")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// RemoveItem handles removing a product from a cart
+func (h *Handler) RemoveItem(c echo.Context) error {
+	response, err := h.cartUseCase.RemoveItem(h.appCtx, c.Param("userId"), c.Param("productId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to remove item")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// ClearCart handles emptying a cart
+func (h *Handler) ClearCart(c echo.Context) error {
+	response, err := h.cartUseCase.ClearCart(h.appCtx, c.Param("userId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to clear cart")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// Checkout handles ordering the content of a cart
+func (h *Handler) Checkout(c echo.Context) error {
+	var req cartusecase.CheckoutRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, req.UserID)
+
+	response, err := h.cartUseCase.Checkout(h.appCtx, req)
+	if err != nil {
+		var violations *domainrule.ValidationError
+		switch {
+		case errors.As(err, &violations):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, orderusecase.ToRuleViolationsResponse(violations))
+		case errors.Is(err, domainorder.ErrCustomerNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Customer not found")
+		case errors.Is(err, domaininventory.ErrInsufficientStock):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, money.ErrCurrencyMismatch), errors.Is(err, domainorder.ErrAddressNotFound),
+			domainvoucher.IsRejection(err):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		default:
+			return toHTTPError(err, "Failed to check out")
+		}
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// toHTTPError maps cart errors to HTTP errors
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domaincart.ErrItemNotInCart):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, domaincart.ErrInvalidQuantity), errors.Is(err, domaincart.ErrCartFull):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, domaincart.ErrCartBusy):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, domaincart.ErrCartEmpty), errors.Is(err, domainproduct.ErrProductUnavailable):
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
@@ -0,0 +1,16 @@
+package cart
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	e.POST("/cart/checkout", handler.Checkout, handler.idempotency)
+
+	g := e.Group("/cart/:userId")
+	g.GET("", handler.GetCart)
+	g.DELETE("", handler.ClearCart)
+	g.POST("/items", handler.AddItem)
+	g.PUT("/items/:productId", handler.UpdateItem)
+	g.DELETE("/items/:productId", handler.RemoveItem)
+}
@@ -0,0 +1,114 @@
+package inventory
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DuongVu089x/interview/order/api/validator"
+	inventoryusecase "github.com/DuongVu089x/interview/order/application/inventory"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	inventoryrepository "github.com/DuongVu089x/interview/order/repository/inventory"
+	inventoryservice "github.com/DuongVu089x/interview/order/service/inventory"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	appCtx           appctx.AppContext
+	inventoryUseCase *inventoryusecase.UseCase
+	validator        *validator.CustomValidator
+}
+
+func NewHandler(appCtx appctx.AppContext) *Handler {
+	// Initialize inventory repository and service
+	inventoryRepo := inventoryrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	inventoryService := inventoryservice.NewInventoryService(inventoryRepo)
+
+	return &Handler{
+		appCtx:           appCtx,
+		inventoryUseCase: inventoryusecase.NewInventoryUseCase(inventoryService),
+		validator:        validator.NewCustomValidator(),
+	}
+}
+
+// GetStocks handles listing the stock level of every product
+func (h *Handler) GetStocks(c echo.Context) error {
+	response, err := h.inventoryUseCase.GetStocks(h.appCtx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stocks: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetStock handles retrieving the stock level of a product
+func (h *Handler) GetStock(c echo.Context) error {
+	response, err := h.inventoryUseCase.GetStock(h.appCtx, c.Param("productId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get stock")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// SetStock handles overwriting the available quantity of a product
+func (h *Handler) SetStock(c echo.Context) error {
+	var req inventoryusecase.SetStockRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.inventoryUseCase.SetStock(h.appCtx, c.Param("productId"), req)
+	if err != nil {
+		return toHTTPError(err, "Failed to set stock")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// AdjustStock handles adding to or removing from the available quantity of a product
+func (h *Handler) AdjustStock(c echo.Context) error {
+	var req inventoryusecase.AdjustStockRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.inventoryUseCase.AdjustStock(h.appCtx, c.Param("productId"), req)
+	if err != nil {
+		return toHTTPError(err, "Failed to adjust stock")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetReservationsByOrder handles listing the stock reservations of an order
+func (h *Handler) GetReservationsByOrder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	response, err := h.inventoryUseCase.GetReservationsByOrder(h.appCtx, orderID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get reservations: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domaininventory.ErrStockNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Stock not found")
+	case errors.Is(err, domaininventory.ErrInvalidQuantity):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, domaininventory.ErrInsufficientStock):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
@@ -0,0 +1,14 @@
+package inventory
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	g := e.Group("/admin/inventory")
+	g.GET("", handler.GetStocks)
+	g.GET("/:productId", handler.GetStock)
+	g.PUT("/:productId", handler.SetStock)
+	g.POST("/:productId/adjust", handler.AdjustStock)
+	g.GET("/reservations/order/:orderId", handler.GetReservationsByOrder)
+}
@@ -0,0 +1,58 @@
+package order
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+var errInvalidIfMatch = errors.New("If-Match must be * or a single ETag")
+
+// etag is the entity tag of an order at the given version
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set(headerETag, etag(version))
+}
+
+// ifMatchVersion returns the order version the client expects from If-Match,
+// or nil when the header is absent or "*"
+func ifMatchVersion(c echo.Context) (*int64, error) {
+	value := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	// If-Match uses strong comparison, so weak tags can never match
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+	return &version, nil
+}
+
+// notModified reports whether If-None-Match already names the current version
+func notModified(c echo.Context, version int64) bool {
+	current := etag(version)
+	for _, tag := range strings.Split(c.Request().Header.Get(headerIfNoneMatch), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
@@ -1,44 +1,104 @@
 package order
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/DuongVu089x/interview/order/api/middleware"
+	"github.com/DuongVu089x/interview/order/api/validator"
 	orderusecase "github.com/DuongVu089x/interview/order/application/order"
+	paymentusecase "github.com/DuongVu089x/interview/order/application/payment"
+	"github.com/DuongVu089x/interview/order/application/port"
+	ruleusecase "github.com/DuongVu089x/interview/order/application/rule"
+	sagausecase "github.com/DuongVu089x/interview/order/application/saga"
 	"github.com/DuongVu089x/interview/order/component/appctx"
-	idgenrepository "github.com/DuongVu089x/interview/order/repository/id_gen"
+	"github.com/DuongVu089x/interview/order/config"
+	domainidgen "github.com/DuongVu089x/interview/order/domain/id_gen"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	historyrepository "github.com/DuongVu089x/interview/order/repository/history"
+	idempotencyrepository "github.com/DuongVu089x/interview/order/repository/idempotency"
+	inventoryrepository "github.com/DuongVu089x/interview/order/repository/inventory"
 	orderrepository "github.com/DuongVu089x/interview/order/repository/order"
-	idgenservice "github.com/DuongVu089x/interview/order/service/id_gen"
+	outboxrepository "github.com/DuongVu089x/interview/order/repository/outbox"
+	paymentrepository "github.com/DuongVu089x/interview/order/repository/payment"
+	productrepository "github.com/DuongVu089x/interview/order/repository/product"
+	voucherrepository "github.com/DuongVu089x/interview/order/repository/voucher"
+	inventoryservice "github.com/DuongVu089x/interview/order/service/inventory"
 	orderservice "github.com/DuongVu089x/interview/order/service/order"
+	paymentservice "github.com/DuongVu089x/interview/order/service/payment"
+	productservice "github.com/DuongVu089x/interview/order/service/product"
+	voucherservice "github.com/DuongVu089x/interview/order/service/voucher"
 	"github.com/labstack/echo/v4"
 )
 
 type Handler struct {
 	appCtx       appctx.AppContext
 	orderUseCase *orderusecase.UseCase
-	validator    *CustomValidator
+	validator    *validator.CustomValidator
+
+	// idempotency guards order creation against client retries
+	idempotency echo.MiddlewareFunc
 }
 
-func NewHandler(appCtx appctx.AppContext) *Handler {
+func NewHandler(appCtx appctx.AppContext, cfg *config.Config, idgenService domainidgen.Service, orchestrator *sagausecase.Orchestrator, ruleEngine *ruleusecase.Engine, riskAssessor port.RiskAssessor) *Handler {
 	// Initialize order repository and service
 	orderRepo := orderrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
 	orderService := orderservice.NewOrderService(orderRepo)
 
-	// Initialize ID generator repository and service
-	idgenRepo := idgenrepository.NewMongoRepository(appCtx.GetMainDBConnection())
-	idgenService := idgenservice.NewIDGenService(idgenRepo)
+	// Initialize product catalog repository and service
+	productRepo := productrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	productService := productservice.NewProductService(productRepo)
+
+	// Initialize inventory repository and service
+	inventoryRepo := inventoryrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	inventoryService := inventoryservice.NewInventoryService(inventoryRepo)
+
+	// Initialize voucher repository and service
+	voucherRepo := voucherrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	voucherService := voucherservice.NewVoucherService(voucherRepo)
+
+	// Initialize outbox and history repositories and transaction manager
+	outboxRepo := outboxrepository.NewMongoRepository(appCtx.GetMainDBConnection())
+	historyRepo := historyrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	txManager := mongodb.NewTransactionManager(appCtx.GetMainDBConnection())
+
+	// Initialize payment authorization for placed orders and compensation for
+	// cancelled and refunded ones
+	paymentRepo := paymentrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	paymentService := paymentservice.NewPaymentService(paymentRepo)
+	paymentAuthorizer := paymentusecase.NewAuthorizer(paymentService, appCtx.GetPaymentGateway())
+	paymentCompensator := paymentusecase.NewCompensator(paymentService, appCtx.GetPaymentGateway(), appCtx.GetRefundProvider())
 
 	// Initialize order use case with all dependencies
-	orderUseCase := orderusecase.NewOrderUseCase(orderService, idgenService, appCtx.GetCustomerClient())
+	orderUseCase := orderusecase.NewOrderUseCase(orderService, productService, inventoryService, voucherService, idgenService, appCtx.GetCustomerClient(), outboxRepo, historyRepo, txManager, paymentAuthorizer, paymentCompensator, orchestrator, ruleEngine, riskAssessor)
+
+	// Initialize idempotency store for POST /order
+	idempotencyRepo := idempotencyrepository.NewRedisRepository(appCtx.GetRedisClient())
 
 	return &Handler{
 		appCtx:       appCtx,
 		orderUseCase: orderUseCase,
-		validator:    NewCustomValidator(),
+		validator:    validator.NewCustomValidator(),
+		idempotency:  middleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL),
 	}
 }
 
+// OrderUseCase exposes the order use case to handlers that change orders as a
+// side effect, such as payment webhooks
+func (h *Handler) OrderUseCase() *orderusecase.UseCase {
+	return h.orderUseCase
+}
+
 // CreateOrder handles order creation requests
 func (h *Handler) CreateOrder(c echo.Context) error {
 	var req orderusecase.CreateOrderRequest
@@ -49,15 +109,99 @@ func (h *Handler) CreateOrder(c echo.Context) error {
 	if err := h.validator.Validate(req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	req.Actor = middleware.Actor(c, req.UserID)
 
 	response, err := h.orderUseCase.CreateOrder(h.appCtx, req)
 	if err != nil {
-		if err.Error() == "customer not found" {
+		var violations *domainrule.ValidationError
+		switch {
+		case errors.As(err, &violations):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, orderusecase.ToRuleViolationsResponse(violations))
+		case errors.Is(err, domainorder.ErrCustomerNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Customer not found")
+		case errors.Is(err, domaininventory.ErrInsufficientStock):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, domainproduct.ErrProductUnavailable), errors.Is(err, money.ErrCurrencyMismatch),
+			errors.Is(err, domainorder.ErrAddressNotFound):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		case domainvoucher.IsRejection(err):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create order: "+err.Error())
+		}
+	}
+
+	setETag(c, response.Version)
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ValidateOrder handles checking an order request without placing the order.
+// Broken order rules are reported in the response body.
+func (h *Handler) ValidateOrder(c echo.Context) error {
+	var req orderusecase.CreateOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.orderUseCase.ValidateOrder(h.appCtx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrCustomerNotFound):
 			return echo.NewHTTPError(http.StatusNotFound, "Customer not found")
+		case errors.Is(err, domainproduct.ErrProductUnavailable), errors.Is(err, money.ErrCurrencyMismatch):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		case domainvoucher.IsRejection(err):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to validate order: "+err.Error())
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create order: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// Reorder handles placing a new order with the items of a past order
+func (h *Handler) Reorder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req orderusecase.ReorderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
 	}
 
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, req.UserID)
+
+	response, err := h.orderUseCase.Reorder(h.appCtx, orderID, req)
+	if err != nil {
+		var violations *domainrule.ValidationError
+		switch {
+		case errors.As(err, &violations):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, orderusecase.ToRuleViolationsResponse(violations))
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		case errors.Is(err, domainorder.ErrCustomerNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Customer not found")
+		case errors.Is(err, domaininventory.ErrInsufficientStock):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, domainorder.ErrNothingToReorder), errors.Is(err, domainproduct.ErrProductUnavailable),
+			errors.Is(err, money.ErrCurrencyMismatch), errors.Is(err, domainorder.ErrAddressNotFound),
+			domainvoucher.IsRejection(err):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to reorder: "+err.Error())
+		}
+	}
+
+	setETag(c, response.Order.Version)
 	return c.JSON(http.StatusCreated, response)
 }
 
@@ -70,40 +214,317 @@ func (h *Handler) GetOrder(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
 	}
 
-	order, err := h.orderUseCase.GetOrder(orderID)
+	order, err := h.orderUseCase.GetOrder(h.appCtx, orderID)
+	if err != nil {
+		if errors.Is(err, domainorder.ErrOrderNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get order")
+	}
+
+	setETag(c, order.Version)
+	if notModified(c, order.Version) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, order)
+}
+
+// GetOrderByCode handles looking up an order by the public code customers quote
+func (h *Handler) GetOrderByCode(c echo.Context) error {
+	order, err := h.orderUseCase.GetOrderByCode(h.appCtx, c.Param("code"))
 	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrInvalidOrderCode):
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid order code, please check it for typos")
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get order")
 	}
+
+	setETag(c, order.Version)
+	if notModified(c, order.Version) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(http.StatusOK, order)
 }
 
-// GetOrdersByUserID handles retrieval of all orders for a specific user
-func (h *Handler) GetOrdersByUserID(c echo.Context) error {
-	// Extract user ID from path parameter
-	userID := c.Param("userId")
-	fmt.Printf("GetOrdersByUserID handler called with userID: %s\n", userID)
+// UpdateOrderStatus handles moving an order through its status state machine
+func (h *Handler) UpdateOrderStatus(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req orderusecase.UpdateOrderStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, "unknown")
+	if req.ExpectedVersion, err = ifMatchVersion(c); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.orderUseCase.UpdateOrderStatus(h.appCtx, orderID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		case errors.Is(err, domainorder.ErrInvalidStatus):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, domainorder.ErrInvalidStatusTransition), errors.Is(err, domainorder.ErrVersionConflict):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, domainorder.ErrVersionMismatch):
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update order status: "+err.Error())
+		}
+	}
+
+	setETag(c, response.Version)
+	return c.JSON(http.StatusOK, response)
+}
 
-	if userID == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "User ID is required")
+// CancelOrder handles order cancellation requests
+func (h *Handler) CancelOrder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
 	}
 
-	// Extract optional status from query parameter
-	status := c.QueryParam("status")
-	fmt.Printf("GetOrdersByUserID status filter: %s\n", status)
+	var req orderusecase.CancelOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, "unknown")
+	if req.ExpectedVersion, err = ifMatchVersion(c); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.orderUseCase.CancelOrder(h.appCtx, orderID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		case errors.Is(err, domainorder.ErrInvalidCancelReason):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, domainorder.ErrOrderNotCancellable), errors.Is(err, domainorder.ErrVersionConflict):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, domainorder.ErrVersionMismatch):
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to cancel order: "+err.Error())
+		}
+	}
 
-	// Create the request
+	setETag(c, response.Version)
+	return c.JSON(http.StatusOK, response)
+}
+
+// RefundOrder handles refunds of whole orders or of some of their items
+func (h *Handler) RefundOrder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req orderusecase.RefundOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, "unknown")
+	if req.ExpectedVersion, err = ifMatchVersion(c); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.orderUseCase.RefundOrder(h.appCtx, orderID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		case errors.Is(err, domainorder.ErrInvalidRefund):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, domainorder.ErrRefundExceedsTotal):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, domainorder.ErrOrderNotRefundable), errors.Is(err, domainpayment.ErrNoCapturedPayment),
+			errors.Is(err, domainorder.ErrVersionConflict):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, domainorder.ErrVersionMismatch):
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to refund order: "+err.Error())
+		}
+	}
+
+	setETag(c, response.Order.Version)
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetHeldOrders handles retrieval of a page of the review queue, the orders
+// held because of their risk, oldest first
+func (h *Handler) GetHeldOrders(c echo.Context) error {
+	req := orderusecase.GetHeldOrdersRequest{
+		Cursor: c.QueryParam("cursor"),
+	}
+	if err := echo.QueryParamsBinder(c).Int("limit", &req.Limit).BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.orderUseCase.GetHeldOrders(h.appCtx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrInvalidFilter):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get held orders: "+err.Error())
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ApproveOrder handles the release of an order held for review
+func (h *Handler) ApproveOrder(c echo.Context) error {
+	return h.reviewOrder(c, h.orderUseCase.ApproveOrder, "Failed to approve order: ")
+}
+
+// RejectOrder handles the cancellation of an order held for review
+func (h *Handler) RejectOrder(c echo.Context) error {
+	return h.reviewOrder(c, h.orderUseCase.RejectOrder, "Failed to reject order: ")
+}
+
+// reviewOrder decodes a review of a held order and records it with review
+func (h *Handler) reviewOrder(c echo.Context, review func(appctx.AppContext, int64, orderusecase.ReviewOrderRequest) (*orderusecase.OrderResponse, error), failure string) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req orderusecase.ReviewOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, "unknown")
+	if req.ExpectedVersion, err = ifMatchVersion(c); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := review(h.appCtx, orderID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		case errors.Is(err, domainorder.ErrOrderNotOnHold), errors.Is(err, domainorder.ErrVersionConflict):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, domainorder.ErrVersionMismatch):
+			return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, failure+err.Error())
+		}
+	}
+
+	setETag(c, response.Version)
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetOrderHistory handles retrieval of the audit trail of an order
+func (h *Handler) GetOrderHistory(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	response, err := h.orderUseCase.GetOrderHistory(h.appCtx, orderID)
+	if err != nil {
+		if errors.Is(err, domainorder.ErrOrderNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get order history: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetOrdersByUserID handles retrieval of a page of orders for a specific user.
+// Statuses may be repeated or comma separated, dates are RFC 3339 and amounts
+// are in minor units of the currency.
+func (h *Handler) GetOrdersByUserID(c echo.Context) error {
 	req := orderusecase.GetOrdersByUserIDRequest{
-		UserID: userID,
-		Status: status,
+		UserID:   c.Param("userId"),
+		Currency: c.QueryParam("currency"),
+		SortBy:   c.QueryParam("sortBy"),
+		Order:    c.QueryParam("order"),
+		Cursor:   c.QueryParam("cursor"),
+	}
+	for _, status := range c.QueryParams()["status"] {
+		for _, s := range strings.Split(status, ",") {
+			if s != "" {
+				req.Statuses = append(req.Statuses, s)
+			}
+		}
+	}
+
+	err := echo.QueryParamsBinder(c).
+		Time("createdFrom", &req.CreatedFrom, time.RFC3339).
+		Time("createdTo", &req.CreatedTo, time.RFC3339).
+		Int("limit", &req.Limit).
+		BindError()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+	if req.MinAmount, err = queryInt64(c, "minAmount"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid minAmount")
+	}
+	if req.MaxAmount, err = queryInt64(c, "maxAmount"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid maxAmount")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
 	// Call the use case
-	response, err := h.orderUseCase.GetOrdersByUserID(req)
+	response, err := h.orderUseCase.GetOrdersByUserID(h.appCtx, req)
 	if err != nil {
-		fmt.Printf("GetOrdersByUserID error: %v\n", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get orders: "+err.Error())
+		switch {
+		case errors.Is(err, domainorder.ErrInvalidFilter), errors.Is(err, domainorder.ErrInvalidStatus):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get orders: "+err.Error())
+		}
 	}
 
-	fmt.Printf("GetOrdersByUserID success, returning %d orders\n", response.Count)
 	return c.JSON(http.StatusOK, response)
 }
+
+// queryInt64 parses an optional integer query parameter
+func queryInt64(c echo.Context, name string) (*int64, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
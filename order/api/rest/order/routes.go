@@ -6,6 +6,18 @@ import (
 
 func RegisterRoutes(e *echo.Echo, handler *Handler) {
 	e.GET("/order/:id", handler.GetOrder)
+	e.GET("/order/code/:code", handler.GetOrderByCode)
+	e.GET("/order/:id/history", handler.GetOrderHistory)
 	e.GET("/user/:userId/orders", handler.GetOrdersByUserID)
-	e.POST("/order", handler.CreateOrder)
+	e.POST("/order", handler.CreateOrder, handler.idempotency)
+	e.POST("/order/validate", handler.ValidateOrder)
+	e.PATCH("/order/:id/status", handler.UpdateOrderStatus)
+	e.POST("/order/:id/cancel", handler.CancelOrder)
+	e.POST("/order/:id/refunds", handler.RefundOrder)
+	e.POST("/order/:id/reorder", handler.Reorder, handler.idempotency)
+
+	g := e.Group("/admin/order-reviews")
+	g.GET("", handler.GetHeldOrders)
+	g.POST("/:id/approve", handler.ApproveOrder)
+	g.POST("/:id/reject", handler.RejectOrder)
 }
@@ -0,0 +1,179 @@
+package payment
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	paymentusecase "github.com/DuongVu089x/interview/order/application/payment"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	orderrepository "github.com/DuongVu089x/interview/order/repository/order"
+	paymentrepository "github.com/DuongVu089x/interview/order/repository/payment"
+	orderservice "github.com/DuongVu089x/interview/order/service/order"
+	paymentservice "github.com/DuongVu089x/interview/order/service/payment"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderPaymentSignature carries the gateway's signature of a webhook body
+const HeaderPaymentSignature = "X-Payment-Signature"
+
+// maxWebhookBody bounds the size of accepted gateway callbacks
+const maxWebhookBody = 64 << 10
+
+// webhookSimulator is implemented by gateways that can produce callbacks
+// locally, such as the fake provider
+type webhookSimulator interface {
+	SimulateWebhook(intentID string, amount money.Money, succeed bool) ([]byte, string, error)
+}
+
+type Handler struct {
+	appCtx         appctx.AppContext
+	paymentUseCase *paymentusecase.UseCase
+	paymentService domainpayment.Service
+}
+
+func NewHandler(appCtx appctx.AppContext, orderPayer paymentusecase.OrderPayer) *Handler {
+	// Initialize payment repository and service
+	paymentRepo := paymentrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	paymentService := paymentservice.NewPaymentService(paymentRepo)
+
+	// Initialize order repository and service
+	orderRepo := orderrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	orderService := orderservice.NewOrderService(orderRepo)
+
+	txManager := mongodb.NewTransactionManager(appCtx.GetMainDBConnection())
+
+	return &Handler{
+		appCtx:         appCtx,
+		paymentUseCase: paymentusecase.NewPaymentUseCase(paymentService, orderService, orderPayer, appCtx.GetPaymentGateway(), txManager),
+		paymentService: paymentService,
+	}
+}
+
+// CreatePaymentIntent handles starting the payment of an order
+func (h *Handler) CreatePaymentIntent(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	response, err := h.paymentUseCase.CreatePaymentIntent(h.appCtx, orderID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainorder.ErrOrderNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		case errors.Is(err, domainpayment.ErrOrderNotPayable):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create payment intent: "+err.Error())
+		}
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetPaymentsByOrder handles listing the payment intents of an order
+func (h *Handler) GetPaymentsByOrder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	response, err := h.paymentUseCase.GetPaymentsByOrder(h.appCtx, orderID)
+	if err != nil {
+		if errors.Is(err, domainorder.ErrOrderNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get payments: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetRefundsByOrder handles listing the refunds of an order
+func (h *Handler) GetRefundsByOrder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	response, err := h.paymentUseCase.GetRefundsByOrder(h.appCtx, orderID)
+	if err != nil {
+		if errors.Is(err, domainorder.ErrOrderNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get refunds: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// HandleWebhook handles payment callbacks from the gateway. The signature is
+// computed over the raw body, so the body is read as is rather than bound.
+func (h *Handler) HandleWebhook(c echo.Context) error {
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBody))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	response, err := h.paymentUseCase.HandleWebhook(h.appCtx, payload, c.Request().Header.Get(HeaderPaymentSignature))
+	if err != nil {
+		return toWebhookHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// SimulateWebhook lets developers settle a fake payment: it asks the gateway
+// for the callback it would send and processes it like a real one
+func (h *Handler) SimulateWebhook(c echo.Context) error {
+	simulator, ok := h.appCtx.GetPaymentGateway().(webhookSimulator)
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Payment gateway cannot simulate webhooks")
+	}
+
+	var succeed bool
+	switch c.Param("outcome") {
+	case "succeed":
+		succeed = true
+	case "fail":
+		succeed = false
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Outcome must be succeed or fail")
+	}
+
+	intent, err := h.paymentService.GetIntent(c.Request().Context(), c.Param("intentId"))
+	if err != nil {
+		if errors.Is(err, domainpayment.ErrIntentNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Payment intent not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get payment intent: "+err.Error())
+	}
+
+	payload, signature, err := simulator.SimulateWebhook(intent.IntentID, intent.Amount, succeed)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	response, err := h.paymentUseCase.HandleWebhook(h.appCtx, payload, signature)
+	if err != nil {
+		return toWebhookHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toWebhookHTTPError(err error) error {
+	switch {
+	case errors.Is(err, domainpayment.ErrInvalidSignature):
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domainpayment.ErrInvalidWebhook), errors.Is(err, domainpayment.ErrAmountMismatch):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, domainpayment.ErrIntentNotFound), errors.Is(err, domainorder.ErrOrderNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, domainpayment.ErrInvalidIntentTransition):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to process webhook: "+err.Error())
+	}
+}
@@ -0,0 +1,19 @@
+package payment
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the payment routes. The route settling fake
+// payments is unauthenticated, so it is only registered in dev mode.
+func RegisterRoutes(e *echo.Echo, handler *Handler, devMode bool) {
+	e.POST("/payments/webhook", handler.HandleWebhook)
+	e.POST("/order/:id/payments", handler.CreatePaymentIntent)
+	e.GET("/order/:id/payments", handler.GetPaymentsByOrder)
+	e.GET("/order/:id/refunds", handler.GetRefundsByOrder)
+
+	// Only gateways that run locally can settle payments on demand
+	if _, ok := handler.appCtx.GetPaymentGateway().(webhookSimulator); ok && devMode {
+		e.POST("/admin/payments/fake/:intentId/:outcome", handler.SimulateWebhook)
+	}
+}
@@ -0,0 +1,118 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DuongVu089x/interview/order/api/validator"
+	productusecase "github.com/DuongVu089x/interview/order/application/product"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+	productrepository "github.com/DuongVu089x/interview/order/repository/product"
+	productservice "github.com/DuongVu089x/interview/order/service/product"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	appCtx         appctx.AppContext
+	productUseCase *productusecase.UseCase
+	validator      *validator.CustomValidator
+}
+
+func NewHandler(appCtx appctx.AppContext) *Handler {
+	// Initialize product repository and service
+	productRepo := productrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	productService := productservice.NewProductService(productRepo)
+
+	return &Handler{
+		appCtx:         appCtx,
+		productUseCase: productusecase.NewProductUseCase(productService),
+		validator:      validator.NewCustomValidator(),
+	}
+}
+
+// CreateProduct handles product creation requests
+func (h *Handler) CreateProduct(c echo.Context) error {
+	var req productusecase.ProductRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.productUseCase.CreateProduct(h.appCtx, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to create product")
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// UpdateProduct handles product update requests
+func (h *Handler) UpdateProduct(c echo.Context) error {
+	var req productusecase.ProductRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+	req.SKU = c.Param("sku")
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.productUseCase.UpdateProduct(h.appCtx, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to update product")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteProduct handles product deletion requests
+func (h *Handler) DeleteProduct(c echo.Context) error {
+	if err := h.productUseCase.DeleteProduct(h.appCtx, c.Param("sku")); err != nil {
+		return toHTTPError(err, "Failed to delete product")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetProduct handles single product retrieval
+func (h *Handler) GetProduct(c echo.Context) error {
+	response, err := h.productUseCase.GetProduct(h.appCtx, c.Param("sku"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get product")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetProducts handles listing products, optionally filtered by the active flag
+func (h *Handler) GetProducts(c echo.Context) error {
+	var req productusecase.GetProductsRequest
+	if active := c.QueryParam("active"); active != "" {
+		value, err := strconv.ParseBool(active)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid active filter")
+		}
+		req.Active = &value
+	}
+
+	response, err := h.productUseCase.GetProducts(h.appCtx, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to get products")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainproduct.ErrProductNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Product not found")
+	case errors.Is(err, domainproduct.ErrProductAlreadyExists):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, domainproduct.ErrInvalidProduct):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
@@ -0,0 +1,15 @@
+package product
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	e.GET("/products", handler.GetProducts)
+	e.GET("/products/:sku", handler.GetProduct)
+
+	g := e.Group("/admin/products")
+	g.POST("", handler.CreateProduct)
+	g.PUT("/:sku", handler.UpdateProduct)
+	g.DELETE("/:sku", handler.DeleteProduct)
+}
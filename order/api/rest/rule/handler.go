@@ -0,0 +1,88 @@
+package rule
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/DuongVu089x/interview/order/api/validator"
+	ruleusecase "github.com/DuongVu089x/interview/order/application/rule"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	rulerepository "github.com/DuongVu089x/interview/order/repository/rule"
+	ruleservice "github.com/DuongVu089x/interview/order/service/rule"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	appCtx      appctx.AppContext
+	ruleUseCase *ruleusecase.UseCase
+	validator   *validator.CustomValidator
+}
+
+func NewHandler(appCtx appctx.AppContext, engine *ruleusecase.Engine) *Handler {
+	// Initialize rule repository and service
+	ruleRepo := rulerepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	ruleService := ruleservice.NewRuleService(ruleRepo)
+
+	return &Handler{
+		appCtx:      appCtx,
+		ruleUseCase: ruleusecase.NewRuleUseCase(ruleService, engine),
+		validator:   validator.NewCustomValidator(),
+	}
+}
+
+// SaveRule handles creating or replacing an order rule
+func (h *Handler) SaveRule(c echo.Context) error {
+	var req ruleusecase.RuleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+	req.RuleID = c.Param("ruleId")
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.ruleUseCase.SaveRule(h.appCtx, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to save order rule")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteRule handles order rule deletion
+func (h *Handler) DeleteRule(c echo.Context) error {
+	if err := h.ruleUseCase.DeleteRule(h.appCtx, c.Param("ruleId")); err != nil {
+		return toHTTPError(err, "Failed to delete order rule")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetRule handles single order rule retrieval
+func (h *Handler) GetRule(c echo.Context) error {
+	response, err := h.ruleUseCase.GetRule(h.appCtx, c.Param("ruleId"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get order rule")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetRules handles listing all order rules
+func (h *Handler) GetRules(c echo.Context) error {
+	response, err := h.ruleUseCase.GetRules(h.appCtx)
+	if err != nil {
+		return toHTTPError(err, "Failed to get order rules")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainrule.ErrRuleNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Order rule not found")
+	case errors.Is(err, domainrule.ErrInvalidRule):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
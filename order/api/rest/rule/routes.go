@@ -0,0 +1,13 @@
+package rule
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	g := e.Group("/admin/order-rules")
+	g.GET("", handler.GetRules)
+	g.GET("/:ruleId", handler.GetRule)
+	g.PUT("/:ruleId", handler.SaveRule)
+	g.DELETE("/:ruleId", handler.DeleteRule)
+}
@@ -0,0 +1,67 @@
+package saga
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	sagausecase "github.com/DuongVu089x/interview/order/application/saga"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+	sagarepository "github.com/DuongVu089x/interview/order/repository/saga"
+	sagaservice "github.com/DuongVu089x/interview/order/service/saga"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	appCtx      appctx.AppContext
+	sagaUseCase *sagausecase.UseCase
+}
+
+func NewHandler(appCtx appctx.AppContext) *Handler {
+	// Initialize saga repository and service
+	sagaRepo := sagarepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	sagaService := sagaservice.NewSagaService(sagaRepo)
+
+	return &Handler{
+		appCtx:      appCtx,
+		sagaUseCase: sagausecase.NewSagaUseCase(sagaService),
+	}
+}
+
+// GetSaga handles retrieving a saga with the progress of its steps
+func (h *Handler) GetSaga(c echo.Context) error {
+	response, err := h.sagaUseCase.GetSaga(h.appCtx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, domainsaga.ErrSagaNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Saga not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get saga: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetSagas handles listing the latest sagas, optionally by type and by
+// statuses, which may be repeated or comma separated
+func (h *Handler) GetSagas(c echo.Context) error {
+	filter := domainsaga.Filter{Type: c.QueryParam("type")}
+	for _, status := range c.QueryParams()["status"] {
+		for _, s := range strings.Split(status, ",") {
+			if s != "" {
+				filter.Statuses = append(filter.Statuses, domainsaga.Status(s))
+			}
+		}
+	}
+	if err := echo.QueryParamsBinder(c).Int("limit", &filter.Limit).BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid limit")
+	}
+
+	response, err := h.sagaUseCase.GetSagas(h.appCtx, filter)
+	if err != nil {
+		if errors.Is(err, domainsaga.ErrInvalidSagaFilter) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get sagas: "+err.Error())
+	}
+	return c.JSON(http.StatusOK, response)
+}
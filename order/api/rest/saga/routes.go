@@ -0,0 +1,11 @@
+package saga
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	g := e.Group("/admin/sagas")
+	g.GET("", handler.GetSagas)
+	g.GET("/:id", handler.GetSaga)
+}
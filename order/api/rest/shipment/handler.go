@@ -0,0 +1,161 @@
+package shipment
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/DuongVu089x/interview/order/api/middleware"
+	"github.com/DuongVu089x/interview/order/api/validator"
+	shipmentusecase "github.com/DuongVu089x/interview/order/application/shipment"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	"github.com/DuongVu089x/interview/order/config"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	idempotencyrepository "github.com/DuongVu089x/interview/order/repository/idempotency"
+	orderrepository "github.com/DuongVu089x/interview/order/repository/order"
+	shipmentrepository "github.com/DuongVu089x/interview/order/repository/shipment"
+	orderservice "github.com/DuongVu089x/interview/order/service/order"
+	shipmentservice "github.com/DuongVu089x/interview/order/service/shipment"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderCarrierSignature carries the carrier's signature of a tracking update
+const HeaderCarrierSignature = "X-Carrier-Signature"
+
+// maxTrackingBody bounds the size of accepted carrier callbacks
+const maxTrackingBody = 64 << 10
+
+// trackingSimulator is implemented by carriers that can produce tracking
+// updates locally, such as the fake carrier
+type trackingSimulator interface {
+	SimulateTracking(trackingNumber string) ([]byte, string, error)
+}
+
+type Handler struct {
+	appCtx          appctx.AppContext
+	shipmentUseCase *shipmentusecase.UseCase
+	validator       *validator.CustomValidator
+
+	// idempotency guards shipment creation against client retries
+	idempotency echo.MiddlewareFunc
+}
+
+func NewHandler(appCtx appctx.AppContext, cfg *config.Config, orderFulfiller shipmentusecase.OrderFulfiller) *Handler {
+	// Initialize shipment repository and service
+	shipmentRepo := shipmentrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	shipmentService := shipmentservice.NewShipmentService(shipmentRepo)
+
+	// Initialize order repository and service
+	orderRepo := orderrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	orderService := orderservice.NewOrderService(orderRepo)
+
+	txManager := mongodb.NewTransactionManager(appCtx.GetMainDBConnection())
+
+	// Initialize idempotency store for POST /order/:id/shipments
+	idempotencyRepo := idempotencyrepository.NewRedisRepository(appCtx.GetRedisClient())
+
+	return &Handler{
+		appCtx:          appCtx,
+		shipmentUseCase: shipmentusecase.NewShipmentUseCase(shipmentService, orderService, orderFulfiller, appCtx.GetCarrier(), txManager),
+		validator:       validator.NewCustomValidator(),
+		idempotency:     middleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL),
+	}
+}
+
+// CreateShipment handles shipping items of an order
+func (h *Handler) CreateShipment(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	var req shipmentusecase.CreateShipmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	req.Actor = middleware.Actor(c, "unknown")
+
+	response, err := h.shipmentUseCase.CreateShipment(h.appCtx, orderID, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to create shipment")
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetShipmentsByOrder handles listing the shipments of an order
+func (h *Handler) GetShipmentsByOrder(c echo.Context) error {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	response, err := h.shipmentUseCase.GetShipmentsByOrder(h.appCtx, orderID)
+	if err != nil {
+		return toHTTPError(err, "Failed to get shipments")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// TrackShipment handles tracking updates posted by carriers. The signature is
+// computed over the raw body, so the body is read as is rather than bound.
+func (h *Handler) TrackShipment(c echo.Context) error {
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxTrackingBody))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	response, err := h.shipmentUseCase.TrackShipment(h.appCtx, payload, c.Request().Header.Get(HeaderCarrierSignature))
+	if err != nil {
+		return toHTTPError(err, "Failed to track shipment")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// SimulateTracking lets developers move a fake parcel along: it asks the
+// carrier for the update of its next scripted scan and processes it like a
+// real one
+func (h *Handler) SimulateTracking(c echo.Context) error {
+	simulator, ok := h.appCtx.GetCarrier().(trackingSimulator)
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Carrier cannot simulate tracking updates")
+	}
+
+	payload, signature, err := simulator.SimulateTracking(c.Param("trackingNumber"))
+	if err != nil {
+		if errors.Is(err, domainshipment.ErrShipmentNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	response, err := h.shipmentUseCase.TrackShipment(h.appCtx, payload, signature)
+	if err != nil {
+		return toHTTPError(err, "Failed to track shipment")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainshipment.ErrInvalidSignature):
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domainorder.ErrOrderNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Order not found")
+	case errors.Is(err, domainshipment.ErrShipmentNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Shipment not found")
+	case errors.Is(err, domainshipment.ErrInvalidAllocation), errors.Is(err, domainshipment.ErrInvalidTrackingEvent):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, domainshipment.ErrOrderNotShippable), errors.Is(err, domainshipment.ErrNothingToShip),
+		errors.Is(err, domainorder.ErrVersionConflict), errors.Is(err, domainshipment.ErrVersionConflict):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
@@ -0,0 +1,18 @@
+package shipment
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterRoutes registers the shipment routes. The route moving fake parcels
+// along is unauthenticated, so it is only registered in dev mode.
+func RegisterRoutes(e *echo.Echo, handler *Handler, devMode bool) {
+	e.POST("/order/:id/shipments", handler.CreateShipment, handler.idempotency)
+	e.GET("/order/:id/shipments", handler.GetShipmentsByOrder)
+	e.POST("/shipments/tracking", handler.TrackShipment)
+
+	// Only carriers that run locally can report scans on demand
+	if _, ok := handler.appCtx.GetCarrier().(trackingSimulator); ok && devMode {
+		e.POST("/admin/shipments/fake/:trackingNumber/advance", handler.SimulateTracking)
+	}
+}
@@ -0,0 +1,100 @@
+package voucher
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/DuongVu089x/interview/order/api/validator"
+	voucherusecase "github.com/DuongVu089x/interview/order/application/voucher"
+	"github.com/DuongVu089x/interview/order/component/appctx"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+	voucherrepository "github.com/DuongVu089x/interview/order/repository/voucher"
+	voucherservice "github.com/DuongVu089x/interview/order/service/voucher"
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	appCtx         appctx.AppContext
+	voucherUseCase *voucherusecase.UseCase
+	validator      *validator.CustomValidator
+}
+
+func NewHandler(appCtx appctx.AppContext) *Handler {
+	// Initialize voucher repository and service
+	voucherRepo := voucherrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	voucherService := voucherservice.NewVoucherService(voucherRepo)
+
+	return &Handler{
+		appCtx:         appCtx,
+		voucherUseCase: voucherusecase.NewVoucherUseCase(voucherService),
+		validator:      validator.NewCustomValidator(),
+	}
+}
+
+// CreateVoucher handles voucher creation requests
+func (h *Handler) CreateVoucher(c echo.Context) error {
+	var req voucherusecase.VoucherRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.voucherUseCase.CreateVoucher(h.appCtx, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to create voucher")
+	}
+	return c.JSON(http.StatusCreated, response)
+}
+
+// UpdateVoucher handles voucher update requests
+func (h *Handler) UpdateVoucher(c echo.Context) error {
+	var req voucherusecase.VoucherRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+	req.Code = c.Param("code")
+
+	if err := h.validator.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	response, err := h.voucherUseCase.UpdateVoucher(h.appCtx, req)
+	if err != nil {
+		return toHTTPError(err, "Failed to update voucher")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetVoucher handles single voucher retrieval
+func (h *Handler) GetVoucher(c echo.Context) error {
+	response, err := h.voucherUseCase.GetVoucher(h.appCtx, c.Param("code"))
+	if err != nil {
+		return toHTTPError(err, "Failed to get voucher")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetVouchers handles listing all vouchers
+func (h *Handler) GetVouchers(c echo.Context) error {
+	response, err := h.voucherUseCase.GetVouchers(h.appCtx)
+	if err != nil {
+		return toHTTPError(err, "Failed to get vouchers")
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func toHTTPError(err error, message string) error {
+	switch {
+	case errors.Is(err, domainvoucher.ErrVoucherNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Voucher not found")
+	case errors.Is(err, domainvoucher.ErrVoucherAlreadyExists):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, domainvoucher.ErrInvalidVoucher):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
@@ -0,0 +1,13 @@
+package voucher
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterRoutes(e *echo.Echo, handler *Handler) {
+	g := e.Group("/admin/vouchers")
+	g.GET("", handler.GetVouchers)
+	g.POST("", handler.CreateVoucher)
+	g.GET("/:code", handler.GetVoucher)
+	g.PUT("/:code", handler.UpdateVoucher)
+}
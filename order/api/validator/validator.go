@@ -1,4 +1,4 @@
-package order
+package validator
 
 import (
 	validator "github.com/go-playground/validator/v10"
@@ -0,0 +1,66 @@
+package cart
+
+import (
+	"time"
+
+	orderusecase "github.com/DuongVu089x/interview/order/application/order"
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// Reasons of a CART_UPDATE event
+const (
+	CartItemAdded   = "item_added"
+	CartItemUpdated = "item_updated"
+	CartItemRemoved = "item_removed"
+	CartCleared     = "cleared"
+	CartCheckedOut  = "checked_out"
+)
+
+// AddItemRequest defines the payload for adding a product to a cart
+type AddItemRequest struct {
+	ProductID string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// UpdateItemRequest defines the payload for changing the quantity of a product
+// in a cart; zero removes it
+type UpdateItemRequest struct {
+	Quantity *int `json:"quantity" validate:"required,gte=0"`
+}
+
+// CheckoutRequest defines the payload for ordering the content of a cart
+type CheckoutRequest struct {
+	UserID      string `json:"userId" validate:"required"`
+	VoucherCode string `json:"voucherCode,omitempty" validate:"omitempty"`
+	AddressID   string `json:"addressId,omitempty" validate:"omitempty"`
+
+	// Actor is who places the order, recorded in the order history
+	Actor string `json:"-"`
+}
+
+type CartItemDTO struct {
+	ProductID string      `json:"productId"`
+	Name      string      `json:"name,omitempty"`
+	Quantity  int         `json:"quantity"`
+	Price     money.Money `json:"price"`
+	LineTotal money.Money `json:"lineTotal"`
+	// Available is false for products no longer sold; they are left out of
+	// the subtotal and fail the checkout until removed
+	Available bool      `json:"available"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+type CartResponse struct {
+	UserID    string        `json:"userId"`
+	Items     []CartItemDTO `json:"items"`
+	ItemCount int           `json:"itemCount"`
+	Subtotal  money.Money   `json:"subtotal"`
+	Version   int64         `json:"version"`
+	UpdatedAt time.Time     `json:"updatedAt,omitempty"`
+}
+
+type CheckoutResponse struct {
+	Order orderusecase.OrderResponse `json:"order"`
+	// Cart holds what is left in the cart, such as items added during checkout
+	Cart CartResponse `json:"cart"`
+}
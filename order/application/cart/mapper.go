@@ -0,0 +1,43 @@
+package cart
+
+import (
+	domaincart "github.com/DuongVu089x/interview/order/domain/cart"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+)
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+// ToResponse prices the cart with the catalog. Products of another currency
+// than the first one priced are shown but left out of the subtotal.
+func (m *Mapper) ToResponse(cart *domaincart.Cart, catalog map[string]domainproduct.Product) CartResponse {
+	response := CartResponse{
+		UserID:    cart.UserID,
+		Items:     make([]CartItemDTO, 0, len(cart.Items)),
+		Version:   cart.Version,
+		UpdatedAt: cart.UpdatedAt,
+	}
+
+	for _, item := range cart.Items {
+		dto := CartItemDTO{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			AddedAt:   item.AddedAt,
+		}
+		if product, ok := catalog[item.ProductID]; ok {
+			dto.Name = product.Name
+			dto.Price = product.Price
+			dto.LineTotal = product.Price.Multiply(item.Quantity)
+			dto.Available = product.Active
+		}
+		if dto.Available {
+			if subtotal, err := response.Subtotal.Add(dto.LineTotal); err == nil {
+				response.Subtotal = subtotal
+			}
+		}
+
+		response.Items = append(response.Items, dto)
+		response.ItemCount += item.Quantity
+	}
+	return response
+}
@@ -0,0 +1,204 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	orderusecase "github.com/DuongVu089x/interview/order/application/order"
+	"github.com/DuongVu089x/interview/order/application/port"
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	"github.com/DuongVu089x/interview/order/domain"
+	domaincart "github.com/DuongVu089x/interview/order/domain/cart"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+)
+
+const cartsTopic = "carts-topic"
+
+// OrderPlacer creates orders, see orderusecase.UseCase.CreateOrder
+type OrderPlacer interface {
+	CreateOrder(ctx appcontext.AppContext, req orderusecase.CreateOrderRequest) (*orderusecase.OrderResponse, error)
+}
+
+type UseCase struct {
+	mapper         *Mapper
+	cartService    domaincart.Service
+	productService domainproduct.Service
+	orderPlacer    OrderPlacer
+
+	// producer publishes CART_UPDATE events straight to the broker: they only
+	// keep open pages in sync, so a lost event is caught up on the next read
+	producer port.MessageProducer
+}
+
+func NewCartUseCase(
+	cartService domaincart.Service,
+	productService domainproduct.Service,
+	orderPlacer OrderPlacer,
+	producer port.MessageProducer,
+) *UseCase {
+	return &UseCase{
+		mapper:         &Mapper{},
+		cartService:    cartService,
+		productService: productService,
+		orderPlacer:    orderPlacer,
+		producer:       producer,
+	}
+}
+
+func (uc *UseCase) GetCart(ctx appcontext.AppContext, userID string) (*CartResponse, error) {
+	cart, err := uc.cartService.GetCart(ctx.GetDefaultContext(), userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toResponse(ctx.GetDefaultContext(), cart)
+}
+
+// AddItem adds a product that is currently sold to the cart
+func (uc *UseCase) AddItem(ctx appcontext.AppContext, userID string, req AddItemRequest) (*CartResponse, error) {
+	product, err := uc.productService.GetProduct(ctx.GetDefaultContext(), req.ProductID)
+	if err != nil {
+		if errors.Is(err, domainproduct.ErrProductNotFound) {
+			return nil, fmt.Errorf("%w: %s", domainproduct.ErrProductUnavailable, req.ProductID)
+		}
+		return nil, err
+	}
+	if !product.Active {
+		return nil, fmt.Errorf("%w: %s", domainproduct.ErrProductUnavailable, req.ProductID)
+	}
+
+	cart, err := uc.cartService.AddItem(ctx.GetDefaultContext(), userID, req.ProductID, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	return uc.changed(ctx.GetDefaultContext(), cart, CartItemAdded)
+}
+
+func (uc *UseCase) UpdateItem(ctx appcontext.AppContext, userID, productID string, req UpdateItemRequest) (*CartResponse, error) {
+	cart, err := uc.cartService.SetQuantity(ctx.GetDefaultContext(), userID, productID, *req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := CartItemUpdated
+	if *req.Quantity == 0 {
+		reason = CartItemRemoved
+	}
+	return uc.changed(ctx.GetDefaultContext(), cart, reason)
+}
+
+func (uc *UseCase) RemoveItem(ctx appcontext.AppContext, userID, productID string) (*CartResponse, error) {
+	cart, err := uc.cartService.RemoveItem(ctx.GetDefaultContext(), userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.changed(ctx.GetDefaultContext(), cart, CartItemRemoved)
+}
+
+func (uc *UseCase) ClearCart(ctx appcontext.AppContext, userID string) (*CartResponse, error) {
+	cart, err := uc.cartService.Clear(ctx.GetDefaultContext(), userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.changed(ctx.GetDefaultContext(), cart, CartCleared)
+}
+
+// Checkout orders the content of the cart. Prices, stock and the voucher are
+// checked by the order placement; the ordered quantities are then taken out
+// of the cart, keeping anything added in the meantime.
+func (uc *UseCase) Checkout(ctx appcontext.AppContext, req CheckoutRequest) (*CheckoutResponse, error) {
+	cart, err := uc.cartService.GetCart(ctx.GetDefaultContext(), req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if cart.IsEmpty() {
+		return nil, domaincart.ErrCartEmpty
+	}
+
+	items := make([]orderusecase.OrderItemRequest, 0, len(cart.Items))
+	ordered := make(map[string]int, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, orderusecase.OrderItemRequest{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+		ordered[item.ProductID] = item.Quantity
+	}
+
+	order, err := uc.orderPlacer.CreateOrder(ctx, orderusecase.CreateOrderRequest{
+		UserID:      req.UserID,
+		Items:       items,
+		VoucherCode: req.VoucherCode,
+		AddressID:   req.AddressID,
+		Actor:       req.Actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The order stands even if the cart cannot be emptied
+	response := &CheckoutResponse{Order: *order}
+	cart, err = uc.cartService.Subtract(ctx.GetDefaultContext(), req.UserID, ordered)
+	if err != nil {
+		log.Printf("Failed to empty cart of user %s after order %d: %v", req.UserID, order.OrderID, err)
+		return response, nil
+	}
+
+	cartResponse, err := uc.changed(ctx.GetDefaultContext(), cart, CartCheckedOut)
+	if err != nil {
+		log.Printf("Failed to announce cart of user %s after order %d: %v", req.UserID, order.OrderID, err)
+		return response, nil
+	}
+	response.Cart = *cartResponse
+	return response, nil
+}
+
+// changed prices the cart and announces its new content
+func (uc *UseCase) changed(ctx context.Context, cart *domaincart.Cart, reason string) (*CartResponse, error) {
+	response, err := uc.toResponse(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	message := domain.Message{
+		Key:   cart.UserID,
+		Topic: cartsTopic,
+		Value: domain.MessageValue{
+			Meta: &domain.MetaData{
+				MessageID: fmt.Sprintf("CART_UPDATE_%s_%d", cart.UserID, cart.Version),
+				ServiceID: "order-service",
+			},
+			MessageCode: "CART_UPDATE",
+			Payload: map[string]any{
+				"user_id": cart.UserID,
+				"reason":  reason,
+				"cart":    response,
+			},
+		},
+	}
+	if err := uc.producer.Publish(message); err != nil {
+		log.Printf("Failed to publish cart update of user %s: %v", cart.UserID, err)
+	}
+	return response, nil
+}
+
+func (uc *UseCase) toResponse(ctx context.Context, cart *domaincart.Cart) (*CartResponse, error) {
+	if cart.IsEmpty() {
+		response := uc.mapper.ToResponse(cart, nil)
+		return &response, nil
+	}
+
+	skus := make([]string, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		skus = append(skus, item.ProductID)
+	}
+
+	catalog, err := uc.productService.GetProductsBySKUs(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	response := uc.mapper.ToResponse(cart, catalog)
+	return &response, nil
+}
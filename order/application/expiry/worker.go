@@ -0,0 +1,102 @@
+package expiry
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+)
+
+// OrderExpirer expires a single pending order created before createdBefore,
+// reporting false when the order did not need to expire any more
+type OrderExpirer interface {
+	ExpireOrder(ctx context.Context, orderID int64, createdBefore time.Time) (bool, error)
+}
+
+// WorkerConfig holds the schedule of the pending order expiry worker
+type WorkerConfig struct {
+	Interval  time.Duration // How often pending orders are checked
+	TTL       time.Duration // How long an order may stay pending
+	BatchSize int           // Maximum orders expired per run
+}
+
+// Worker expires orders left pending for longer than the TTL. Replicas may run
+// it concurrently: every order is expired under its version check, so an
+// order claimed by another replica is skipped rather than expired twice.
+type Worker struct {
+	orderService domainorder.Service
+	expirer      OrderExpirer
+	config       WorkerConfig
+}
+
+func NewWorker(orderService domainorder.Service, expirer OrderExpirer, config WorkerConfig) *Worker {
+	if config.Interval == 0 {
+		config.Interval = 1 * time.Minute
+	}
+	if config.TTL == 0 {
+		config.TTL = 30 * time.Minute
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 100
+	}
+
+	return &Worker{
+		orderService: orderService,
+		expirer:      expirer,
+		config:       config,
+	}
+}
+
+// Start runs the worker until the context is cancelled
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := w.ExpireDue(ctx)
+			if err != nil {
+				log.Printf("Order expiry error: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("Order expiry run expired %d pending orders", expired)
+			}
+		}
+	}
+}
+
+// ExpireDue expires up to one batch of orders pending for longer than the TTL
+// and returns how many it expired. Orders approved after a review count from
+// their approval, so the batch only holds orders that are due.
+func (w *Worker) ExpireDue(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-w.config.TTL)
+
+	orders, _, err := w.orderService.GetOrders(ctx, domainorder.Filter{
+		Statuses:      []domainorder.OrderStatus{domainorder.StatusPending},
+		PendingBefore: cutoff,
+		SortBy:        domainorder.SortByCreatedAt,
+		Ascending:     true,
+		Limit:         w.config.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, order := range orders {
+		ok, err := w.expirer.ExpireOrder(ctx, order.OrderID, cutoff)
+		if err != nil {
+			// Keep going: one broken order must not hold back the others
+			log.Printf("Failed to expire order %d: %v", order.OrderID, err)
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,50 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOrderService lists the pending orders it holds; other methods are unused
+type fakeOrderService struct {
+	domainorder.Service
+	orders []domainorder.Order
+	filter domainorder.Filter
+}
+
+func (s *fakeOrderService) GetOrders(ctx context.Context, filter domainorder.Filter) ([]domainorder.Order, *domainorder.Cursor, error) {
+	s.filter = filter
+	return s.orders, nil, nil
+}
+
+// fakeExpirer expires every order except those another replica already took
+type fakeExpirer struct {
+	taken  map[int64]bool
+	broken map[int64]bool
+}
+
+func (e *fakeExpirer) ExpireOrder(ctx context.Context, orderID int64, createdBefore time.Time) (bool, error) {
+	if e.broken[orderID] {
+		return false, errors.New("boom")
+	}
+	return !e.taken[orderID], nil
+}
+
+func TestExpireDue(t *testing.T) {
+	service := &fakeOrderService{orders: []domainorder.Order{{OrderID: 1}, {OrderID: 2}, {OrderID: 3}, {OrderID: 4}}}
+	expirer := &fakeExpirer{taken: map[int64]bool{2: true}, broken: map[int64]bool{3: true}}
+	worker := NewWorker(service, expirer, WorkerConfig{TTL: time.Hour, BatchSize: 10})
+
+	expired, err := worker.ExpireDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expired, "orders taken by another replica or failing are not counted")
+
+	assert.Equal(t, []domainorder.OrderStatus{domainorder.StatusPending}, service.filter.Statuses)
+	assert.Equal(t, 10, service.filter.Limit)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), service.filter.PendingBefore, time.Minute)
+}
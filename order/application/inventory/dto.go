@@ -0,0 +1,39 @@
+package inventory
+
+import "time"
+
+// SetStockRequest defines the payload for setting the available quantity of a product
+type SetStockRequest struct {
+	Available *int `json:"available" validate:"required,gte=0"`
+}
+
+// AdjustStockRequest defines the payload for adding to or removing from available stock
+type AdjustStockRequest struct {
+	Delta int `json:"delta" validate:"required"`
+}
+
+type StockResponse struct {
+	ProductID string    `json:"productId"`
+	Available int       `json:"available"`
+	Reserved  int       `json:"reserved"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type StockListResponse struct {
+	Stocks []StockResponse `json:"stocks"`
+	Count  int             `json:"count"`
+}
+
+type ReservationResponse struct {
+	OrderID   int64     `json:"orderId"`
+	ProductID string    `json:"productId"`
+	Quantity  int       `json:"quantity"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ReservationListResponse struct {
+	Reservations []ReservationResponse `json:"reservations"`
+	Count        int                   `json:"count"`
+}
@@ -0,0 +1,26 @@
+package inventory
+
+import domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToStockResponse(stock *domaininventory.Stock) StockResponse {
+	return StockResponse{
+		ProductID: stock.ProductID,
+		Available: stock.Available,
+		Reserved:  stock.Reserved,
+		UpdatedAt: stock.UpdatedAt,
+	}
+}
+
+func (m *Mapper) ToReservationResponse(reservation *domaininventory.Reservation) ReservationResponse {
+	return ReservationResponse{
+		OrderID:   reservation.OrderID,
+		ProductID: reservation.ProductID,
+		Quantity:  reservation.Quantity,
+		Status:    string(reservation.Status),
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package inventory
+
+import (
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+)
+
+type UseCase struct {
+	mapper           *Mapper
+	inventoryService domaininventory.Service
+}
+
+func NewInventoryUseCase(inventoryService domaininventory.Service) *UseCase {
+	return &UseCase{
+		mapper:           &Mapper{},
+		inventoryService: inventoryService,
+	}
+}
+
+func (uc *UseCase) GetStock(ctx appcontext.AppContext, productID string) (*StockResponse, error) {
+	stock, err := uc.inventoryService.GetStock(ctx.GetDefaultContext(), productID)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToStockResponse(stock)
+	return &response, nil
+}
+
+func (uc *UseCase) GetStocks(ctx appcontext.AppContext) (*StockListResponse, error) {
+	stocks, err := uc.inventoryService.GetStocks(ctx.GetDefaultContext())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]StockResponse, 0, len(stocks))
+	for _, stock := range stocks {
+		responses = append(responses, uc.mapper.ToStockResponse(&stock))
+	}
+	return &StockListResponse{
+		Stocks: responses,
+		Count:  len(responses),
+	}, nil
+}
+
+func (uc *UseCase) SetStock(ctx appcontext.AppContext, productID string, req SetStockRequest) (*StockResponse, error) {
+	stock, err := uc.inventoryService.SetStock(ctx.GetDefaultContext(), productID, *req.Available)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToStockResponse(stock)
+	return &response, nil
+}
+
+func (uc *UseCase) AdjustStock(ctx appcontext.AppContext, productID string, req AdjustStockRequest) (*StockResponse, error) {
+	stock, err := uc.inventoryService.AdjustStock(ctx.GetDefaultContext(), productID, req.Delta)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToStockResponse(stock)
+	return &response, nil
+}
+
+func (uc *UseCase) GetReservationsByOrder(ctx appcontext.AppContext, orderID int64) (*ReservationListResponse, error) {
+	reservations, err := uc.inventoryService.GetReservationsByOrder(ctx.GetDefaultContext(), orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ReservationResponse, 0, len(reservations))
+	for _, reservation := range reservations {
+		responses = append(responses, uc.mapper.ToReservationResponse(&reservation))
+	}
+	return &ReservationListResponse{
+		Reservations: responses,
+		Count:        len(responses),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package order
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+)
+
+// encodeCursor turns a listing position into the opaque token handed to clients
+func encodeCursor(cursor *domainorder.Cursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor parses a token produced by encodeCursor
+func decodeCursor(token string) (*domainorder.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domainorder.ErrInvalidFilter)
+	}
+	var cursor domainorder.Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domainorder.ErrInvalidFilter)
+	}
+	return &cursor, nil
+}
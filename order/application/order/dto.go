@@ -1,40 +1,276 @@
 package order
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
 )
 
 // DTOs (Data Transfer Objects) for input/output
 type CreateOrderRequest struct {
-	OrderID string    `json:"orderId" validate:"omitempty"`
-	UserID  string    `json:"userId" validate:"required"`
-	Items   []ItemDTO `json:"items" validate:"required,dive,required"`
+	OrderID     string             `json:"orderId" validate:"omitempty"`
+	UserID      string             `json:"userId" validate:"required"`
+	Items       []OrderItemRequest `json:"items" validate:"required,dive,required"`
+	VoucherCode string             `json:"voucherCode,omitempty" validate:"omitempty"`
+
+	// AddressID picks the shipping address from the customer's address book;
+	// the default address is used when empty
+	AddressID string `json:"addressId,omitempty" validate:"omitempty"`
+
+	// Actor is who places the order, recorded in the order history
+	Actor string `json:"-"`
+}
+
+// OrderItemRequest is a line of a new order. Prices are not accepted from the
+// client; they are taken from the product catalog when the order is created.
+type OrderItemRequest struct {
+	ProductID string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
 }
 
 type ItemDTO struct {
-	ProductID string  `json:"productId" validate:"required"`
-	Quantity  int     `json:"quantity" validate:"required,gt=0"`
-	Price     float64 `json:"price" validate:"required,gt=0"`
+	ProductID        string      `json:"productId"`
+	Name             string      `json:"name,omitempty"`
+	Quantity         int         `json:"quantity"`
+	Price            money.Money `json:"price"`
+	RefundedQuantity int         `json:"refundedQuantity,omitempty"`
 }
 
 type OrderResponse struct {
-	OrderID     int64     `json:"orderId"`
-	OrderCode   string    `json:"orderCode"`
-	UserID      string    `json:"userId"`
-	Items       []ItemDTO `json:"items"`
-	TotalAmount float64   `json:"totalAmount"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"createdAt"`
+	OrderID     int64       `json:"orderId"`
+	OrderCode   string      `json:"orderCode"`
+	UserID      string      `json:"userId"`
+	Items       []ItemDTO   `json:"items"`
+	Subtotal    money.Money `json:"subtotal"`
+	Discount    money.Money `json:"discount"`
+	VoucherCode string      `json:"voucherCode,omitempty"`
+	TotalAmount money.Money `json:"totalAmount"`
+	Status      string      `json:"status"`
+	Version     int64       `json:"version"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt,omitempty"`
+
+	Cancellation    *CancellationDTO    `json:"cancellation,omitempty"`
+	RefundedAmount  money.Money         `json:"refundedAmount"`
+	ShippingAddress *ShippingAddressDTO `json:"shippingAddress,omitempty"`
+	Risk            *RiskDTO            `json:"risk,omitempty"`
+}
+
+type ShippingAddressDTO struct {
+	AddressID     string `json:"addressId,omitempty"`
+	Label         string `json:"label,omitempty"`
+	RecipientName string `json:"recipientName"`
+	Phone         string `json:"phone,omitempty"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+	City          string `json:"city"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty"`
+	CountryCode   string `json:"countryCode"`
+}
+
+type CancellationDTO struct {
+	Reason      string    `json:"reason"`
+	Note        string    `json:"note,omitempty"`
+	CancelledBy string    `json:"cancelledBy,omitempty"`
+	CancelledAt time.Time `json:"cancelledAt"`
+	RefundDue   bool      `json:"refundDue,omitempty"`
+}
+
+type RiskDTO struct {
+	Score      int       `json:"score"`
+	Level      string    `json:"level"`
+	Signals    []string  `json:"signals,omitempty"`
+	AssessedAt time.Time `json:"assessedAt"`
+	Decision   string    `json:"decision,omitempty"`
+	ReviewedBy string    `json:"reviewedBy,omitempty"`
+	ReviewedAt time.Time `json:"reviewedAt,omitempty"`
+	Note       string    `json:"note,omitempty"`
+}
+
+// UpdateOrderStatusRequest defines the payload for moving an order to a new status
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required"`
+
+	// Actor is who changes the status, recorded in the order history
+	Actor string `json:"-"`
+	// ExpectedVersion comes from If-Match; the update fails if the order moved on
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// CancelOrderRequest defines the payload for cancelling an order
+type CancelOrderRequest struct {
+	Reason string `json:"reason" validate:"required"`
+	Note   string `json:"note,omitempty" validate:"omitempty,max=500"`
+
+	// Actor is who cancels the order, recorded in the order history
+	Actor string `json:"-"`
+	// ExpectedVersion comes from If-Match; the cancellation fails if the order moved on
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// ReviewOrderRequest defines the payload for approving or rejecting an order
+// held for review
+type ReviewOrderRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=500"`
+
+	// Actor is who reviews the order, recorded on the order and in its history
+	Actor string `json:"-"`
+	// ExpectedVersion comes from If-Match; the review fails if the order moved on
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// GetHeldOrdersRequest defines request parameters for the review queue
+type GetHeldOrdersRequest struct {
+	// Cursor is the nextCursor of the previous page
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// RefundOrderRequest defines the payload for refunding an order. Without
+// items, everything not refunded yet is refunded.
+type RefundOrderRequest struct {
+	Items  []RefundItemRequest `json:"items,omitempty" validate:"dive"`
+	Reason string              `json:"reason" validate:"required,max=200"`
+
+	// Actor is who refunds the order, recorded in the order history
+	Actor string `json:"-"`
+	// ExpectedVersion comes from If-Match; the refund fails if the order moved on
+	ExpectedVersion *int64 `json:"-"`
+}
+
+type RefundItemRequest struct {
+	ProductID string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type RefundResponse struct {
+	RefundID  string          `json:"refundId"`
+	Amount    money.Money     `json:"amount"`
+	Items     []RefundItemDTO `json:"items"`
+	Reason    string          `json:"reason"`
+	CreatedAt time.Time       `json:"createdAt"`
+
+	Order OrderResponse `json:"order"`
+}
+
+type RefundItemDTO struct {
+	ProductID string      `json:"productId"`
+	Quantity  int         `json:"quantity"`
+	Amount    money.Money `json:"amount"`
+}
+
+// ReorderRequest defines the payload for ordering the items of a past order again
+type ReorderRequest struct {
+	UserID      string `json:"userId" validate:"required"`
+	VoucherCode string `json:"voucherCode,omitempty" validate:"omitempty"`
+	AddressID   string `json:"addressId,omitempty" validate:"omitempty"`
+
+	// Actor is who places the order, recorded in the order history
+	Actor string `json:"-"`
+}
+
+// Reorder change kinds
+const (
+	ReorderPriceChanged    = "price_changed"
+	ReorderQuantityReduced = "quantity_reduced"
+	ReorderUnavailable     = "unavailable"
+	ReorderOutOfStock      = "out_of_stock"
+)
+
+type ReorderResponse struct {
+	SourceOrderID int64              `json:"sourceOrderId"`
+	Changes       []ReorderChangeDTO `json:"changes"`
+
+	Order OrderResponse `json:"order"`
+}
+
+// ReorderChangeDTO describes how an item of the past order differs in the new
+// one. Unavailable and out of stock items were dropped.
+type ReorderChangeDTO struct {
+	ProductID        string       `json:"productId"`
+	Name             string       `json:"name,omitempty"`
+	Change           string       `json:"change"`
+	PreviousQuantity int          `json:"previousQuantity"`
+	Quantity         int          `json:"quantity"`
+	PreviousPrice    money.Money  `json:"previousPrice"`
+	Price            *money.Money `json:"price,omitempty"`
+}
+
+// ValidateOrderResponse is the outcome of checking an order request without
+// placing it
+type ValidateOrderResponse struct {
+	Valid       bool               `json:"valid"`
+	Violations  []RuleViolationDTO `json:"violations"`
+	Subtotal    money.Money        `json:"subtotal"`
+	Discount    money.Money        `json:"discount"`
+	TotalAmount money.Money        `json:"totalAmount"`
+}
+
+// RuleViolationsResponse is the body of order requests refused for breaking
+// order rules
+type RuleViolationsResponse struct {
+	Message    string             `json:"message"`
+	Violations []RuleViolationDTO `json:"violations"`
+}
+
+// RuleViolationDTO is an order rule the order breaks. Field names the part of
+// the request at fault, such as items[0].quantity.
+type RuleViolationDTO struct {
+	RuleID  string `json:"ruleId"`
+	Type    string `json:"type"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // GetOrdersByUserIDRequest defines request parameters for getting orders by user ID
 type GetOrdersByUserIDRequest struct {
-	UserID string `json:"userId,omitempty" validate:"omitempty"`
-	Status string `json:"status,omitempty" validate:"omitempty"`
+	UserID   string   `json:"userId,omitempty" validate:"required"`
+	Statuses []string `json:"statuses,omitempty" validate:"omitempty"`
+
+	CreatedFrom time.Time `json:"createdFrom,omitempty"`
+	CreatedTo   time.Time `json:"createdTo,omitempty"`
+
+	// MinAmount and MaxAmount are in minor units of Currency
+	MinAmount *int64 `json:"minAmount,omitempty" validate:"omitempty,gte=0"`
+	MaxAmount *int64 `json:"maxAmount,omitempty" validate:"omitempty,gte=0"`
+	Currency  string `json:"currency,omitempty" validate:"required_with=MinAmount MaxAmount"`
+
+	SortBy string `json:"sortBy,omitempty" validate:"omitempty,oneof=created_at total_amount"`
+	Order  string `json:"order,omitempty" validate:"omitempty,oneof=asc desc"`
+
+	// Cursor is the nextCursor of the previous page
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
 }
 
-// OrderListResponse represents a collection of orders
+// OrderListResponse represents a page of orders
 type OrderListResponse struct {
-	Orders []OrderResponse `json:"orders,omitempty"`
-	Count  int             `json:"count,omitempty"`
+	Orders     []OrderResponse `json:"orders,omitempty"`
+	Count      int             `json:"count,omitempty"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
+
+// OrderHistoryResponse lists the recorded mutations of an order, oldest first
+type OrderHistoryResponse struct {
+	OrderID int64                  `json:"orderId"`
+	Entries []HistoryEntryResponse `json:"entries"`
+	Count   int                    `json:"count"`
+}
+
+type HistoryEntryResponse struct {
+	Action         string           `json:"action"`
+	Actor          string           `json:"actor,omitempty"`
+	PreviousStatus string           `json:"previousStatus,omitempty"`
+	NewStatus      string           `json:"newStatus,omitempty"`
+	Changes        []FieldChangeDTO `json:"changes,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+}
+
+// FieldChangeDTO holds the values of a changed field as they appear in OrderResponse
+type FieldChangeDTO struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from,omitempty"`
+	To    json.RawMessage `json:"to,omitempty"`
 }
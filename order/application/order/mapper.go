@@ -1,6 +1,13 @@
 package order
 
-import domainorder "github.com/DuongVu089x/interview/order/domain/order"
+import (
+	"encoding/json"
+
+	domainhistory "github.com/DuongVu089x/interview/order/domain/history"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+)
 
 // Mapper converts between DTOs and Domain entities
 type Mapper struct{}
@@ -13,13 +20,12 @@ func (m *Mapper) ToEntity(dto CreateOrderRequest) *domainorder.Order {
 	}
 }
 
-func (m *Mapper) toOrderItems(dtos []ItemDTO) []domainorder.OrderItem {
+func (m *Mapper) toOrderItems(dtos []OrderItemRequest) []domainorder.OrderItem {
 	items := make([]domainorder.OrderItem, len(dtos))
 	for i, dto := range dtos {
 		items[i] = domainorder.OrderItem{
 			ProductID: dto.ProductID,
 			Quantity:  dto.Quantity,
-			Price:     dto.Price,
 		}
 	}
 	return items
@@ -31,9 +37,66 @@ func (m *Mapper) ToResponse(order *domainorder.Order) OrderResponse {
 		OrderCode:   order.OrderCode,
 		UserID:      order.UserID,
 		Items:       m.toItemDTOs(order.Items),
+		Subtotal:    order.Subtotal,
+		Discount:    order.Discount,
+		VoucherCode: order.VoucherCode,
 		TotalAmount: order.TotalAmount,
 		Status:      string(order.Status),
+		Version:     order.Version,
 		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+
+		Cancellation:    m.toCancellationDTO(order.Cancellation),
+		RefundedAmount:  order.RefundedAmount,
+		ShippingAddress: m.toShippingAddressDTO(order.ShippingAddress),
+		Risk:            m.toRiskDTO(order.Risk),
+	}
+}
+
+func (m *Mapper) toRiskDTO(risk *domainorder.Risk) *RiskDTO {
+	if risk == nil {
+		return nil
+	}
+	return &RiskDTO{
+		Score:      risk.Score,
+		Level:      risk.Level,
+		Signals:    risk.Signals,
+		AssessedAt: risk.AssessedAt,
+		Decision:   string(risk.Decision),
+		ReviewedBy: risk.ReviewedBy,
+		ReviewedAt: risk.ReviewedAt,
+		Note:       risk.Note,
+	}
+}
+
+func (m *Mapper) toShippingAddressDTO(address *domainorder.ShippingAddress) *ShippingAddressDTO {
+	if address == nil {
+		return nil
+	}
+	return &ShippingAddressDTO{
+		AddressID:     address.AddressID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
+	}
+}
+
+func (m *Mapper) toCancellationDTO(cancellation *domainorder.Cancellation) *CancellationDTO {
+	if cancellation == nil {
+		return nil
+	}
+	return &CancellationDTO{
+		Reason:      string(cancellation.Reason),
+		Note:        cancellation.Note,
+		CancelledBy: cancellation.CancelledBy,
+		CancelledAt: cancellation.CancelledAt,
+		RefundDue:   cancellation.RefundDue,
 	}
 }
 
@@ -41,10 +104,85 @@ func (m *Mapper) toItemDTOs(items []domainorder.OrderItem) []ItemDTO {
 	dto := make([]ItemDTO, len(items))
 	for i, item := range items {
 		dto[i] = ItemDTO{
+			ProductID:        item.ProductID,
+			Name:             item.Name,
+			Quantity:         item.Quantity,
+			Price:            item.Price,
+			RefundedQuantity: item.RefundedQuantity,
+		}
+	}
+	return dto
+}
+
+func (m *Mapper) ToRefundResponse(refund *domainpayment.Refund, order *domainorder.Order) RefundResponse {
+	items := make([]RefundItemDTO, len(refund.Items))
+	for i, item := range refund.Items {
+		items[i] = RefundItemDTO{
 			ProductID: item.ProductID,
 			Quantity:  item.Quantity,
-			Price:     item.Price,
+			Amount:    item.Amount,
+		}
+	}
+	return RefundResponse{
+		RefundID:  refund.RefundID,
+		Amount:    refund.Amount,
+		Items:     items,
+		Reason:    refund.Reason,
+		CreatedAt: refund.CreatedAt,
+		Order:     m.ToResponse(order),
+	}
+}
+
+func (m *Mapper) ToHistoryResponse(orderID int64, entries []domainhistory.Entry) OrderHistoryResponse {
+	responses := make([]HistoryEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = HistoryEntryResponse{
+			Action:         string(entry.Action),
+			Actor:          entry.Actor,
+			PreviousStatus: entry.PreviousStatus,
+			NewStatus:      entry.NewStatus,
+			Changes:        m.toFieldChangeDTOs(entry.Changes),
+			CreatedAt:      entry.CreatedAt,
+		}
+	}
+	return OrderHistoryResponse{
+		OrderID: orderID,
+		Entries: responses,
+		Count:   len(responses),
+	}
+}
+
+func (m *Mapper) toFieldChangeDTOs(changes []domainhistory.Change) []FieldChangeDTO {
+	dto := make([]FieldChangeDTO, len(changes))
+	for i, change := range changes {
+		dto[i] = FieldChangeDTO{Field: change.Field}
+		if change.From != "" {
+			dto[i].From = json.RawMessage(change.From)
+		}
+		if change.To != "" {
+			dto[i].To = json.RawMessage(change.To)
 		}
 	}
 	return dto
 }
+
+// ToRuleViolationsResponse describes an order refused for breaking order rules
+func ToRuleViolationsResponse(err *domainrule.ValidationError) RuleViolationsResponse {
+	return RuleViolationsResponse{
+		Message:    domainrule.ErrRulesViolated.Error(),
+		Violations: toRuleViolationDTOs(err.Violations),
+	}
+}
+
+func toRuleViolationDTOs(violations []domainrule.Violation) []RuleViolationDTO {
+	dtos := make([]RuleViolationDTO, len(violations))
+	for i, violation := range violations {
+		dtos[i] = RuleViolationDTO{
+			RuleID:  violation.RuleID,
+			Type:    string(violation.Type),
+			Field:   violation.Field,
+			Message: violation.Message,
+		}
+	}
+	return dtos
+}
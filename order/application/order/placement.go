@@ -0,0 +1,520 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/saga"
+	domainhistory "github.com/DuongVu089x/interview/order/domain/history"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainoutbox "github.com/DuongVu089x/interview/order/domain/outbox"
+	domainrisk "github.com/DuongVu089x/interview/order/domain/risk"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	pb "github.com/DuongVu089x/interview/order/proto/customer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// placementSagaType is the saga that places an order
+	placementSagaType = "order_placement"
+
+	// Keys of the placement saga state
+	placementRequestKey  = "request"
+	placementActorKey    = "actor"
+	placementOrderKey    = "order"
+	placementAddressKey  = "shipping_address"
+	placementTierKey     = "customer_tier"
+	placementCustomerKey = "customer"
+
+	// placementActor is recorded in the history of orders cancelled because
+	// their placement failed
+	placementActor = "system:placement"
+)
+
+// placementSagaID names the placement saga of an order, so that an order is
+// placed at most once
+func placementSagaID(orderID int64) string {
+	return fmt.Sprintf("order-placement-%d", orderID)
+}
+
+// placementSaga checks the customer, copies the shipping address, prices the
+// order and checks it against the order rules, assesses its risk, holds its
+// stock and voucher, starts its payment and finally saves it. High risk
+// orders are saved on hold for review, with no payment and no ORDER_CREATED
+// event until they are approved.
+// Steps that fail undo the steps before them, so an order is never left half
+// placed.
+func (uc *UseCase) placementSaga() saga.Definition {
+	return saga.Definition{
+		Type: placementSagaType,
+		Steps: []saga.Step{
+			{Name: "validate_customer", Timeout: 5 * time.Second, Action: uc.validateCustomer},
+			{Name: "resolve_address", Timeout: 5 * time.Second, Action: uc.resolveAddress},
+			{Name: "price_order", Action: uc.priceOrder},
+			{Name: "assess_risk", Timeout: 5 * time.Second, Action: uc.assessRisk},
+			{Name: "reserve_stock", Action: uc.reserveStock, Compensate: uc.releaseStock},
+			{Name: "redeem_voucher", Action: uc.redeemVoucher, Compensate: uc.releaseVoucher},
+			{Name: "authorize_payment", Action: uc.authorizePayment, Compensate: uc.voidPayment},
+			{Name: "save_order", Action: uc.saveOrder, Compensate: uc.cancelUnplacedOrder},
+		},
+	}
+}
+
+// placedOrder reads the order priced by the saga
+func placedOrder(state *saga.State) (*domainorder.Order, error) {
+	var order domainorder.Order
+	if err := state.Get(placementOrderKey, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// validateCustomer checks that the customer exists and keeps them for the
+// order rules and the risk assessment
+func (uc *UseCase) validateCustomer(ctx context.Context, state *saga.State) error {
+	var req CreateOrderRequest
+	if err := state.Get(placementRequestKey, &req); err != nil {
+		return err
+	}
+
+	customer, err := uc.getCustomer(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if err := state.Set(placementCustomerKey, customer); err != nil {
+		return err
+	}
+	return state.Set(placementTierKey, customerTier(customer))
+}
+
+// getCustomer returns the customer, failing when they do not exist
+func (uc *UseCase) getCustomer(ctx context.Context, userID string) (*pb.Customer, error) {
+	customerResp, err := uc.customerClient.GetCustomer(ctx, &pb.GetCustomerRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return nil, domainorder.ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to check customer existence: %w", err)
+	}
+	if !customerResp.Exists {
+		return nil, domainorder.ErrCustomerNotFound
+	}
+	return customerResp.GetCustomer(), nil
+}
+
+// customerTier returns the tier the order rules apply to the customer
+func customerTier(customer *pb.Customer) string {
+	if tier := customer.GetTier(); tier != "" {
+		return tier
+	}
+	return domainrule.DefaultTier
+}
+
+// resolveAddress copies the shipping address onto the saga: the address named
+// by the request, or else the default address of the customer. Orders of
+// customers with no address have none.
+func (uc *UseCase) resolveAddress(ctx context.Context, state *saga.State) error {
+	var req CreateOrderRequest
+	if err := state.Get(placementRequestKey, &req); err != nil {
+		return err
+	}
+
+	var address *pb.Address
+	if req.AddressID != "" {
+		addressResp, err := uc.customerClient.GetAddress(ctx, &pb.GetAddressRequest{
+			UserId:    req.UserID,
+			AddressId: req.AddressID,
+		})
+		if err != nil {
+			if st, ok := status.FromError(err); ok && (st.Code() == codes.NotFound || st.Code() == codes.InvalidArgument) {
+				return fmt.Errorf("%w: %s", domainorder.ErrAddressNotFound, req.AddressID)
+			}
+			return fmt.Errorf("failed to get shipping address: %w", err)
+		}
+		address = addressResp.Address
+	} else {
+		addressesResp, err := uc.customerClient.ListAddresses(ctx, &pb.ListAddressesRequest{
+			UserId: req.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get shipping address: %w", err)
+		}
+		for _, candidate := range addressesResp.Addresses {
+			if candidate.IsDefault {
+				address = candidate
+				break
+			}
+		}
+	}
+	if address == nil {
+		return nil
+	}
+	return state.Set(placementAddressKey, toShippingAddress(address))
+}
+
+// priceOrder builds the order from the request with the ID allocated for it
+// and checks it against the order rules
+func (uc *UseCase) priceOrder(ctx context.Context, state *saga.State) error {
+	var req CreateOrderRequest
+	if err := state.Get(placementRequestKey, &req); err != nil {
+		return err
+	}
+	var allocated domainorder.Order
+	if err := state.Get(placementOrderKey, &allocated); err != nil {
+		return err
+	}
+	// Sagas started before tiers were kept apply the rules of the default tier
+	tier := domainrule.DefaultTier
+	if state.Has(placementTierKey) {
+		if err := state.Get(placementTierKey, &tier); err != nil {
+			return err
+		}
+	}
+
+	order, err := uc.buildOrder(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := uc.ruleEngine.Check(toRuleOrder(order, tier)); err != nil {
+		return err
+	}
+
+	order.OrderID = allocated.OrderID
+	order.OrderCode = allocated.OrderCode
+	if state.Has(placementAddressKey) {
+		if err := state.Get(placementAddressKey, &order.ShippingAddress); err != nil {
+			return err
+		}
+	}
+
+	order.CreatedAt = time.Now()
+	order.UpdatedAt = order.CreatedAt
+	return state.Set(placementOrderKey, order)
+}
+
+// buildOrder converts the request to an order priced from the catalog with
+// its voucher applied
+func (uc *UseCase) buildOrder(ctx context.Context, req CreateOrderRequest) (*domainorder.Order, error) {
+	// Convert DTO to domain entity
+	order := uc.mapper.ToEntity(req)
+
+	// Price items from the catalog, never from the request
+	if err := uc.priceItems(ctx, order.Items); err != nil {
+		return nil, err
+	}
+
+	// Calculate total using domain service
+	var err error
+	order.Subtotal, err = uc.orderService.CalculateTotal(order.Items)
+	if err != nil {
+		return nil, err
+	}
+	order.TotalAmount = order.Subtotal
+
+	if req.VoucherCode != "" {
+		discount, err := uc.voucherService.CalculateDiscount(ctx, req.VoucherCode, order.UserID, order.Subtotal, toPricedItems(order.Items))
+		if err != nil {
+			return nil, err
+		}
+		order.VoucherCode = req.VoucherCode
+		order.Discount = discount
+		if order.TotalAmount, err = order.Subtotal.Sub(discount); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.orderService.ValidateOrder(order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// assessRisk puts the order on hold when it is assessed as high risk. An
+// order that cannot be assessed is held too, so that it is neither lost nor
+// placed unchecked.
+func (uc *UseCase) assessRisk(ctx context.Context, state *saga.State) error {
+	if uc.riskAssessor == nil {
+		return nil
+	}
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+	if order.Risk != nil {
+		return nil
+	}
+	// Sagas started before customers were kept are assessed without one
+	var customer *pb.Customer
+	if state.Has(placementCustomerKey) {
+		if err := state.Get(placementCustomerKey, &customer); err != nil {
+			return err
+		}
+	}
+
+	assessment, err := uc.riskAssessor.AssessRisk(ctx, order, customer)
+	if err != nil {
+		log.Printf("Failed to assess the risk of order %d, holding it for review: %v", order.OrderID, err)
+		if err := order.Hold(domainorder.Risk{
+			Level:      string(domainrisk.LevelHigh),
+			Signals:    []string{"risk assessment failed: " + err.Error()},
+			AssessedAt: time.Now(),
+		}); err != nil {
+			return err
+		}
+		return state.Set(placementOrderKey, order)
+	}
+
+	risk := toOrderRisk(assessment)
+	if assessment.IsHigh() {
+		if err := order.Hold(risk); err != nil {
+			return err
+		}
+	} else {
+		order.Risk = &risk
+	}
+	return state.Set(placementOrderKey, order)
+}
+
+func (uc *UseCase) reserveStock(ctx context.Context, state *saga.State) error {
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		// A retried step finds the stock it reserved before
+		reservations, err := uc.inventoryService.GetReservationsByOrder(txCtx, order.OrderID)
+		if err != nil {
+			return err
+		}
+		if len(reservations) > 0 {
+			return nil
+		}
+		return uc.inventoryService.ReserveForOrder(txCtx, order.OrderID, toReservationItems(order.Items))
+	})
+}
+
+func (uc *UseCase) releaseStock(ctx context.Context, state *saga.State) error {
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		return uc.inventoryService.ReleaseForOrder(txCtx, order.OrderID)
+	})
+}
+
+func (uc *UseCase) redeemVoucher(ctx context.Context, state *saga.State) error {
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+	if order.VoucherCode == "" {
+		return nil
+	}
+
+	return uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		return uc.voucherService.Redeem(txCtx, order.VoucherCode, order.UserID, order.OrderID, order.Discount)
+	})
+}
+
+func (uc *UseCase) releaseVoucher(ctx context.Context, state *saga.State) error {
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+	if order.VoucherCode == "" {
+		return nil
+	}
+
+	return uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		return uc.voucherService.Release(txCtx, order.OrderID)
+	})
+}
+
+func (uc *UseCase) authorizePayment(ctx context.Context, state *saga.State) error {
+	if uc.paymentAuthorizer == nil {
+		return nil
+	}
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+	// Held orders are paid once approved
+	if order.Status == domainorder.StatusOnHold {
+		return nil
+	}
+
+	if _, err := uc.paymentAuthorizer.AuthorizePayment(ctx, order); err != nil {
+		return fmt.Errorf("failed to authorize payment: %w", err)
+	}
+	return nil
+}
+
+func (uc *UseCase) voidPayment(ctx context.Context, state *saga.State) error {
+	if uc.paymentCompensator == nil {
+		return nil
+	}
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+	return uc.paymentCompensator.VoidPayment(ctx, order.OrderID)
+}
+
+// saveOrder saves the order with its history and ORDER_CREATED event
+// atomically; the outbox relay publishes the event. Held orders are announced
+// when they are approved.
+func (uc *UseCase) saveOrder(ctx context.Context, state *saga.State) error {
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+	var actor string
+	if err := state.Get(placementActorKey, &actor); err != nil {
+		return err
+	}
+
+	var event *domainoutbox.Message
+	if order.Status != domainorder.StatusOnHold {
+		if event, err = newCreatedEvent(order); err != nil {
+			return err
+		}
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionCreated, actor, nil, order)
+	if err != nil {
+		return err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		// A retried step finds the order it saved before
+		if saved, err := uc.orderService.GetOrderForUpdate(txCtx, order.OrderID); err == nil {
+			order = saved
+			return nil
+		} else if !errors.Is(err, domainorder.ErrOrderNotFound) {
+			return err
+		}
+
+		if err := uc.orderService.CreateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save order: %w", err)
+	}
+	return state.Set(placementOrderKey, order)
+}
+
+// cancelUnplacedOrder cancels the order if saving it took effect although the
+// step failed, so that the released stock and voucher are not in use
+func (uc *UseCase) cancelUnplacedOrder(ctx context.Context, state *saga.State) error {
+	order, err := placedOrder(state)
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		saved, err := uc.orderService.GetOrderForUpdate(txCtx, order.OrderID)
+		if errors.Is(err, domainorder.ErrOrderNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if saved.Status == domainorder.StatusCancelled {
+			return nil
+		}
+
+		before := snapshot(saved)
+		previousStatus := saved.Status
+		if err := saved.Cancel(domainorder.CancelReasonOther, "order placement failed", placementActor, time.Now()); err != nil {
+			return err
+		}
+
+		event, err := newOrderEvent("ORDER_CANCELLED", fmt.Sprintf("ORDER_CANCELLED_%d", saved.OrderID), saved, map[string]any{
+			"previous_status": previousStatus,
+			"reason":          saved.Cancellation.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		entry, err := newHistoryEntry(domainhistory.ActionCancelled, placementActor, before, saved)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.orderService.UpdateOrder(txCtx, saved); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+}
+
+// newCreatedEvent announces a placed order
+func newCreatedEvent(order *domainorder.Order) (*domainoutbox.Message, error) {
+	return newOrderEvent("ORDER_CREATED", fmt.Sprintf("ORDER_CREATED_%d", order.OrderID), order, map[string]any{
+		"amount": order.TotalAmount,
+	})
+}
+
+// toOrderRisk records an assessment on the order
+func toOrderRisk(assessment *domainrisk.Assessment) domainorder.Risk {
+	signals := make([]string, len(assessment.Signals))
+	for i, signal := range assessment.Signals {
+		signals[i] = fmt.Sprintf("%s: %s", signal.Name, signal.Detail)
+	}
+	return domainorder.Risk{
+		Score:      assessment.Score,
+		Level:      string(assessment.Level),
+		Signals:    signals,
+		AssessedAt: assessment.AssessedAt,
+	}
+}
+
+// toRuleOrder describes the order for the order rules
+func toRuleOrder(order *domainorder.Order, tier string) domainrule.Order {
+	items := make([]domainrule.Item, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = domainrule.Item{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	return domainrule.Order{
+		UserID:   order.UserID,
+		Tier:     tier,
+		Items:    items,
+		Subtotal: order.Subtotal,
+		Total:    order.TotalAmount,
+	}
+}
+
+func toShippingAddress(address *pb.Address) *domainorder.ShippingAddress {
+	return &domainorder.ShippingAddress{
+		AddressID:     address.Id,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		CountryCode:   address.CountryCode,
+	}
+}
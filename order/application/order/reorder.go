@@ -0,0 +1,143 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+)
+
+// Reorder places a new order with the items of a past order of the same user.
+// Items are priced from the catalog again; products no longer sold or out of
+// stock are dropped and short stock lowers the quantity. The new order goes
+// through CreateOrder like any other.
+func (uc *UseCase) Reorder(ctx appcontext.AppContext, id int64, req ReorderRequest) (*ReorderResponse, error) {
+	source, err := uc.orderService.GetOrder(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, err
+	}
+	// Orders of other users are reported as missing so their IDs reveal nothing
+	if source.UserID != req.UserID {
+		return nil, domainorder.ErrOrderNotFound
+	}
+
+	items, changes, err := uc.planReorder(ctx.GetDefaultContext(), source.Items)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, domainorder.ErrNothingToReorder
+	}
+
+	order, err := uc.CreateOrder(ctx, CreateOrderRequest{
+		UserID:      req.UserID,
+		Items:       items,
+		VoucherCode: req.VoucherCode,
+		AddressID:   req.AddressID,
+		Actor:       req.Actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReorderResponse{
+		SourceOrderID: source.OrderID,
+		Changes:       changes,
+		Order:         *order,
+	}, nil
+}
+
+// planReorder returns the items of a past order that can be ordered now and
+// how they differ from the past order
+func (uc *UseCase) planReorder(ctx context.Context, pastItems []domainorder.OrderItem) ([]OrderItemRequest, []ReorderChangeDTO, error) {
+	items := make([]OrderItemRequest, 0, len(pastItems))
+	changes := make([]ReorderChangeDTO, 0)
+
+	products := make(map[string]*domainproduct.Product)
+	remaining := make(map[string]int)
+	for _, past := range pastItems {
+		change := ReorderChangeDTO{
+			ProductID:        past.ProductID,
+			Name:             past.Name,
+			PreviousQuantity: past.Quantity,
+			PreviousPrice:    past.Price,
+		}
+
+		product, ok := products[past.ProductID]
+		if !ok {
+			var err error
+			if product, err = uc.sellableProduct(ctx, past.ProductID); err != nil {
+				return nil, nil, err
+			}
+			products[past.ProductID] = product
+
+			if product != nil {
+				if remaining[past.ProductID], err = uc.availableStock(ctx, past.ProductID); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+		if product == nil {
+			change.Change = ReorderUnavailable
+			changes = append(changes, change)
+			continue
+		}
+
+		// Several lines of the same product share its stock
+		quantity := min(past.Quantity, remaining[past.ProductID])
+		remaining[past.ProductID] -= quantity
+		change.Name = product.Name
+		change.Quantity = quantity
+		change.Price = &product.Price
+
+		switch {
+		case quantity == 0:
+			change.Change = ReorderOutOfStock
+			changes = append(changes, change)
+			continue
+		case quantity < past.Quantity:
+			change.Change = ReorderQuantityReduced
+			changes = append(changes, change)
+		}
+		if product.Price != past.Price {
+			change.Change = ReorderPriceChanged
+			changes = append(changes, change)
+		}
+
+		items = append(items, OrderItemRequest{
+			ProductID: past.ProductID,
+			Quantity:  quantity,
+		})
+	}
+	return items, changes, nil
+}
+
+// sellableProduct returns the product if it is still sold, or nil
+func (uc *UseCase) sellableProduct(ctx context.Context, sku string) (*domainproduct.Product, error) {
+	product, err := uc.productService.GetProduct(ctx, sku)
+	if errors.Is(err, domainproduct.ErrProductNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !product.Active {
+		return nil, nil
+	}
+	return product, nil
+}
+
+// availableStock returns the stock of a product that can still be sold
+func (uc *UseCase) availableStock(ctx context.Context, productID string) (int, error) {
+	stock, err := uc.inventoryService.GetStock(ctx, productID)
+	if errors.Is(err, domaininventory.ErrStockNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return stock.Available, nil
+}
@@ -0,0 +1,124 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainhistory "github.com/DuongVu089x/interview/order/domain/history"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+)
+
+// GetHeldOrders returns a page of the orders held for review, oldest first
+func (uc *UseCase) GetHeldOrders(ctx appcontext.AppContext, req GetHeldOrdersRequest) (*OrderListResponse, error) {
+	return uc.GetOrdersByUserID(ctx, GetOrdersByUserIDRequest{
+		Statuses: []string{string(domainorder.StatusOnHold)},
+		SortBy:   string(domainorder.SortByCreatedAt),
+		Order:    "asc",
+		Cursor:   req.Cursor,
+		Limit:    req.Limit,
+	})
+}
+
+// ApproveOrder releases an order held for review. Its payment is started
+// first, as it would have been when it was placed, so a failure leaves the
+// order held and the approval can simply be retried. The order is announced
+// with ORDER_CREATED once approved.
+func (uc *UseCase) ApproveOrder(ctx appcontext.AppContext, id int64, req ReviewOrderRequest) (*OrderResponse, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	before := snapshot(order)
+	if err := order.Approve(req.Actor, req.Note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if uc.paymentAuthorizer != nil {
+		if _, err := uc.paymentAuthorizer.AuthorizePayment(ctx.GetDefaultContext(), order); err != nil {
+			return nil, fmt.Errorf("failed to authorize payment: %w", err)
+		}
+	}
+
+	event, err := newCreatedEvent(order)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionReviewed, req.Actor, before, order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve order: %w", err)
+	}
+
+	response := uc.mapper.ToResponse(order)
+	return &response, nil
+}
+
+// RejectOrder cancels an order held for review as suspected fraud and
+// releases its stock. Nothing was charged for it, so there is no payment to
+// void.
+func (uc *UseCase) RejectOrder(ctx appcontext.AppContext, id int64, req ReviewOrderRequest) (*OrderResponse, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	before := snapshot(order)
+	previousStatus := order.Status
+	if err := order.Reject(req.Actor, req.Note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	event, err := newOrderEvent("ORDER_CANCELLED", fmt.Sprintf("ORDER_CANCELLED_%d", order.OrderID), order, map[string]any{
+		"previous_status": previousStatus,
+		"reason":          order.Cancellation.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionReviewed, req.Actor, before, order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		if err := uc.inventoryService.ReleaseForOrder(txCtx, order.OrderID); err != nil {
+			return err
+		}
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject order: %w", err)
+	}
+
+	response := uc.mapper.ToResponse(order)
+	return &response, nil
+}
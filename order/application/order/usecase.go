@@ -1,106 +1,182 @@
 package order
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/DuongVu089x/interview/order/application/port"
+	"github.com/DuongVu089x/interview/order/application/rule"
+	"github.com/DuongVu089x/interview/order/application/saga"
 	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
-	"github.com/DuongVu089x/interview/order/domain"
 
+	domainhistory "github.com/DuongVu089x/interview/order/domain/history"
 	domainidgen "github.com/DuongVu089x/interview/order/domain/id_gen"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	"github.com/DuongVu089x/interview/order/domain/money"
 	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainoutbox "github.com/DuongVu089x/interview/order/domain/outbox"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
 	pb "github.com/DuongVu089x/interview/order/proto/customer"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+)
+
+const (
+	ordersTopic     = "orders-topic"
+	defaultPageSize = 20
+
+	// expiryActor is recorded in the history of orders expired by the worker
+	expiryActor = "system:expiry"
 )
 
 type UseCase struct {
-	mapper       *Mapper
-	orderService domainorder.Service
+	mapper           *Mapper
+	orderService     domainorder.Service
+	productService   domainproduct.Service
+	inventoryService domaininventory.Service
+	voucherService   domainvoucher.Service
 
 	// helper repository
 	idgenService   domainidgen.Service
 	customerClient pb.CustomerServiceClient
+	outboxRepo     domainoutbox.Repository
+	historyRepo    domainhistory.Repository
+	txManager      port.TransactionManager
+
+	// paymentAuthorizer starts the payment of placed orders and
+	// paymentCompensator voids or refunds the payment of cancelled orders
+	paymentAuthorizer  port.PaymentAuthorizer
+	paymentCompensator port.PaymentCompensator
+
+	// orchestrator runs the placement saga
+	orchestrator *saga.Orchestrator
+
+	// ruleEngine checks new orders against the order rules
+	ruleEngine *rule.Engine
+
+	// riskAssessor scores new orders, holding high risk ones for review; nil
+	// places every order unchecked
+	riskAssessor port.RiskAssessor
 }
 
 func NewOrderUseCase(
 	orderService domainorder.Service,
+	productService domainproduct.Service,
+	inventoryService domaininventory.Service,
+	voucherService domainvoucher.Service,
 	idgenService domainidgen.Service,
 	customerClient pb.CustomerServiceClient,
+	outboxRepo domainoutbox.Repository,
+	historyRepo domainhistory.Repository,
+	txManager port.TransactionManager,
+	paymentAuthorizer port.PaymentAuthorizer,
+	paymentCompensator port.PaymentCompensator,
+	orchestrator *saga.Orchestrator,
+	ruleEngine *rule.Engine,
+	riskAssessor port.RiskAssessor,
 ) *UseCase {
 
 	mapper := &Mapper{}
 
-	return &UseCase{
-		mapper:         mapper,
-		orderService:   orderService,
-		idgenService:   idgenService,
-		customerClient: customerClient,
+	uc := &UseCase{
+		mapper:           mapper,
+		orderService:     orderService,
+		productService:   productService,
+		inventoryService: inventoryService,
+		voucherService:   voucherService,
+		idgenService:     idgenService,
+		customerClient:   customerClient,
+		outboxRepo:       outboxRepo,
+		historyRepo:      historyRepo,
+		txManager:        txManager,
+
+		paymentAuthorizer:  paymentAuthorizer,
+		paymentCompensator: paymentCompensator,
+		orchestrator:       orchestrator,
+		ruleEngine:         ruleEngine,
+		riskAssessor:       riskAssessor,
 	}
+	orchestrator.Register(uc.placementSaga())
+	return uc
 }
 
+// CreateOrder places an order through the placement saga. The order ID is
+// allocated first so that the saga, and any resumption of it, works on a
+// single order.
 func (uc *UseCase) CreateOrder(ctx appcontext.AppContext, req CreateOrderRequest) (*OrderResponse, error) {
-	// Check if customer exists
-	customerResp, err := uc.customerClient.GetCustomer(ctx.GetDefaultContext(), &pb.GetCustomerRequest{
-		UserId: req.UserID,
-	})
+	id, code, err := uc.idgenService.GenerateID("ORDER")
 	if err != nil {
-		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
-			return nil, fmt.Errorf("customer not found")
-		}
-		return nil, fmt.Errorf("failed to check customer existence: %w", err)
-	}
-	if !customerResp.Exists {
-		return nil, fmt.Errorf("customer not found")
+		return nil, err
 	}
 
-	customer := customerResp.Customer
+	state := saga.NewState()
+	if err := state.Set(placementRequestKey, req); err != nil {
+		return nil, err
+	}
+	if err := state.Set(placementActorKey, req.Actor); err != nil {
+		return nil, err
+	}
+	if err := state.Set(placementOrderKey, &domainorder.Order{OrderID: id, OrderCode: code}); err != nil {
+		return nil, err
+	}
 
-	fmt.Println(customer)
+	if err := uc.orchestrator.Run(ctx.GetDefaultContext(), placementSagaType, placementSagaID(id), state); err != nil {
+		return nil, err
+	}
 
-	// Convert DTO to domain entity
-	order := uc.mapper.ToEntity(req)
+	order, err := placedOrder(state)
+	if err != nil {
+		return nil, err
+	}
 
-	// Calculate total using domain service
-	order.TotalAmount = uc.orderService.CalculateTotal(order.Items)
+	// Convert domain entity to response DTO
+	response := uc.mapper.ToResponse(order)
+	return &response, nil
+}
 
-	if err := uc.orderService.ValidateOrder(order); err != nil {
+// ValidateOrder checks an order request the way placing it would, without
+// placing it: the customer, the catalog, the voucher and the order rules.
+// Stock and the shipping address are only checked when the order is placed.
+// Broken rules are reported in the response, other failures as errors.
+func (uc *UseCase) ValidateOrder(ctx appcontext.AppContext, req CreateOrderRequest) (*ValidateOrderResponse, error) {
+	customer, err := uc.getCustomer(ctx.GetDefaultContext(), req.UserID)
+	if err != nil {
 		return nil, err
 	}
 
-	id, _, err := uc.idgenService.GenerateID("ORDER")
+	order, err := uc.buildOrder(ctx.GetDefaultContext(), req)
 	if err != nil {
 		return nil, err
 	}
-	order.OrderID = id
-	order.OrderCode = fmt.Sprintf("O%08d", id)
 
-	// Save order
-	if err := uc.orderService.CreateOrder(order); err != nil {
+	response := &ValidateOrderResponse{
+		Valid:       true,
+		Violations:  []RuleViolationDTO{},
+		Subtotal:    order.Subtotal,
+		Discount:    order.Discount,
+		TotalAmount: order.TotalAmount,
+	}
+
+	var violations *domainrule.ValidationError
+	if err := uc.ruleEngine.Check(toRuleOrder(order, customerTier(customer))); errors.As(err, &violations) {
+		response.Valid = false
+		response.Violations = toRuleViolationDTOs(violations.Violations)
+	} else if err != nil {
 		return nil, err
 	}
+	return response, nil
+}
 
-	// Send order to Kafka
-	err = ctx.GetKafkaProducer().Publish(domain.Message{
-		Key:   fmt.Sprintf("ORDER_CREATED_%d", order.OrderID),
-		Topic: "orders-topic",
-		Value: domain.MessageValue{
-			Meta: &domain.MetaData{
-				MessageID: fmt.Sprintf("ORDER_CREATED_%d", order.OrderID),
-				ServiceID: "order-service",
-				Timestamp: time.Now().UnixNano(),
-			},
-			MessageCode: "ORDER_CREATED",
-			Payload: map[string]any{
-				"order_id": fmt.Sprintf("%d", order.OrderID),
-				"user_id":  order.UserID,
-				"amount":   order.TotalAmount,
-				"status":   order.Status,
-			},
-		},
-	})
+func (uc *UseCase) GetOrder(ctx appcontext.AppContext, id int64) (*OrderResponse, error) {
+	order, err := uc.orderService.GetOrder(ctx.GetDefaultContext(), id)
 	if err != nil {
-		fmt.Println("Error sending order to Kafka:", err)
+		return nil, err
 	}
 
 	// Convert domain entity to response DTO
@@ -108,40 +184,733 @@ func (uc *UseCase) CreateOrder(ctx appcontext.AppContext, req CreateOrderRequest
 	return &response, nil
 }
 
-func (uc *UseCase) GetOrder(id int64) (*OrderResponse, error) {
-	order, err := uc.orderService.GetOrder(id)
+// GetOrderByCode retrieves an order by the public code customers quote. Codes
+// failing their check character are rejected without a lookup.
+func (uc *UseCase) GetOrderByCode(ctx appcontext.AppContext, code string) (*OrderResponse, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	// Codes issued before scrambling carry no check character and are looked up as they are
+	if !uc.idgenService.IsValidCode(code) && !domainidgen.IsLegacyCode(code) {
+		return nil, domainorder.ErrInvalidOrderCode
+	}
+
+	order, err := uc.orderService.GetOrderByCode(ctx.GetDefaultContext(), code)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert domain entity to response DTO
 	response := uc.mapper.ToResponse(order)
 	return &response, nil
 }
 
-// GetOrdersByUserID retrieves all orders for a specific user
-func (uc *UseCase) GetOrdersByUserID(req GetOrdersByUserIDRequest) (*OrderListResponse, error) {
-	// Prepare conditions map for filtering
-	conditions := make(map[string]any)
-	if req.Status != "" {
-		conditions["status"] = domainorder.OrderStatus(req.Status)
+// GetOrdersByUserID retrieves a page of a user's orders
+func (uc *UseCase) GetOrdersByUserID(ctx appcontext.AppContext, req GetOrdersByUserIDRequest) (*OrderListResponse, error) {
+	filter, err := uc.toFilter(req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get orders from domain service
-	orders, err := uc.orderService.GetOrderByCustomerID(req.UserID, conditions)
+	orders, next, err := uc.orderService.GetOrders(ctx.GetDefaultContext(), filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get orders: %w", err)
 	}
 
+	nextCursor, err := encodeCursor(next)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert domain entities to response DTOs
 	var orderResponses []OrderResponse
 	for _, order := range orders {
 		orderResponses = append(orderResponses, uc.mapper.ToResponse(&order))
 	}
 
-	// Return the list of orders
+	// Return the page of orders
 	return &OrderListResponse{
-		Orders: orderResponses,
-		Count:  len(orderResponses),
+		Orders:     orderResponses,
+		Count:      len(orderResponses),
+		NextCursor: nextCursor,
 	}, nil
 }
+
+// toFilter builds the domain filter of a listing request, defaulting to the
+// newest orders first in pages of defaultPageSize
+func (uc *UseCase) toFilter(req GetOrdersByUserIDRequest) (domainorder.Filter, error) {
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return domainorder.Filter{}, err
+	}
+
+	filter := domainorder.Filter{
+		UserID:      req.UserID,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		SortBy:      domainorder.SortField(req.SortBy),
+		Ascending:   req.Order == "asc",
+		After:       after,
+		Limit:       req.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	for _, status := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, domainorder.OrderStatus(status))
+	}
+	if req.MinAmount != nil {
+		minAmount := money.New(*req.MinAmount, req.Currency)
+		filter.MinAmount = &minAmount
+	}
+	if req.MaxAmount != nil {
+		maxAmount := money.New(*req.MaxAmount, req.Currency)
+		filter.MaxAmount = &maxAmount
+	}
+	return filter, nil
+}
+
+// UpdateOrderStatus moves an order to a new status and announces the change
+func (uc *UseCase) UpdateOrderStatus(ctx appcontext.AppContext, id int64, req UpdateOrderStatusRequest) (*OrderResponse, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	// Cancelling needs a reason and compensations, see CancelOrder, refunding
+	// pays the money back, see RefundOrder, only the expiry worker moves
+	// orders to expired, see ExpireOrder, and held orders are released by
+	// their review, see ApproveOrder
+	switch domainorder.OrderStatus(req.Status) {
+	case domainorder.StatusCancelled:
+		return nil, fmt.Errorf("%w: use the cancel endpoint to cancel an order", domainorder.ErrInvalidStatusTransition)
+	case domainorder.StatusRefunded:
+		return nil, fmt.Errorf("%w: use the refunds endpoint to refund an order", domainorder.ErrInvalidStatusTransition)
+	case domainorder.StatusExpired:
+		return nil, fmt.Errorf("%w: pending orders expire on their own", domainorder.ErrInvalidStatusTransition)
+	case domainorder.StatusOnHold:
+		return nil, fmt.Errorf("%w: only new orders are held for review", domainorder.ErrInvalidStatusTransition)
+	}
+	if order.Status == domainorder.StatusOnHold {
+		return nil, fmt.Errorf("%w: use the review endpoints to release a held order", domainorder.ErrInvalidStatusTransition)
+	}
+
+	before := *order
+	previousStatus := order.Status
+	if err := order.TransitionTo(domainorder.OrderStatus(req.Status)); err != nil {
+		return nil, err
+	}
+	order.UpdatedAt = time.Now()
+
+	event, err := newOrderEvent("ORDER_STATUS_CHANGED", fmt.Sprintf("ORDER_STATUS_CHANGED_%d_%s", order.OrderID, order.Status), order, map[string]any{
+		"previous_status": previousStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionStatusChanged, req.Actor, &before, order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		if err := uc.settleStock(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+
+	response := uc.mapper.ToResponse(order)
+	return &response, nil
+}
+
+// CancelOrder cancels an order for the given reason. Reserved stock is
+// released together with the status change, and orders that were paid are
+// flagged for a refund in the same transaction. The payment is voided and
+// refunded once the cancellation is committed, so a transaction retry never
+// pays out, and a failed refund stays flagged for reconciliation.
+func (uc *UseCase) CancelOrder(ctx appcontext.AppContext, id int64, req CancelOrderRequest) (*OrderResponse, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	before := snapshot(order)
+	previousStatus := order.Status
+	if err := order.Cancel(domainorder.CancelReason(req.Reason), req.Note, req.Actor, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Pending orders have not been paid, and held ones not even charged
+	paid := previousStatus != domainorder.StatusPending && previousStatus != domainorder.StatusOnHold
+	order.Cancellation.RefundDue = paid && uc.paymentCompensator != nil
+
+	event, err := newOrderEvent("ORDER_CANCELLED", fmt.Sprintf("ORDER_CANCELLED_%d", order.OrderID), order, map[string]any{
+		"previous_status": previousStatus,
+		"reason":          order.Cancellation.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionCancelled, req.Actor, before, order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		if err := uc.inventoryService.ReleaseForOrder(txCtx, order.OrderID); err != nil {
+			return err
+		}
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	// The order is closed, so an intent paid from now on is refunded rather
+	// than lost. Voiding is best effort for the same reason.
+	if uc.paymentCompensator != nil {
+		if err := uc.paymentCompensator.VoidPayment(ctx.GetDefaultContext(), order.OrderID); err != nil {
+			log.Printf("Failed to void payment of cancelled order %d: %v", order.OrderID, err)
+		}
+	}
+	if order.Cancellation.RefundDue {
+		if err := uc.refundCancelledOrder(ctx.GetDefaultContext(), order, req.Actor); err != nil {
+			log.Printf("Failed to refund cancelled order %d, left for reconciliation: %v", order.OrderID, err)
+		}
+	}
+
+	response := uc.mapper.ToResponse(order)
+	return &response, nil
+}
+
+// refundCancelledOrder refunds whatever was captured for a cancelled order
+// flagged for a refund, and clears the flag together with recording the
+// refund. The refund is keyed on the order, so retrying it pays out once.
+func (uc *UseCase) refundCancelledOrder(ctx context.Context, order *domainorder.Order, actor string) error {
+	updated := snapshot(order)
+	updated.Cancellation.RefundDue = false
+	updated.UpdatedAt = time.Now()
+
+	plan, err := updated.PlanRefund(nil)
+	switch {
+	case errors.Is(err, domainorder.ErrInvalidRefund):
+		// Nothing is left to refund
+		plan = nil
+	case err != nil:
+		return err
+	}
+
+	var event *domainoutbox.Message
+	if plan != nil {
+		refund := newRefund(updated, plan, string(updated.Cancellation.Reason), actor, fmt.Sprintf("order-%d-cancel", updated.OrderID))
+		err := uc.paymentCompensator.RefundPayment(ctx, refund)
+		switch {
+		case errors.Is(err, domainpayment.ErrNoCapturedPayment):
+			// The order was not paid through the payment gateway
+		case err != nil:
+			return err
+		default:
+			if err := updated.ApplyRefund(plan, updated.UpdatedAt); err != nil {
+				return err
+			}
+			if event, err = newRefundEvent(updated, refund); err != nil {
+				return err
+			}
+		}
+	}
+
+	var entry *domainhistory.Entry
+	if event != nil {
+		if entry, err = newHistoryEntry(domainhistory.ActionRefunded, actor, order, updated); err != nil {
+			return err
+		}
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		if err := uc.orderService.UpdateOrder(txCtx, updated); err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	*order = *updated
+	return nil
+}
+
+// RefundOrder returns money to the customer for the given items, or for
+// everything not refunded yet. The payment provider is called first and the
+// refund recorded under a key derived from the order version, so retrying a
+// refund whose order update failed does not pay out twice.
+func (uc *UseCase) RefundOrder(ctx appcontext.AppContext, id int64, req RefundOrderRequest) (*RefundResponse, error) {
+	if uc.paymentCompensator == nil {
+		return nil, fmt.Errorf("%w: payments are not configured", domainpayment.ErrNoCapturedPayment)
+	}
+
+	order, err := uc.orderService.GetOrderForUpdate(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	lines := make([]domainorder.RefundLine, len(req.Items))
+	for i, item := range req.Items {
+		lines[i] = domainorder.RefundLine{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	plan, err := order.PlanRefund(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := newRefund(order, plan, req.Reason, req.Actor, fmt.Sprintf("order-%d-v%d-refund", order.OrderID, order.Version))
+	if err := uc.paymentCompensator.RefundPayment(ctx.GetDefaultContext(), refund); err != nil {
+		return nil, err
+	}
+
+	before := snapshot(order)
+	if err := order.ApplyRefund(plan, time.Now()); err != nil {
+		return nil, err
+	}
+	// Orders refunded in full before they ship give their reserved stock back
+	releaseStock := order.Status == domainorder.StatusRefunded &&
+		(before.Status == domainorder.StatusPaid || before.Status == domainorder.StatusProcessing)
+
+	event, err := newRefundEvent(order, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionRefunded, req.Actor, before, order)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		if releaseStock {
+			if err := uc.inventoryService.ReleaseForOrder(txCtx, order.OrderID); err != nil {
+				return err
+			}
+		}
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	response := uc.mapper.ToRefundResponse(refund, order)
+	return &response, nil
+}
+
+// RefundLateCapture refunds a payment collected for an order that was
+// cancelled or expired before it could be paid. The refund is keyed on the
+// intent, so calling it again for the same payment pays nothing out twice.
+func (uc *UseCase) RefundLateCapture(ctx context.Context, orderID int64, intentID, actor string) error {
+	if uc.paymentCompensator == nil {
+		return fmt.Errorf("%w: payments are not configured", domainpayment.ErrNoCapturedPayment)
+	}
+
+	order, err := uc.orderService.GetOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	// Payments of orders still open are reconciled by hand
+	if order.Status != domainorder.StatusCancelled && order.Status != domainorder.StatusExpired {
+		return fmt.Errorf("%w: order is %s", domainorder.ErrOrderNotRefundable, order.Status)
+	}
+
+	plan, err := order.PlanRefund(nil)
+	if err != nil {
+		return err
+	}
+
+	refund := newRefund(order, plan, "payment captured after the order was closed", actor, fmt.Sprintf("order-%d-%s-late-capture", order.OrderID, intentID))
+	if err := uc.paymentCompensator.RefundPayment(ctx, refund); err != nil {
+		return err
+	}
+
+	before := snapshot(order)
+	if err := order.ApplyRefund(plan, time.Now()); err != nil {
+		return err
+	}
+
+	event, err := newRefundEvent(order, refund)
+	if err != nil {
+		return err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionRefunded, actor, before, order)
+	if err != nil {
+		return err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+	return nil
+}
+
+// MarkAsPaid moves a pending order to paid once its payment has been collected.
+// It must be called with a transaction context; the caller commits the order
+// change together with its own payment records.
+func (uc *UseCase) MarkAsPaid(ctx context.Context, orderID int64, intentID string, amount money.Money, actor string) error {
+	order, err := uc.orderService.GetOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	before := snapshot(order)
+	if err := order.TransitionTo(domainorder.StatusPaid); err != nil {
+		return err
+	}
+	order.UpdatedAt = time.Now()
+
+	event, err := newOrderEvent("ORDER_PAID", fmt.Sprintf("ORDER_PAID_%d", order.OrderID), order, map[string]any{
+		"amount":            amount,
+		"payment_intent_id": intentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionPaid, actor, before, order)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.orderService.UpdateOrder(ctx, order); err != nil {
+		return err
+	}
+	if err := uc.historyRepo.CreateEntry(ctx, entry); err != nil {
+		return err
+	}
+	return uc.outboxRepo.CreateMessage(ctx, event)
+}
+
+// AdvanceFulfilment moves an order forward to next, one of processing,
+// shipped or delivered, within the caller's transaction. The order is saved
+// even when it is already there, so that concurrent shipments of an order
+// conflict on its version. Orders that moved past next are left as they are.
+func (uc *UseCase) AdvanceFulfilment(ctx context.Context, order *domainorder.Order, next domainorder.OrderStatus, actor string) error {
+	before := snapshot(order)
+	previousStatus := order.Status
+	for _, status := range []domainorder.OrderStatus{domainorder.StatusProcessing, domainorder.StatusShipped, domainorder.StatusDelivered} {
+		if order.Status == next || next.CanReach(order.Status) {
+			break
+		}
+		if order.Status.CanTransitionTo(status) {
+			if err := order.TransitionTo(status); err != nil {
+				return err
+			}
+			// Stock is consumed even if the order goes past shipped at once
+			if err := uc.settleStock(ctx, order); err != nil {
+				return err
+			}
+		}
+	}
+	if order.Status != next && !next.CanReach(order.Status) {
+		return fmt.Errorf("%w: %s -> %s", domainorder.ErrInvalidStatusTransition, previousStatus, next)
+	}
+	order.UpdatedAt = time.Now()
+
+	if order.Status == previousStatus {
+		return uc.orderService.UpdateOrder(ctx, order)
+	}
+
+	event, err := newOrderEvent("ORDER_STATUS_CHANGED", fmt.Sprintf("ORDER_STATUS_CHANGED_%d_%s", order.OrderID, order.Status), order, map[string]any{
+		"previous_status": previousStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionStatusChanged, actor, before, order)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.orderService.UpdateOrder(ctx, order); err != nil {
+		return err
+	}
+	if err := uc.historyRepo.CreateEntry(ctx, entry); err != nil {
+		return err
+	}
+	return uc.outboxRepo.CreateMessage(ctx, event)
+}
+
+// ExpireOrder moves a pending order created before createdBefore to expired
+// and releases its stock. Approved orders are timed from their approval. It
+// reports false when the order no longer needs to expire, including when
+// another replica or a payment changed it first.
+func (uc *UseCase) ExpireOrder(ctx context.Context, orderID int64, createdBefore time.Time) (bool, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return false, err
+	}
+	if order.Status != domainorder.StatusPending || !order.PendingSince().Before(createdBefore) {
+		return false, nil
+	}
+
+	before := snapshot(order)
+	if err := order.TransitionTo(domainorder.StatusExpired); err != nil {
+		return false, err
+	}
+	order.UpdatedAt = time.Now()
+
+	event, err := newOrderEvent("ORDER_EXPIRED", fmt.Sprintf("ORDER_EXPIRED_%d", order.OrderID), order, map[string]any{
+		"created_at": order.CreatedAt,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	entry, err := newHistoryEntry(domainhistory.ActionExpired, expiryActor, before, order)
+	if err != nil {
+		return false, err
+	}
+
+	err = uc.txManager.ExecuteInTx(ctx, func(txCtx context.Context) error {
+		if err := uc.inventoryService.ReleaseForOrder(txCtx, order.OrderID); err != nil {
+			return err
+		}
+		if err := uc.orderService.UpdateOrder(txCtx, order); err != nil {
+			return err
+		}
+		if err := uc.historyRepo.CreateEntry(txCtx, entry); err != nil {
+			return err
+		}
+		return uc.outboxRepo.CreateMessage(txCtx, event)
+	})
+	if errors.Is(err, domainorder.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to expire order %d: %w", order.OrderID, err)
+	}
+
+	// The order is closed, so an intent paid from now on is refunded rather
+	// than lost. Voiding is best effort for the same reason.
+	if uc.paymentCompensator != nil {
+		if err := uc.paymentCompensator.VoidPayment(ctx, order.OrderID); err != nil {
+			log.Printf("Failed to void payment of expired order %d: %v", order.OrderID, err)
+		}
+	}
+	return true, nil
+}
+
+// GetOrderHistory returns every recorded mutation of an order, oldest first
+func (uc *UseCase) GetOrderHistory(ctx appcontext.AppContext, id int64) (*OrderHistoryResponse, error) {
+	if _, err := uc.orderService.GetOrder(ctx.GetDefaultContext(), id); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.historyRepo.GetEntries(ctx.GetDefaultContext(), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order history: %w", err)
+	}
+
+	response := uc.mapper.ToHistoryResponse(id, entries)
+	return &response, nil
+}
+
+// settleStock consumes the stock reserved for an order once it ships
+func (uc *UseCase) settleStock(ctx context.Context, order *domainorder.Order) error {
+	switch order.Status {
+	case domainorder.StatusShipped:
+		return uc.inventoryService.CommitForOrder(ctx, order.OrderID)
+	}
+	return nil
+}
+
+// priceItems snapshots the current catalog name and price onto each order item
+// so later catalog changes do not alter existing orders
+func (uc *UseCase) priceItems(ctx context.Context, items []domainorder.OrderItem) error {
+	skus := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			skus = append(skus, item.ProductID)
+		}
+	}
+
+	catalog, err := uc.productService.GetSellableProducts(ctx, skus)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		product := catalog[items[i].ProductID]
+		items[i].Name = product.Name
+		items[i].Price = product.Price
+	}
+	return nil
+}
+
+func toReservationItems(items []domainorder.OrderItem) []domaininventory.ReservationItem {
+	reservationItems := make([]domaininventory.ReservationItem, len(items))
+	for i, item := range items {
+		reservationItems[i] = domaininventory.ReservationItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+	return reservationItems
+}
+
+func toPricedItems(items []domainorder.OrderItem) []domainvoucher.PricedItem {
+	pricedItems := make([]domainvoucher.PricedItem, len(items))
+	for i, item := range items {
+		pricedItems[i] = domainvoucher.PricedItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+		}
+	}
+	return pricedItems
+}
+
+// checkVersion rejects a change made from a version of the order the client no
+// longer holds. A nil expected version skips the check.
+func checkVersion(order *domainorder.Order, expected *int64) error {
+	if expected != nil && *expected != order.Version {
+		return fmt.Errorf("%w: order %d is at version %d", domainorder.ErrVersionMismatch, order.OrderID, order.Version)
+	}
+	return nil
+}
+
+// snapshot copies an order, items and risk included, to diff it after a change
+func snapshot(order *domainorder.Order) *domainorder.Order {
+	before := *order
+	before.Items = slices.Clone(order.Items)
+	if order.Risk != nil {
+		risk := *order.Risk
+		before.Risk = &risk
+	}
+	if order.Cancellation != nil {
+		cancellation := *order.Cancellation
+		before.Cancellation = &cancellation
+	}
+	return &before
+}
+
+// newRefund builds the refund record of a planned refund
+func newRefund(order *domainorder.Order, plan *domainorder.RefundPlan, reason, actor, idempotencyKey string) *domainpayment.Refund {
+	items := make([]domainpayment.RefundItem, len(plan.Lines))
+	for i, line := range plan.Lines {
+		items[i] = domainpayment.RefundItem{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			Amount:    line.Amount,
+		}
+	}
+	return &domainpayment.Refund{
+		OrderID:        order.OrderID,
+		Amount:         plan.Amount,
+		Items:          items,
+		Reason:         reason,
+		RequestedBy:    actor,
+		IdempotencyKey: idempotencyKey,
+	}
+}
+
+// newRefundEvent builds the ORDER_REFUNDED message of a refund
+func newRefundEvent(order *domainorder.Order, refund *domainpayment.Refund) (*domainoutbox.Message, error) {
+	return newOrderEvent("ORDER_REFUNDED", "ORDER_REFUNDED_"+refund.RefundID, order, map[string]any{
+		"refund_id":       refund.RefundID,
+		"amount":          refund.Amount,
+		"amount_text":     refund.Amount.String(),
+		"refunded_amount": order.RefundedAmount,
+		"items":           refund.Items,
+		"reason":          refund.Reason,
+	})
+}
+
+// newHistoryEntry records a mutation of an order by actor. before is nil for
+// a new order, in which case every field of after shows up as changed.
+func newHistoryEntry(action domainhistory.Action, actor string, before, after *domainorder.Order) (*domainhistory.Entry, error) {
+	changes, err := domainhistory.Diff(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domainhistory.Entry{
+		OrderID:   after.OrderID,
+		Action:    action,
+		Actor:     actor,
+		NewStatus: string(after.Status),
+		Changes:   changes,
+		CreatedAt: after.UpdatedAt,
+	}
+	if before != nil {
+		entry.PreviousStatus = string(before.Status)
+	}
+	return entry, nil
+}
+
+// newOrderEvent builds the outbox message for an order event. The payload always
+// carries the order ID, user ID and current status; extra adds event specific fields.
+func newOrderEvent(messageCode, messageID string, order *domainorder.Order, extra map[string]any) (*domainoutbox.Message, error) {
+	payload := map[string]any{
+		"order_id": fmt.Sprintf("%d", order.OrderID),
+		"user_id":  order.UserID,
+		"status":   order.Status,
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+
+	return domainoutbox.NewMessage(
+		ordersTopic,
+		fmt.Sprintf("%s_%d", messageCode, order.OrderID),
+		messageCode,
+		messageID,
+		payload,
+	)
+}
@@ -0,0 +1,205 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DuongVu089x/interview/order/application/saga"
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainhistory "github.com/DuongVu089x/interview/order/domain/history"
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainoutbox "github.com/DuongVu089x/interview/order/domain/outbox"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAppContext only carries a context; other methods are unused
+type fakeAppContext struct {
+	appcontext.AppContext
+}
+
+func (c fakeAppContext) GetDefaultContext() context.Context {
+	return context.Background()
+}
+
+// memoryOrderService stores orders in memory with the version check of the
+// Mongo repository
+type memoryOrderService struct {
+	domainorder.Service
+	orders map[int64]domainorder.Order
+}
+
+func (s *memoryOrderService) GetOrderForUpdate(ctx context.Context, id int64) (*domainorder.Order, error) {
+	order, ok := s.orders[id]
+	if !ok {
+		return nil, domainorder.ErrOrderNotFound
+	}
+	return snapshot(&order), nil
+}
+
+func (s *memoryOrderService) UpdateOrder(ctx context.Context, order *domainorder.Order) error {
+	if s.orders[order.OrderID].Version != order.Version {
+		return &domainorder.VersionConflictError{OrderID: order.OrderID, Version: order.Version}
+	}
+	order.Version++
+	s.orders[order.OrderID] = *snapshot(order)
+	return nil
+}
+
+// fakeInventoryService records the orders whose stock was released
+type fakeInventoryService struct {
+	domaininventory.Service
+	released []int64
+}
+
+func (s *fakeInventoryService) ReleaseForOrder(ctx context.Context, orderID int64) error {
+	s.released = append(s.released, orderID)
+	return nil
+}
+
+// fakeOutbox records the messages written
+type fakeOutbox struct {
+	domainoutbox.Repository
+	codes []string
+}
+
+func (r *fakeOutbox) CreateMessage(ctx context.Context, message *domainoutbox.Message) error {
+	r.codes = append(r.codes, message.MessageCode)
+	return nil
+}
+
+// fakeHistory records the history entries written
+type fakeHistory struct {
+	domainhistory.Repository
+	actions []domainhistory.Action
+}
+
+func (r *fakeHistory) CreateEntry(ctx context.Context, entry *domainhistory.Entry) error {
+	r.actions = append(r.actions, entry.Action)
+	return nil
+}
+
+// fakeTxManager runs transactions without one
+type fakeTxManager struct{}
+
+func (fakeTxManager) ExecuteInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeCompensator refunds the orders it holds a captured payment for
+type fakeCompensator struct {
+	captured  map[int64]bool
+	refundErr error
+	voided    []int64
+	refunds   []domainpayment.Refund
+}
+
+func (c *fakeCompensator) VoidPayment(ctx context.Context, orderID int64) error {
+	c.voided = append(c.voided, orderID)
+	return nil
+}
+
+func (c *fakeCompensator) RefundPayment(ctx context.Context, refund *domainpayment.Refund) error {
+	if c.refundErr != nil {
+		return c.refundErr
+	}
+	if !c.captured[refund.OrderID] {
+		return domainpayment.ErrNoCapturedPayment
+	}
+	refund.RefundID = fmt.Sprintf("re_%d", len(c.refunds)+1)
+	c.refunds = append(c.refunds, *refund)
+	return nil
+}
+
+type cancelFixture struct {
+	uc          *UseCase
+	orders      *memoryOrderService
+	inventory   *fakeInventoryService
+	outbox      *fakeOutbox
+	history     *fakeHistory
+	compensator *fakeCompensator
+}
+
+func newCancelFixture(order domainorder.Order) *cancelFixture {
+	f := &cancelFixture{
+		orders:      &memoryOrderService{orders: map[int64]domainorder.Order{order.OrderID: order}},
+		inventory:   &fakeInventoryService{},
+		outbox:      &fakeOutbox{},
+		history:     &fakeHistory{},
+		compensator: &fakeCompensator{captured: map[int64]bool{}},
+	}
+	f.uc = NewOrderUseCase(f.orders, nil, f.inventory, nil, nil, nil, f.outbox, f.history, fakeTxManager{},
+		nil, f.compensator, saga.NewOrchestrator(nil, saga.Config{}), nil, nil)
+	return f
+}
+
+func newTestOrder(status domainorder.OrderStatus) domainorder.Order {
+	return domainorder.Order{
+		OrderID:     1,
+		UserID:      "user-1",
+		Status:      status,
+		Items:       []domainorder.OrderItem{{ProductID: "sku-1", Quantity: 2, Price: money.New(500, "USD")}},
+		Subtotal:    money.New(1000, "USD"),
+		TotalAmount: money.New(1000, "USD"),
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	tests := []struct {
+		status   domainorder.OrderStatus
+		refunded bool
+	}{
+		{status: domainorder.StatusPending},
+		{status: domainorder.StatusOnHold},
+		{status: domainorder.StatusPaid, refunded: true},
+		{status: domainorder.StatusProcessing, refunded: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			f := newCancelFixture(newTestOrder(tt.status))
+			// A payment captured for an unpaid order is refunded on its
+			// late capture, never by the cancellation
+			f.compensator.captured[1] = true
+
+			resp, err := f.uc.CancelOrder(fakeAppContext{}, 1, CancelOrderRequest{Reason: "customer_request", Actor: "user-1"})
+			assert.NoError(t, err)
+			assert.Equal(t, string(domainorder.StatusCancelled), resp.Status)
+
+			assert.Equal(t, []int64{1}, f.compensator.voided, "every cancelled order is voided")
+			assert.Equal(t, []int64{1}, f.inventory.released)
+
+			stored := f.orders.orders[1]
+			assert.False(t, stored.Cancellation.RefundDue)
+			if tt.refunded {
+				assert.Len(t, f.compensator.refunds, 1)
+				assert.Equal(t, "order-1-cancel", f.compensator.refunds[0].IdempotencyKey)
+				assert.Equal(t, money.New(1000, "USD"), stored.RefundedAmount)
+				assert.Equal(t, []string{"ORDER_CANCELLED", "ORDER_REFUNDED"}, f.outbox.codes)
+				assert.Equal(t, []domainhistory.Action{domainhistory.ActionCancelled, domainhistory.ActionRefunded}, f.history.actions)
+			} else {
+				assert.Empty(t, f.compensator.refunds)
+				assert.True(t, stored.RefundedAmount.IsZero())
+				assert.Equal(t, []string{"ORDER_CANCELLED"}, f.outbox.codes)
+			}
+		})
+	}
+}
+
+func TestCancelOrderRefundFailure(t *testing.T) {
+	f := newCancelFixture(newTestOrder(domainorder.StatusPaid))
+	f.compensator.refundErr = errors.New("provider down")
+
+	resp, err := f.uc.CancelOrder(fakeAppContext{}, 1, CancelOrderRequest{Reason: "customer_request", Actor: "user-1"})
+	assert.NoError(t, err, "the cancellation is committed before the refund")
+	assert.True(t, resp.Cancellation.RefundDue)
+
+	stored := f.orders.orders[1]
+	assert.Equal(t, domainorder.StatusCancelled, stored.Status)
+	assert.True(t, stored.Cancellation.RefundDue, "failed refunds stay flagged for reconciliation")
+	assert.Equal(t, []string{"ORDER_CANCELLED"}, f.outbox.codes)
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	domainoutbox "github.com/DuongVu089x/interview/order/domain/outbox"
+)
+
+// RelayConfig holds the polling and retry behaviour of the outbox relay
+type RelayConfig struct {
+	ServiceID           string
+	PollInterval        time.Duration // How often pending messages are polled
+	BatchSize           int           // Maximum messages published per poll
+	LeaseDuration       time.Duration // How long a claimed message is hidden from other relays
+	MaxAttempts         int           // Attempts before a message is marked as failed
+	RetryBackoffInitial time.Duration // Initial backoff duration
+	RetryBackoffMax     time.Duration // Maximum backoff duration
+}
+
+// Relay publishes pending outbox messages to the message broker
+type Relay struct {
+	outboxRepo domainoutbox.Repository
+	producer   port.MessageProducer
+	config     RelayConfig
+}
+
+func NewRelay(outboxRepo domainoutbox.Repository, producer port.MessageProducer, config RelayConfig) *Relay {
+	if config.ServiceID == "" {
+		config.ServiceID = "order-service"
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = 1 * time.Second
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 100
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = 30 * time.Second
+	}
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = 10
+	}
+	if config.RetryBackoffInitial == 0 {
+		config.RetryBackoffInitial = 1 * time.Second
+	}
+	if config.RetryBackoffMax == 0 {
+		config.RetryBackoffMax = 5 * time.Minute
+	}
+
+	return &Relay{
+		outboxRepo: outboxRepo,
+		producer:   producer,
+		config:     config,
+	}
+}
+
+// Start polls the outbox until the context is cancelled
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.ProcessPending(ctx); err != nil {
+				log.Printf("Outbox relay error: %v", err)
+			}
+		}
+	}
+}
+
+// ProcessPending publishes up to one batch of due messages and returns how many were sent
+func (r *Relay) ProcessPending(ctx context.Context) (int, error) {
+	sent := 0
+	for i := 0; i < r.config.BatchSize; i++ {
+		now := time.Now()
+		message, err := r.outboxRepo.ClaimPending(ctx, now, now.Add(r.config.LeaseDuration))
+		if errors.Is(err, domainoutbox.ErrNoPendingMessage) {
+			break
+		}
+		if err != nil {
+			return sent, err
+		}
+
+		if err := r.producer.Publish(message.ToDomainMessage(r.config.ServiceID)); err != nil {
+			r.handleFailure(ctx, message, err)
+			continue
+		}
+
+		if err := r.outboxRepo.MarkAsSent(ctx, *message.ID, time.Now()); err != nil {
+			// The lease expires and the message is published again, which
+			// consumers already tolerate through the message ID
+			log.Printf("Failed to mark outbox message %s as sent: %v", message.MessageID, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (r *Relay) handleFailure(ctx context.Context, message *domainoutbox.Message, publishErr error) {
+	attempts := message.Attempts + 1
+
+	var err error
+	if attempts >= r.config.MaxAttempts {
+		log.Printf("Outbox message %s failed after %d attempts: %v", message.MessageID, attempts, publishErr)
+		err = r.outboxRepo.MarkAsFailed(ctx, *message.ID, attempts, publishErr.Error())
+	} else {
+		err = r.outboxRepo.ScheduleRetry(ctx, *message.ID, attempts, time.Now().Add(r.backoff(attempts)), publishErr.Error())
+	}
+	if err != nil {
+		log.Printf("Failed to record outbox failure for %s: %v", message.MessageID, err)
+	}
+}
+
+// backoff doubles the delay on every attempt up to RetryBackoffMax
+func (r *Relay) backoff(attempt int) time.Duration {
+	delay := r.config.RetryBackoffInitial
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= r.config.RetryBackoffMax {
+			return r.config.RetryBackoffMax
+		}
+	}
+	return delay
+}
@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain"
+	domainoutbox "github.com/DuongVu089x/interview/order/domain/outbox"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeRepository keeps outbox messages in memory
+type fakeRepository struct {
+	messages []*domainoutbox.Message
+}
+
+func (r *fakeRepository) CreateMessage(ctx context.Context, message *domainoutbox.Message) error {
+	id := primitive.NewObjectID()
+	message.ID = &id
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func (r *fakeRepository) ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time) (*domainoutbox.Message, error) {
+	for _, m := range r.messages {
+		if m.Status == domainoutbox.StatusPending && !m.NextAttemptAt.After(now) {
+			m.NextAttemptAt = leaseUntil
+			return m, nil
+		}
+	}
+	return nil, domainoutbox.ErrNoPendingMessage
+}
+
+func (r *fakeRepository) find(id primitive.ObjectID) *domainoutbox.Message {
+	for _, m := range r.messages {
+		if *m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepository) MarkAsSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error {
+	m := r.find(id)
+	m.Status = domainoutbox.StatusSent
+	m.SentAt = &sentAt
+	return nil
+}
+
+func (r *fakeRepository) ScheduleRetry(ctx context.Context, id primitive.ObjectID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	m := r.find(id)
+	m.Attempts = attempts
+	m.NextAttemptAt = nextAttemptAt
+	m.LastError = lastError
+	return nil
+}
+
+func (r *fakeRepository) MarkAsFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string) error {
+	m := r.find(id)
+	m.Status = domainoutbox.StatusFailed
+	m.Attempts = attempts
+	m.LastError = lastError
+	return nil
+}
+
+// fakeProducer records published messages and fails while err is set
+type fakeProducer struct {
+	published []domain.Message
+	err       error
+}
+
+func (p *fakeProducer) Publish(message domain.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, message)
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	return nil
+}
+
+func newPendingMessage(t *testing.T, repo *fakeRepository, messageID string) *domainoutbox.Message {
+	message, err := domainoutbox.NewMessage("orders-topic", "ORDER_CREATED_1", "ORDER_CREATED", messageID, map[string]any{"order_id": "1"})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.CreateMessage(context.Background(), message))
+	return message
+}
+
+func TestProcessPendingPublishesAndMarksSent(t *testing.T) {
+	repo := &fakeRepository{}
+	producer := &fakeProducer{}
+	relay := NewRelay(repo, producer, RelayConfig{})
+
+	message := newPendingMessage(t, repo, "ORDER_CREATED_1")
+
+	sent, err := relay.ProcessPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, domainoutbox.StatusSent, message.Status)
+	assert.Len(t, producer.published, 1)
+	assert.Equal(t, "ORDER_CREATED", producer.published[0].Value.MessageCode)
+	assert.Equal(t, "ORDER_CREATED_1", producer.published[0].Value.Meta.MessageID)
+}
+
+func TestProcessPendingSchedulesRetryThenFails(t *testing.T) {
+	repo := &fakeRepository{}
+	producer := &fakeProducer{err: errors.New("broker unavailable")}
+	relay := NewRelay(repo, producer, RelayConfig{MaxAttempts: 2})
+
+	message := newPendingMessage(t, repo, "ORDER_CREATED_1")
+
+	sent, err := relay.ProcessPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Equal(t, domainoutbox.StatusPending, message.Status)
+	assert.Equal(t, 1, message.Attempts)
+	assert.True(t, message.NextAttemptAt.After(time.Now()))
+
+	// Make the message due again and let the last attempt fail
+	message.NextAttemptAt = time.Now()
+	_, err = relay.ProcessPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, domainoutbox.StatusFailed, message.Status)
+	assert.Equal(t, 2, message.Attempts)
+	assert.Equal(t, "broker unavailable", message.LastError)
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	relay := NewRelay(&fakeRepository{}, &fakeProducer{}, RelayConfig{
+		RetryBackoffInitial: time.Second,
+		RetryBackoffMax:     5 * time.Second,
+	})
+
+	assert.Equal(t, 1*time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 4*time.Second, relay.backoff(3))
+	assert.Equal(t, 5*time.Second, relay.backoff(4))
+}
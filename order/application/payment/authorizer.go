@@ -0,0 +1,70 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+// Authorizer creates payment intents for orders through the payment provider
+type Authorizer struct {
+	paymentService domainpayment.Service
+	gateway        port.PaymentGateway
+}
+
+// Ensure Authorizer implements PaymentAuthorizer
+var _ port.PaymentAuthorizer = (*Authorizer)(nil)
+
+func NewAuthorizer(paymentService domainpayment.Service, gateway port.PaymentGateway) *Authorizer {
+	return &Authorizer{
+		paymentService: paymentService,
+		gateway:        gateway,
+	}
+}
+
+// AuthorizePayment keeps an order to at most one open intent: asking again
+// returns the existing one
+func (a *Authorizer) AuthorizePayment(ctx context.Context, order *domainorder.Order) (*domainpayment.Intent, error) {
+	intents, err := a.paymentService.GetIntentsByOrder(ctx, order.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intents: %w", err)
+	}
+	for i := range intents {
+		if intents[i].IsOpen() {
+			return &intents[i], nil
+		}
+	}
+
+	// The key changes with every attempt, so a new intent can follow a
+	// declined one, while a retried attempt reuses the gateway's intent
+	result, err := a.gateway.CreateIntent(ctx, port.PaymentIntentRequest{
+		OrderID:        order.OrderID,
+		UserID:         order.UserID,
+		Amount:         order.TotalAmount,
+		IdempotencyKey: fmt.Sprintf("order-%d-%d", order.OrderID, len(intents)+1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	now := time.Now()
+	intent := &domainpayment.Intent{
+		IntentID:     result.IntentID,
+		OrderID:      order.OrderID,
+		UserID:       order.UserID,
+		Provider:     a.gateway.Name(),
+		Amount:       order.TotalAmount,
+		Status:       domainpayment.IntentRequiresPayment,
+		ClientSecret: result.ClientSecret,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := a.paymentService.CreateIntent(ctx, intent); err != nil {
+		return nil, fmt.Errorf("failed to save payment intent: %w", err)
+	}
+	return intent, nil
+}
@@ -0,0 +1,116 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+// Compensator voids and refunds the payments of orders through the payment
+// provider
+type Compensator struct {
+	paymentService domainpayment.Service
+	gateway        port.PaymentGateway
+	refundProvider port.RefundProvider
+}
+
+// Ensure Compensator implements PaymentCompensator
+var _ port.PaymentCompensator = (*Compensator)(nil)
+
+func NewCompensator(paymentService domainpayment.Service, gateway port.PaymentGateway, refundProvider port.RefundProvider) *Compensator {
+	return &Compensator{
+		paymentService: paymentService,
+		gateway:        gateway,
+		refundProvider: refundProvider,
+	}
+}
+
+// VoidPayment voids the open intents of the order. Captured payments are left
+// to RefundPayment.
+func (c *Compensator) VoidPayment(ctx context.Context, orderID int64) error {
+	intents, err := c.paymentService.GetIntentsByOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for i := range intents {
+		intent := &intents[i]
+		if !intent.IsOpen() {
+			continue
+		}
+		if err := c.gateway.CancelIntent(ctx, intent.IntentID); err != nil {
+			return fmt.Errorf("failed to cancel payment intent %s: %w", intent.IntentID, err)
+		}
+		if err := intent.Cancel(time.Now()); err != nil {
+			return err
+		}
+		if err := c.paymentService.UpdateIntent(ctx, intent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefundPayment refunds from the first captured intent of the order that has
+// enough left. The refund is recorded as soon as the provider accepts it, so
+// a retry after a later failure does not pay out twice.
+func (c *Compensator) RefundPayment(ctx context.Context, refund *domainpayment.Refund) error {
+	existing, err := c.paymentService.GetRefundByKey(ctx, refund.IdempotencyKey)
+	if err == nil {
+		*refund = *existing
+		return nil
+	}
+	if !errors.Is(err, domainpayment.ErrRefundNotFound) {
+		return err
+	}
+
+	intent, err := c.refundableIntent(ctx, refund)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.refundProvider.Refund(ctx, port.RefundRequest{
+		IntentID:       intent.IntentID,
+		Amount:         refund.Amount,
+		IdempotencyKey: refund.IdempotencyKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refund payment intent %s: %w", intent.IntentID, err)
+	}
+
+	refund.RefundID = result.RefundID
+	refund.IntentID = intent.IntentID
+	refund.Provider = intent.Provider
+	refund.CreatedAt = time.Now()
+	return c.paymentService.CreateRefund(ctx, refund)
+}
+
+func (c *Compensator) refundableIntent(ctx context.Context, refund *domainpayment.Refund) (*domainpayment.Intent, error) {
+	intents, err := c.paymentService.GetIntentsByOrder(ctx, refund.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range intents {
+		intent := &intents[i]
+		if intent.Status != domainpayment.IntentSucceeded {
+			continue
+		}
+		refunded, err := c.paymentService.RefundedAmount(ctx, intent)
+		if err != nil {
+			return nil, err
+		}
+		left, err := intent.Amount.Sub(refunded)
+		if err != nil {
+			return nil, err
+		}
+		if cmp, err := refund.Amount.Compare(left); err == nil && cmp <= 0 {
+			return intent, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: order %d", domainpayment.ErrNoCapturedPayment, refund.OrderID)
+}
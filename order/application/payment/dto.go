@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+type PaymentIntentResponse struct {
+	IntentID      string      `json:"intentId"`
+	OrderID       int64       `json:"orderId"`
+	Provider      string      `json:"provider"`
+	Amount        money.Money `json:"amount"`
+	Status        string      `json:"status"`
+	ClientSecret  string      `json:"clientSecret,omitempty"`
+	FailureReason string      `json:"failureReason,omitempty"`
+	RefundDue     bool        `json:"refundDue,omitempty"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+}
+
+type PaymentIntentListResponse struct {
+	OrderID int64                   `json:"orderId"`
+	Intents []PaymentIntentResponse `json:"intents"`
+	Count   int                     `json:"count"`
+}
+
+type RefundResponse struct {
+	RefundID    string          `json:"refundId"`
+	IntentID    string          `json:"intentId"`
+	Provider    string          `json:"provider"`
+	Amount      money.Money     `json:"amount"`
+	Items       []RefundItemDTO `json:"items,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	RequestedBy string          `json:"requestedBy,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+type RefundItemDTO struct {
+	ProductID string      `json:"productId"`
+	Quantity  int         `json:"quantity"`
+	Amount    money.Money `json:"amount"`
+}
+
+type RefundListResponse struct {
+	OrderID int64            `json:"orderId"`
+	Refunds []RefundResponse `json:"refunds"`
+	Count   int              `json:"count"`
+}
+
+// WebhookResponse acknowledges a gateway callback. Status is "processed" or
+// "duplicate" when the callback had already been handled.
+type WebhookResponse struct {
+	Status string `json:"status"`
+}
@@ -0,0 +1,64 @@
+package payment
+
+import (
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToResponse(intent *domainpayment.Intent) PaymentIntentResponse {
+	return PaymentIntentResponse{
+		IntentID:      intent.IntentID,
+		OrderID:       intent.OrderID,
+		Provider:      intent.Provider,
+		Amount:        intent.Amount,
+		Status:        string(intent.Status),
+		ClientSecret:  intent.ClientSecret,
+		FailureReason: intent.FailureReason,
+		RefundDue:     intent.RefundDue,
+		CreatedAt:     intent.CreatedAt,
+		UpdatedAt:     intent.UpdatedAt,
+	}
+}
+
+func (m *Mapper) ToListResponse(orderID int64, intents []domainpayment.Intent) PaymentIntentListResponse {
+	responses := make([]PaymentIntentResponse, len(intents))
+	for i := range intents {
+		responses[i] = m.ToResponse(&intents[i])
+	}
+	return PaymentIntentListResponse{
+		OrderID: orderID,
+		Intents: responses,
+		Count:   len(responses),
+	}
+}
+
+func (m *Mapper) ToRefundListResponse(orderID int64, refunds []domainpayment.Refund) RefundListResponse {
+	responses := make([]RefundResponse, len(refunds))
+	for i, refund := range refunds {
+		items := make([]RefundItemDTO, len(refund.Items))
+		for j, item := range refund.Items {
+			items[j] = RefundItemDTO{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+				Amount:    item.Amount,
+			}
+		}
+		responses[i] = RefundResponse{
+			RefundID:    refund.RefundID,
+			IntentID:    refund.IntentID,
+			Provider:    refund.Provider,
+			Amount:      refund.Amount,
+			Items:       items,
+			Reason:      refund.Reason,
+			RequestedBy: refund.RequestedBy,
+			CreatedAt:   refund.CreatedAt,
+		}
+	}
+	return RefundListResponse{
+		OrderID: orderID,
+		Refunds: responses,
+		Count:   len(responses),
+	}
+}
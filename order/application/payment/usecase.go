@@ -0,0 +1,196 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+const (
+	webhookProcessed = "processed"
+	webhookDuplicate = "duplicate"
+)
+
+// OrderPayer moves an order to paid within the caller's transaction, and
+// refunds payments collected for orders that were closed meanwhile
+type OrderPayer interface {
+	MarkAsPaid(ctx context.Context, orderID int64, intentID string, amount money.Money, actor string) error
+	RefundLateCapture(ctx context.Context, orderID int64, intentID, actor string) error
+}
+
+type UseCase struct {
+	mapper         *Mapper
+	paymentService domainpayment.Service
+	orderService   domainorder.Service
+	orderPayer     OrderPayer
+	authorizer     port.PaymentAuthorizer
+	gateway        port.PaymentGateway
+	txManager      port.TransactionManager
+}
+
+func NewPaymentUseCase(
+	paymentService domainpayment.Service,
+	orderService domainorder.Service,
+	orderPayer OrderPayer,
+	gateway port.PaymentGateway,
+	txManager port.TransactionManager,
+) *UseCase {
+	return &UseCase{
+		mapper:         &Mapper{},
+		paymentService: paymentService,
+		orderService:   orderService,
+		orderPayer:     orderPayer,
+		authorizer:     NewAuthorizer(paymentService, gateway),
+		gateway:        gateway,
+		txManager:      txManager,
+	}
+}
+
+// CreatePaymentIntent starts collecting the total of a pending order. An order
+// has at most one open intent: asking again returns the existing one.
+func (uc *UseCase) CreatePaymentIntent(ctx appcontext.AppContext, orderID int64) (*PaymentIntentResponse, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx.GetDefaultContext(), orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domainorder.StatusPending {
+		return nil, fmt.Errorf("%w: order is %s", domainpayment.ErrOrderNotPayable, order.Status)
+	}
+
+	intent, err := uc.authorizer.AuthorizePayment(ctx.GetDefaultContext(), order)
+	if err != nil {
+		return nil, err
+	}
+
+	response := uc.mapper.ToResponse(intent)
+	return &response, nil
+}
+
+// GetPaymentsByOrder returns every payment intent of an order, oldest first
+func (uc *UseCase) GetPaymentsByOrder(ctx appcontext.AppContext, orderID int64) (*PaymentIntentListResponse, error) {
+	if _, err := uc.orderService.GetOrder(ctx.GetDefaultContext(), orderID); err != nil {
+		return nil, err
+	}
+
+	intents, err := uc.paymentService.GetIntentsByOrder(ctx.GetDefaultContext(), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intents: %w", err)
+	}
+
+	response := uc.mapper.ToListResponse(orderID, intents)
+	return &response, nil
+}
+
+// GetRefundsByOrder returns every refund of an order, oldest first
+func (uc *UseCase) GetRefundsByOrder(ctx appcontext.AppContext, orderID int64) (*RefundListResponse, error) {
+	if _, err := uc.orderService.GetOrder(ctx.GetDefaultContext(), orderID); err != nil {
+		return nil, err
+	}
+
+	refunds, err := uc.paymentService.GetRefundsByOrder(ctx.GetDefaultContext(), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunds: %w", err)
+	}
+
+	response := uc.mapper.ToRefundListResponse(orderID, refunds)
+	return &response, nil
+}
+
+// HandleWebhook applies a gateway callback. The callback is recorded, the
+// intent updated and the order marked as paid in one transaction, so a
+// callback delivered twice is applied once and a failed attempt can be
+// redelivered by the gateway. A payment collected for an order that can no
+// longer be paid is flagged in the same transaction and refunded afterwards.
+func (uc *UseCase) HandleWebhook(ctx appcontext.AppContext, payload []byte, signature string) (*WebhookResponse, error) {
+	event, err := uc.gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return nil, err
+	}
+	event.ReceivedAt = time.Now()
+
+	var refundDue *domainpayment.Intent
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		if err := uc.paymentService.RecordWebhookEvent(txCtx, event); err != nil {
+			return err
+		}
+		refundDue, err = uc.applyWebhook(txCtx, event)
+		return err
+	})
+	if errors.Is(err, domainpayment.ErrDuplicateWebhook) {
+		return &WebhookResponse{Status: webhookDuplicate}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if refundDue != nil {
+		uc.refundLateCapture(ctx.GetDefaultContext(), refundDue, "payment:"+event.Provider)
+	}
+	return &WebhookResponse{Status: webhookProcessed}, nil
+}
+
+// applyWebhook updates the intent and the order of a callback. It returns the
+// intent when its payment must be refunded because the order was closed.
+func (uc *UseCase) applyWebhook(ctx context.Context, event *domainpayment.WebhookEvent) (*domainpayment.Intent, error) {
+	intent, err := uc.paymentService.GetIntent(ctx, event.IntentID)
+	if err != nil {
+		return nil, err
+	}
+	if intent.Provider != event.Provider {
+		return nil, fmt.Errorf("%w: intent %s belongs to %s", domainpayment.ErrInvalidWebhook, intent.IntentID, intent.Provider)
+	}
+
+	if event.Type == domainpayment.WebhookPaymentFailed {
+		changed, err := intent.Fail(event.FailureReason, event.ReceivedAt)
+		if err != nil || !changed {
+			return nil, err
+		}
+		return nil, uc.paymentService.UpdateIntent(ctx, intent)
+	}
+
+	if event.Amount != intent.Amount {
+		return nil, fmt.Errorf("%w: received %s, expected %s", domainpayment.ErrAmountMismatch, event.Amount, intent.Amount)
+	}
+	changed, err := intent.Succeed(event.ReceivedAt)
+	if err != nil || !changed {
+		return nil, err
+	}
+	if err := uc.paymentService.UpdateIntent(ctx, intent); err != nil {
+		return nil, err
+	}
+
+	err = uc.orderPayer.MarkAsPaid(ctx, intent.OrderID, intent.IntentID, event.Amount, "payment:"+event.Provider)
+	if errors.Is(err, domainorder.ErrInvalidStatusTransition) {
+		// The money was collected for an order that was cancelled or expired
+		// meanwhile. The intent is flagged and refunded once the transaction
+		// commits; the flag stays until the refund succeeds, so failed
+		// refunds are left for reconciliation.
+		log.Printf("Payment %s captured for order %d which can no longer be paid, refund required", intent.IntentID, intent.OrderID)
+		intent.RefundDue = true
+		return intent, uc.paymentService.UpdateIntent(ctx, intent)
+	}
+	return nil, err
+}
+
+// refundLateCapture refunds a payment collected for a closed order and clears
+// its flag. Failures are logged: the intent stays flagged for reconciliation.
+func (uc *UseCase) refundLateCapture(ctx context.Context, intent *domainpayment.Intent, actor string) {
+	if err := uc.orderPayer.RefundLateCapture(ctx, intent.OrderID, intent.IntentID, actor); err != nil {
+		log.Printf("Failed to refund payment %s of closed order %d, left for reconciliation: %v", intent.IntentID, intent.OrderID, err)
+		return
+	}
+
+	intent.RefundDue = false
+	intent.UpdatedAt = time.Now()
+	if err := uc.paymentService.UpdateIntent(ctx, intent); err != nil {
+		log.Printf("Refunded payment %s of order %d but failed to clear its refund flag: %v", intent.IntentID, intent.OrderID, err)
+	}
+}
@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+)
+
+// Carrier is a shipping carrier that delivers the parcels of orders. Carriers
+// report progress by posting signed tracking updates, see
+// domainshipment.Shipment.Track.
+type Carrier interface {
+	// Name identifies the carrier in stored shipments and tracking updates
+	Name() string
+
+	// CreateShipment books a parcel. Calls with the same reference return the
+	// same tracking number.
+	CreateShipment(ctx context.Context, req ShipmentRequest) (*ShipmentResult, error)
+
+	// ParseTrackingUpdate verifies the signature of a tracking callback and
+	// decodes it. It returns domainshipment.ErrInvalidSignature for forged
+	// callbacks.
+	ParseTrackingUpdate(payload []byte, signature string) (*TrackingUpdate, error)
+}
+
+type ShipmentRequest struct {
+	Reference string
+	OrderID   int64
+	Address   *domainorder.ShippingAddress
+	Items     []domainshipment.Item
+}
+
+type ShipmentResult struct {
+	TrackingNumber string
+}
+
+// TrackingUpdate is a scan of a parcel reported by the carrier
+type TrackingUpdate struct {
+	TrackingNumber string
+	Event          domainshipment.TrackingEvent
+}
@@ -0,0 +1,77 @@
+package port
+
+import (
+	"context"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+// PaymentAuthorizer starts collecting the total of orders
+type PaymentAuthorizer interface {
+	// AuthorizePayment returns the open payment intent of the order, creating
+	// one with the payment provider when there is none. Calls are idempotent.
+	AuthorizePayment(ctx context.Context, order *domainorder.Order) (*domainpayment.Intent, error)
+}
+
+// PaymentCompensator returns money to customers: it voids the payments of
+// closed orders and refunds captured ones. Calls must be idempotent, as
+// cancellations and refunds may be retried.
+type PaymentCompensator interface {
+	// VoidPayment voids the payment intents of the order that were not paid
+	VoidPayment(ctx context.Context, orderID int64) error
+
+	// RefundPayment pays refund.Amount back from the captured payment of
+	// refund.OrderID and records the refund. A refund whose idempotency key
+	// was used before is not paid again: the recorded one is copied into
+	// refund. It returns domainpayment.ErrNoCapturedPayment when the order has
+	// no captured payment left to refund.
+	RefundPayment(ctx context.Context, refund *domainpayment.Refund) error
+}
+
+// PaymentGateway is a payment provider that collects money for orders
+type PaymentGateway interface {
+	// Name identifies the provider in stored intents and webhook events
+	Name() string
+
+	// CreateIntent starts collecting amount. Calls with the same idempotency
+	// key return the same intent.
+	CreateIntent(ctx context.Context, req PaymentIntentRequest) (*PaymentIntentResult, error)
+
+	// CancelIntent voids an intent that has not been paid
+	CancelIntent(ctx context.Context, intentID string) error
+
+	// ParseWebhook verifies the signature of a callback and decodes it. It
+	// returns domainpayment.ErrInvalidSignature for forged callbacks.
+	ParseWebhook(payload []byte, signature string) (*domainpayment.WebhookEvent, error)
+}
+
+// RefundProvider returns captured payments to customers
+type RefundProvider interface {
+	// Refund pays amount back from a captured intent. Calls with the same
+	// idempotency key return the same refund.
+	Refund(ctx context.Context, req RefundRequest) (*RefundResult, error)
+}
+
+type PaymentIntentRequest struct {
+	OrderID        int64
+	UserID         string
+	Amount         money.Money
+	IdempotencyKey string
+}
+
+type PaymentIntentResult struct {
+	IntentID     string
+	ClientSecret string
+}
+
+type RefundRequest struct {
+	IntentID       string
+	Amount         money.Money
+	IdempotencyKey string
+}
+
+type RefundResult struct {
+	RefundID string
+}
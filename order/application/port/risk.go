@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainrisk "github.com/DuongVu089x/interview/order/domain/risk"
+	pb "github.com/DuongVu089x/interview/order/proto/customer"
+)
+
+// RiskAssessor scores how likely a new order is to be fraudulent. Orders
+// assessed as high risk are held for review before they are placed.
+type RiskAssessor interface {
+	// AssessRisk scores an order that is being placed, with the customer
+	// returned by GetCustomer; customer is nil when it is not known. Orders
+	// are assessed again when their placement is retried, so calls must not
+	// count an order twice.
+	AssessRisk(ctx context.Context, order *domainorder.Order, customer *pb.Customer) (*domainrisk.Assessment, error)
+}
@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// TransactionManager runs a unit of work atomically. Repositories called with
+// the context passed to fn take part in the same transaction.
+type TransactionManager interface {
+	ExecuteInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,34 @@
+package product
+
+import (
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// ProductRequest defines the payload for creating or updating a product
+type ProductRequest struct {
+	SKU    string      `json:"sku" validate:"required"`
+	Name   string      `json:"name" validate:"required"`
+	Price  money.Money `json:"price"`
+	Active bool        `json:"active"`
+}
+
+// GetProductsRequest defines the filters for listing products
+type GetProductsRequest struct {
+	Active *bool `json:"active,omitempty"`
+}
+
+type ProductResponse struct {
+	SKU       string      `json:"sku"`
+	Name      string      `json:"name"`
+	Price     money.Money `json:"price"`
+	Active    bool        `json:"active"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type ProductListResponse struct {
+	Products []ProductResponse `json:"products"`
+	Count    int               `json:"count"`
+}
@@ -0,0 +1,26 @@
+package product
+
+import domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToEntity(dto ProductRequest) *domainproduct.Product {
+	return &domainproduct.Product{
+		SKU:    dto.SKU,
+		Name:   dto.Name,
+		Price:  dto.Price,
+		Active: dto.Active,
+	}
+}
+
+func (m *Mapper) ToResponse(product *domainproduct.Product) ProductResponse {
+	return ProductResponse{
+		SKU:       product.SKU,
+		Name:      product.Name,
+		Price:     product.Price,
+		Active:    product.Active,
+		CreatedAt: product.CreatedAt,
+		UpdatedAt: product.UpdatedAt,
+	}
+}
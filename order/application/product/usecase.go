@@ -0,0 +1,66 @@
+package product
+
+import (
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+)
+
+type UseCase struct {
+	mapper         *Mapper
+	productService domainproduct.Service
+}
+
+func NewProductUseCase(productService domainproduct.Service) *UseCase {
+	return &UseCase{
+		mapper:         &Mapper{},
+		productService: productService,
+	}
+}
+
+func (uc *UseCase) CreateProduct(ctx appcontext.AppContext, req ProductRequest) (*ProductResponse, error) {
+	product := uc.mapper.ToEntity(req)
+	if err := uc.productService.CreateProduct(ctx.GetDefaultContext(), product); err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToResponse(product)
+	return &response, nil
+}
+
+func (uc *UseCase) UpdateProduct(ctx appcontext.AppContext, req ProductRequest) (*ProductResponse, error) {
+	product := uc.mapper.ToEntity(req)
+	if err := uc.productService.UpdateProduct(ctx.GetDefaultContext(), product); err != nil {
+		return nil, err
+	}
+	return uc.GetProduct(ctx, req.SKU)
+}
+
+func (uc *UseCase) DeleteProduct(ctx appcontext.AppContext, sku string) error {
+	return uc.productService.DeleteProduct(ctx.GetDefaultContext(), sku)
+}
+
+func (uc *UseCase) GetProduct(ctx appcontext.AppContext, sku string) (*ProductResponse, error) {
+	product, err := uc.productService.GetProduct(ctx.GetDefaultContext(), sku)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToResponse(product)
+	return &response, nil
+}
+
+func (uc *UseCase) GetProducts(ctx appcontext.AppContext, req GetProductsRequest) (*ProductListResponse, error) {
+	products, err := uc.productService.GetProducts(ctx.GetDefaultContext(), domainproduct.Filter{
+		Active: req.Active,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ProductResponse, 0, len(products))
+	for _, product := range products {
+		responses = append(responses, uc.mapper.ToResponse(&product))
+	}
+	return &ProductListResponse{
+		Products: responses,
+		Count:    len(responses),
+	}, nil
+}
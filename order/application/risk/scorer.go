@@ -0,0 +1,152 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainrisk "github.com/DuongVu089x/interview/order/domain/risk"
+	pb "github.com/DuongVu089x/interview/order/proto/customer"
+)
+
+// Scores added by the signals of the heuristic scorer. No signal alone
+// reaches the default high threshold; two together do.
+const (
+	velocityScore      = 40
+	amountOutlierScore = 40
+	newAccountScore    = 30
+)
+
+// ScorerConfig holds the thresholds of the heuristic scorer
+type ScorerConfig struct {
+	VelocityWindow time.Duration // Window orders are counted in
+	VelocityLimit  int           // Orders per window a customer may place before it looks suspicious
+
+	// An order is an outlier when its total exceeds OutlierFactor times the
+	// average of the last OutlierHistory orders of the customer
+	OutlierFactor  float64
+	OutlierHistory int
+	// OutlierMinHistory is how many past orders a customer needs for the comparison to apply
+	OutlierMinHistory int
+
+	NewAccountAge time.Duration // Accounts younger than this are flagged
+
+	Thresholds domainrisk.Thresholds
+}
+
+// HeuristicScorer assesses orders from the order velocity of the customer,
+// how the total compares to their past orders and the age of their account
+type HeuristicScorer struct {
+	velocityRepo domainrisk.VelocityRepository
+	orderService domainorder.Service
+	config       ScorerConfig
+}
+
+// Ensure HeuristicScorer implements RiskAssessor
+var _ port.RiskAssessor = (*HeuristicScorer)(nil)
+
+func NewHeuristicScorer(velocityRepo domainrisk.VelocityRepository, orderService domainorder.Service, config ScorerConfig) *HeuristicScorer {
+	if config.VelocityWindow == 0 {
+		config.VelocityWindow = 1 * time.Hour
+	}
+	if config.VelocityLimit == 0 {
+		config.VelocityLimit = 3
+	}
+	if config.OutlierFactor == 0 {
+		config.OutlierFactor = 5
+	}
+	if config.OutlierHistory == 0 {
+		config.OutlierHistory = 10
+	}
+	if config.OutlierMinHistory == 0 {
+		config.OutlierMinHistory = 3
+	}
+	if config.NewAccountAge == 0 {
+		config.NewAccountAge = 7 * 24 * time.Hour
+	}
+	if config.Thresholds == (domainrisk.Thresholds{}) {
+		config.Thresholds = domainrisk.Thresholds{Medium: 40, High: 70}
+	}
+
+	return &HeuristicScorer{
+		velocityRepo: velocityRepo,
+		orderService: orderService,
+		config:       config,
+	}
+}
+
+func (s *HeuristicScorer) AssessRisk(ctx context.Context, order *domainorder.Order, customer *pb.Customer) (*domainrisk.Assessment, error) {
+	now := time.Now()
+	var signals []domainrisk.Signal
+
+	velocity, err := s.velocityRepo.RecordOrder(ctx, order.UserID, order.OrderID, now, s.config.VelocityWindow)
+	if err != nil {
+		return nil, err
+	}
+	if velocity > int64(s.config.VelocityLimit) {
+		signals = append(signals, domainrisk.Signal{
+			Name:   "velocity",
+			Score:  velocityScore,
+			Detail: fmt.Sprintf("%d orders within %s", velocity, s.config.VelocityWindow),
+		})
+	}
+
+	outlier, err := s.amountOutlier(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	if outlier != nil {
+		signals = append(signals, *outlier)
+	}
+
+	if created, err := time.Parse(time.RFC3339, customer.GetCreatedAt()); err == nil && now.Sub(created) < s.config.NewAccountAge {
+		signals = append(signals, domainrisk.Signal{
+			Name:   "new_account",
+			Score:  newAccountScore,
+			Detail: fmt.Sprintf("account created %s", created.Format(time.RFC3339)),
+		})
+	}
+
+	assessment := domainrisk.NewAssessment(signals, s.config.Thresholds, now)
+	return &assessment, nil
+}
+
+// amountOutlier compares the total of the order to the average total of the
+// recent orders of the customer in the same currency
+func (s *HeuristicScorer) amountOutlier(ctx context.Context, order *domainorder.Order) (*domainrisk.Signal, error) {
+	past, _, err := s.orderService.GetOrders(ctx, domainorder.Filter{
+		UserID: order.UserID,
+		Statuses: []domainorder.OrderStatus{
+			domainorder.StatusPaid, domainorder.StatusProcessing, domainorder.StatusShipped, domainorder.StatusDelivered,
+		},
+		SortBy: domainorder.SortByCreatedAt,
+		Limit:  s.config.OutlierHistory,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get past orders: %w", err)
+	}
+
+	var sum int64
+	count := 0
+	for _, previous := range past {
+		if previous.OrderID != order.OrderID && previous.TotalAmount.Currency == order.TotalAmount.Currency {
+			sum += previous.TotalAmount.Amount
+			count++
+		}
+	}
+	if count < s.config.OutlierMinHistory {
+		return nil, nil
+	}
+
+	average := float64(sum) / float64(count)
+	if float64(order.TotalAmount.Amount) <= average*s.config.OutlierFactor {
+		return nil, nil
+	}
+	return &domainrisk.Signal{
+		Name:   "amount_outlier",
+		Score:  amountOutlierScore,
+		Detail: fmt.Sprintf("total %s is over %.1f times the average of the last %d orders", order.TotalAmount, s.config.OutlierFactor, count),
+	}, nil
+}
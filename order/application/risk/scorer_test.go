@@ -0,0 +1,83 @@
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainrisk "github.com/DuongVu089x/interview/order/domain/risk"
+	pb "github.com/DuongVu089x/interview/order/proto/customer"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryVelocityRepository counts orders in memory, ignoring the window
+type memoryVelocityRepository struct {
+	orders map[string]map[int64]bool
+}
+
+func (r *memoryVelocityRepository) RecordOrder(ctx context.Context, userID string, orderID int64, at time.Time, window time.Duration) (int64, error) {
+	if r.orders[userID] == nil {
+		r.orders[userID] = make(map[int64]bool)
+	}
+	r.orders[userID][orderID] = true
+	return int64(len(r.orders[userID])), nil
+}
+
+// pastOrderService returns the same past orders for every customer
+type pastOrderService struct {
+	domainorder.Service
+	orders []domainorder.Order
+}
+
+func (s *pastOrderService) GetOrders(ctx context.Context, filter domainorder.Filter) ([]domainorder.Order, *domainorder.Cursor, error) {
+	return s.orders, nil, nil
+}
+
+func newScorer(past ...domainorder.Order) *HeuristicScorer {
+	return NewHeuristicScorer(
+		&memoryVelocityRepository{orders: make(map[string]map[int64]bool)},
+		&pastOrderService{orders: past},
+		ScorerConfig{VelocityLimit: 2},
+	)
+}
+
+func order(id int64, amount int64) *domainorder.Order {
+	return &domainorder.Order{OrderID: id, UserID: "user-1", TotalAmount: money.New(amount, "USD")}
+}
+
+func TestAssessRiskHoldsFastOrdersFromNewAccounts(t *testing.T) {
+	scorer := newScorer()
+	customer := &pb.Customer{CreatedAt: time.Now().Add(-time.Hour).Format(time.RFC3339)}
+
+	first, err := scorer.AssessRisk(context.Background(), order(1, 1000), customer)
+	assert.NoError(t, err)
+	assert.Equal(t, domainrisk.LevelLow, first.Level)
+	assert.Equal(t, "new_account", first.Signals[0].Name)
+
+	// A retried placement does not count its order again
+	_, err = scorer.AssessRisk(context.Background(), order(2, 1000), customer)
+	assert.NoError(t, err)
+	retried, err := scorer.AssessRisk(context.Background(), order(2, 1000), customer)
+	assert.NoError(t, err)
+	assert.False(t, retried.IsHigh())
+
+	third, err := scorer.AssessRisk(context.Background(), order(3, 1000), customer)
+	assert.NoError(t, err)
+	assert.True(t, third.IsHigh())
+	assert.Equal(t, 70, third.Score)
+}
+
+func TestAssessRiskFlagsAmountOutliers(t *testing.T) {
+	scorer := newScorer(*order(1, 1000), *order(2, 2000), *order(3, 3000))
+
+	usual, err := scorer.AssessRisk(context.Background(), order(4, 9000), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, usual.Signals)
+
+	outlier, err := scorer.AssessRisk(context.Background(), order(5, 10001), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "amount_outlier", outlier.Signals[0].Name)
+	assert.Equal(t, domainrisk.LevelMedium, outlier.Level)
+}
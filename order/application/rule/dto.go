@@ -0,0 +1,43 @@
+package rule
+
+import (
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// RuleRequest defines the payload for creating or replacing an order rule
+type RuleRequest struct {
+	RuleID      string      `json:"ruleId" validate:"required"`
+	Type        string      `json:"type" validate:"required,oneof=max_quantity_per_sku max_order_value blocked_products min_basket"`
+	Description string      `json:"description,omitempty"`
+	Priority    int         `json:"priority"`
+	Enabled     bool        `json:"enabled"`
+	Tiers       []string    `json:"tiers,omitempty"`
+	ProductIDs  []string    `json:"productIds,omitempty"`
+	MaxQuantity int         `json:"maxQuantity,omitempty" validate:"gte=0"`
+	Amount      money.Money `json:"amount"`
+	MinItems    int         `json:"minItems,omitempty" validate:"gte=0"`
+}
+
+type RuleResponse struct {
+	RuleID      string      `json:"ruleId"`
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Priority    int         `json:"priority"`
+	Enabled     bool        `json:"enabled"`
+	Tiers       []string    `json:"tiers,omitempty"`
+	ProductIDs  []string    `json:"productIds,omitempty"`
+	MaxQuantity int         `json:"maxQuantity,omitempty"`
+	Amount      money.Money `json:"amount"`
+	MinItems    int         `json:"minItems,omitempty"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+type RuleListResponse struct {
+	Rules []RuleResponse `json:"rules"`
+	Count int            `json:"count"`
+	// LoadedAt is when this instance last loaded the rules it enforces
+	LoadedAt time.Time `json:"loadedAt"`
+}
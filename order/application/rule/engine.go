@@ -0,0 +1,89 @@
+package rule
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+)
+
+// EngineConfig holds how the rule engine follows rule changes
+type EngineConfig struct {
+	ReloadInterval time.Duration // How often rules are reloaded from the store
+}
+
+// Engine validates orders against the rules in the store. It evaluates an in
+// memory copy of the rules, reloaded periodically and whenever rules are
+// changed through this instance. A failed reload keeps the rules loaded last.
+type Engine struct {
+	ruleService domainrule.Service
+	config      EngineConfig
+
+	mu       sync.RWMutex
+	rules    []domainrule.Rule
+	loadedAt time.Time
+}
+
+func NewEngine(ruleService domainrule.Service, config EngineConfig) *Engine {
+	if config.ReloadInterval == 0 {
+		config.ReloadInterval = 30 * time.Second
+	}
+
+	return &Engine{
+		ruleService: ruleService,
+		config:      config,
+	}
+}
+
+// Reload replaces the rules in memory with the rules in the store
+func (e *Engine) Reload(ctx context.Context) error {
+	rules, err := e.ruleService.GetRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rules = rules
+	e.loadedAt = time.Now()
+	return nil
+}
+
+// Start reloads the rules until the context is cancelled
+func (e *Engine) Start(ctx context.Context) {
+	ticker := time.NewTicker(e.config.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := e.Reload(ctx); err != nil {
+			log.Printf("Order rules reload error, keeping the rules loaded at %s: %v", e.LoadedAt().Format(time.RFC3339), err)
+		}
+	}
+}
+
+// LoadedAt returns when the rules in memory were loaded
+func (e *Engine) LoadedAt() time.Time {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.loadedAt
+}
+
+// Check returns a *domainrule.ValidationError listing every rule the order
+// breaks, or nil when it breaks none
+func (e *Engine) Check(order domainrule.Order) error {
+	e.mu.RLock()
+	rules := e.rules
+	e.mu.RUnlock()
+
+	return domainrule.Check(rules, order)
+}
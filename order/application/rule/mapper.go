@@ -0,0 +1,38 @@
+package rule
+
+import domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToEntity(dto RuleRequest) *domainrule.Rule {
+	return &domainrule.Rule{
+		RuleID:      dto.RuleID,
+		Type:        domainrule.RuleType(dto.Type),
+		Description: dto.Description,
+		Priority:    dto.Priority,
+		Enabled:     dto.Enabled,
+		Tiers:       dto.Tiers,
+		ProductIDs:  dto.ProductIDs,
+		MaxQuantity: dto.MaxQuantity,
+		Amount:      dto.Amount,
+		MinItems:    dto.MinItems,
+	}
+}
+
+func (m *Mapper) ToResponse(rule *domainrule.Rule) RuleResponse {
+	return RuleResponse{
+		RuleID:      rule.RuleID,
+		Type:        string(rule.Type),
+		Description: rule.Description,
+		Priority:    rule.Priority,
+		Enabled:     rule.Enabled,
+		Tiers:       rule.Tiers,
+		ProductIDs:  rule.ProductIDs,
+		MaxQuantity: rule.MaxQuantity,
+		Amount:      rule.Amount,
+		MinItems:    rule.MinItems,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
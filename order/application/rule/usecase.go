@@ -0,0 +1,77 @@
+package rule
+
+import (
+	"log"
+
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+)
+
+type UseCase struct {
+	mapper      *Mapper
+	ruleService domainrule.Service
+	engine      *Engine
+}
+
+func NewRuleUseCase(ruleService domainrule.Service, engine *Engine) *UseCase {
+	return &UseCase{
+		mapper:      &Mapper{},
+		ruleService: ruleService,
+		engine:      engine,
+	}
+}
+
+// SaveRule creates or replaces a rule. This instance enforces it at once,
+// the others from their next reload.
+func (uc *UseCase) SaveRule(ctx appcontext.AppContext, req RuleRequest) (*RuleResponse, error) {
+	rule := uc.mapper.ToEntity(req)
+	if err := uc.ruleService.SaveRule(ctx.GetDefaultContext(), rule); err != nil {
+		return nil, err
+	}
+	uc.reload(ctx)
+	return uc.GetRule(ctx, req.RuleID)
+}
+
+// DeleteRule deletes a rule. This instance stops enforcing it at once, the
+// others from their next reload.
+func (uc *UseCase) DeleteRule(ctx appcontext.AppContext, ruleID string) error {
+	if err := uc.ruleService.DeleteRule(ctx.GetDefaultContext(), ruleID); err != nil {
+		return err
+	}
+	uc.reload(ctx)
+	return nil
+}
+
+func (uc *UseCase) GetRule(ctx appcontext.AppContext, ruleID string) (*RuleResponse, error) {
+	rule, err := uc.ruleService.GetRule(ctx.GetDefaultContext(), ruleID)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToResponse(rule)
+	return &response, nil
+}
+
+func (uc *UseCase) GetRules(ctx appcontext.AppContext) (*RuleListResponse, error) {
+	rules, err := uc.ruleService.GetRules(ctx.GetDefaultContext())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]RuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, uc.mapper.ToResponse(&rule))
+	}
+	return &RuleListResponse{
+		Rules:    responses,
+		Count:    len(responses),
+		LoadedAt: uc.engine.LoadedAt(),
+	}, nil
+}
+
+// reload refreshes the rules of this instance after a change. The change is
+// saved either way, so a failure only delays it to the next periodic reload.
+func (uc *UseCase) reload(ctx appcontext.AppContext) {
+	if err := uc.engine.Reload(ctx.GetDefaultContext()); err != nil {
+		log.Printf("Failed to reload order rules: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package saga
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SagaResponse struct {
+	SagaID               string                     `json:"sagaId"`
+	Type                 string                     `json:"type"`
+	Status               string                     `json:"status"`
+	Steps                []StepResponse             `json:"steps"`
+	Data                 map[string]json.RawMessage `json:"data,omitempty"`
+	Error                string                     `json:"error,omitempty"`
+	CompensationAttempts int                        `json:"compensationAttempts"`
+	LeaseUntil           time.Time                  `json:"leaseUntil"`
+	CreatedAt            time.Time                  `json:"createdAt"`
+	UpdatedAt            time.Time                  `json:"updatedAt"`
+}
+
+type StepResponse struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+type SagaListResponse struct {
+	Sagas []SagaResponse `json:"sagas"`
+	Count int            `json:"count"`
+}
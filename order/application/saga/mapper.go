@@ -0,0 +1,42 @@
+package saga
+
+import (
+	"encoding/json"
+
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+)
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToSagaResponse(saga *domainsaga.Saga) SagaResponse {
+	steps := make([]StepResponse, 0, len(saga.Steps))
+	for _, step := range saga.Steps {
+		steps = append(steps, StepResponse{
+			Name:       step.Name,
+			Status:     string(step.Status),
+			Error:      step.Error,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+		})
+	}
+
+	// Values are stored as JSON, so they are shown as is rather than as strings
+	data := make(map[string]json.RawMessage, len(saga.Data))
+	for key, value := range saga.Data {
+		data[key] = json.RawMessage(value)
+	}
+
+	return SagaResponse{
+		SagaID:               saga.SagaID,
+		Type:                 saga.Type,
+		Status:               string(saga.Status),
+		Steps:                steps,
+		Data:                 data,
+		Error:                saga.Error,
+		CompensationAttempts: saga.CompensationAttempts,
+		LeaseUntil:           saga.LeaseUntil,
+		CreatedAt:            saga.CreatedAt,
+		UpdatedAt:            saga.UpdatedAt,
+	}
+}
@@ -0,0 +1,275 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+)
+
+// Step is an action of a saga and the compensation that undoes it. Actions are
+// retried when a saga is resumed after its step was interrupted, so they must
+// be idempotent.
+type Step struct {
+	Name string
+	// Timeout bounds the action and the compensation; Config.StepTimeout applies when zero
+	Timeout time.Duration
+	Action  func(ctx context.Context, state *State) error
+	// Compensate undoes the action. It also runs for the step that failed, so
+	// it must tolerate an action that never took effect. Nil when there is
+	// nothing to undo.
+	Compensate func(ctx context.Context, state *State) error
+}
+
+// Definition describes a type of saga by its steps, run in order
+type Definition struct {
+	Type  string
+	Steps []Step
+}
+
+func (d Definition) step(name string) (Step, bool) {
+	for _, step := range d.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// Config holds the timing of the saga orchestrator
+type Config struct {
+	StepTimeout time.Duration // Timeout of steps that set none
+	// LeaseDuration is how long a saga is left to the instance running it; it
+	// must be longer than any step timeout
+	LeaseDuration           time.Duration
+	MaxCompensationAttempts int           // Compensation runs before a saga is left to an operator
+	RecoveryInterval        time.Duration // How often abandoned sagas are looked for
+	RecoveryBatchSize       int           // Maximum sagas resumed per run
+}
+
+// Orchestrator runs sagas step by step, saving them after every step. When a
+// step fails the compensations of the steps run so far run in reverse. Sagas
+// abandoned by a stopped instance are resumed once their lease runs out.
+type Orchestrator struct {
+	sagaService domainsaga.Service
+	config      Config
+
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+func NewOrchestrator(sagaService domainsaga.Service, config Config) *Orchestrator {
+	if config.StepTimeout == 0 {
+		config.StepTimeout = 10 * time.Second
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = 1 * time.Minute
+	}
+	if config.MaxCompensationAttempts == 0 {
+		config.MaxCompensationAttempts = 10
+	}
+	if config.RecoveryInterval == 0 {
+		config.RecoveryInterval = 30 * time.Second
+	}
+	if config.RecoveryBatchSize == 0 {
+		config.RecoveryBatchSize = 50
+	}
+
+	return &Orchestrator{
+		sagaService: sagaService,
+		config:      config,
+		definitions: make(map[string]Definition),
+	}
+}
+
+// Register makes a type of saga runnable and resumable. Registering a type
+// again replaces its definition.
+func (o *Orchestrator) Register(definition Definition) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.definitions[definition.Type] = definition
+}
+
+func (o *Orchestrator) definition(sagaType string) (Definition, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	definition, ok := o.definitions[sagaType]
+	return definition, ok
+}
+
+// Run starts a saga and runs it to the end. It returns nil when every step
+// succeeded, or the error of the step that failed once the saga has been
+// compensated as far as possible. The values set by the steps are in state.
+func (o *Orchestrator) Run(ctx context.Context, sagaType, sagaID string, state *State) error {
+	definition, ok := o.definition(sagaType)
+	if !ok {
+		return fmt.Errorf("%w: %s", domainsaga.ErrUnknownSagaType, sagaType)
+	}
+
+	stepNames := make([]string, len(definition.Steps))
+	for i, step := range definition.Steps {
+		stepNames[i] = step.Name
+	}
+
+	now := time.Now()
+	saga := domainsaga.NewSaga(sagaID, sagaType, stepNames, state.data, now)
+	saga.LeaseUntil = now.Add(o.config.LeaseDuration)
+	if err := o.sagaService.CreateSaga(ctx, saga); err != nil {
+		return fmt.Errorf("failed to create saga: %w", err)
+	}
+
+	return o.execute(ctx, definition, saga, state)
+}
+
+// Start resumes abandoned sagas until the context is cancelled
+func (o *Orchestrator) Start(ctx context.Context) {
+	ticker := time.NewTicker(o.config.RecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		resumed, err := o.ResumeAbandoned(ctx)
+		if err != nil {
+			log.Printf("Saga recovery error: %v", err)
+		}
+		if resumed > 0 {
+			log.Printf("Saga recovery resumed %d sagas", resumed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ResumeAbandoned resumes up to one batch of unfinished sagas whose lease ran
+// out and returns how many it resumed
+func (o *Orchestrator) ResumeAbandoned(ctx context.Context) (int, error) {
+	sagas, err := o.sagaService.GetAbandonedSagas(ctx, time.Now(), o.config.RecoveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	for i := range sagas {
+		saga := &sagas[i]
+		definition, ok := o.definition(saga.Type)
+		if !ok {
+			log.Printf("Cannot resume saga %s: %v %q", saga.SagaID, domainsaga.ErrUnknownSagaType, saga.Type)
+			continue
+		}
+
+		// Saving takes the lease; if another instance saved first, it resumes the saga
+		if err := o.save(ctx, saga); err != nil {
+			if !errors.Is(err, domainsaga.ErrLeaseLost) {
+				log.Printf("Failed to claim saga %s: %v", saga.SagaID, err)
+			}
+			continue
+		}
+		resumed++
+
+		if saga.Data == nil {
+			saga.Data = make(map[string]string)
+		}
+		state := &State{data: saga.Data}
+		if err := o.execute(ctx, definition, saga, state); err != nil {
+			log.Printf("Resumed saga %s ended %s: %v", saga.SagaID, saga.Status, err)
+		}
+	}
+	return resumed, nil
+}
+
+// execute runs the remaining steps of a saga, then its compensations if a
+// step failed
+func (o *Orchestrator) execute(ctx context.Context, definition Definition, saga *domainsaga.Saga, state *State) error {
+	// The saga must finish even if the caller goes away
+	ctx = context.WithoutCancel(ctx)
+
+	var failure error
+	for saga.Status == domainsaga.StatusRunning {
+		i := saga.NextStep()
+		step, ok := definition.step(saga.Steps[i].Name)
+		if !ok {
+			failure = fmt.Errorf("saga %s has no step %q", saga.Type, saga.Steps[i].Name)
+			saga.FailStep(i, failure, time.Now())
+		} else {
+			saga.StartStep(i, time.Now())
+			if err := o.save(ctx, saga); err != nil {
+				return err
+			}
+
+			if err := o.run(ctx, step, step.Action, state); err != nil {
+				failure = err
+				saga.FailStep(i, err, time.Now())
+			} else {
+				saga.CompleteStep(i, time.Now())
+			}
+		}
+		if err := o.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	for saga.Status == domainsaga.StatusCompensating {
+		i := saga.NextCompensation()
+		step, ok := definition.step(saga.Steps[i].Name)
+		if ok && step.Compensate != nil {
+			if err := o.run(ctx, step, step.Compensate, state); err != nil {
+				// Left compensating, the saga is retried once its lease runs out
+				log.Printf("Failed to compensate step %s of saga %s: %v", step.Name, saga.SagaID, err)
+				saga.FailCompensation(i, err, o.config.MaxCompensationAttempts)
+				if err := o.save(ctx, saga); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		saga.CompensateStep(i, time.Now())
+		if err := o.save(ctx, saga); err != nil {
+			return err
+		}
+	}
+
+	if saga.Status == domainsaga.StatusCompleted {
+		return nil
+	}
+	if failure == nil {
+		failure = errors.New(saga.Error)
+	}
+	return failure
+}
+
+// run calls fn within the timeout of the step
+func (o *Orchestrator) run(ctx context.Context, step Step, fn func(ctx context.Context, state *State) error, state *State) error {
+	timeout := step.Timeout
+	if timeout == 0 {
+		timeout = o.config.StepTimeout
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := fn(stepCtx, state)
+	if err != nil && errors.Is(stepCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("step %s timed out after %s: %w", step.Name, timeout, err)
+	}
+	return err
+}
+
+// save stores the saga and extends the lease of this instance
+func (o *Orchestrator) save(ctx context.Context, saga *domainsaga.Saga) error {
+	now := time.Now()
+	saga.UpdatedAt = now
+	saga.LeaseUntil = now.Add(o.config.LeaseDuration)
+	if err := o.sagaService.UpdateSaga(ctx, saga); err != nil {
+		return fmt.Errorf("failed to save saga %s: %w", saga.SagaID, err)
+	}
+	return nil
+}
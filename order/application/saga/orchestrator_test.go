@@ -0,0 +1,201 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySagaService stores sagas in memory with the version check of the
+// Mongo repository
+type memorySagaService struct {
+	domainsaga.Service
+	mu    sync.Mutex
+	sagas map[string]domainsaga.Saga
+}
+
+func newMemorySagaService() *memorySagaService {
+	return &memorySagaService{sagas: make(map[string]domainsaga.Saga)}
+}
+
+func (s *memorySagaService) CreateSaga(ctx context.Context, saga *domainsaga.Saga) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sagas[saga.SagaID]; ok {
+		return domainsaga.ErrSagaExists
+	}
+	s.sagas[saga.SagaID] = clone(saga)
+	return nil
+}
+
+func (s *memorySagaService) UpdateSaga(ctx context.Context, saga *domainsaga.Saga) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sagas[saga.SagaID].Version != saga.Version {
+		return domainsaga.ErrLeaseLost
+	}
+	saga.Version++
+	s.sagas[saga.SagaID] = clone(saga)
+	return nil
+}
+
+func (s *memorySagaService) GetAbandonedSagas(ctx context.Context, now time.Time, limit int) ([]domainsaga.Saga, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sagas []domainsaga.Saga
+	for _, saga := range s.sagas {
+		if !saga.Status.IsFinished() && saga.LeaseUntil.Before(now) {
+			sagas = append(sagas, clone(&saga))
+		}
+	}
+	return sagas, nil
+}
+
+func (s *memorySagaService) get(sagaID string) domainsaga.Saga {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sagas[sagaID]
+}
+
+func clone(saga *domainsaga.Saga) domainsaga.Saga {
+	copied := *saga
+	copied.Steps = append([]domainsaga.Step(nil), saga.Steps...)
+	copied.Data = make(map[string]string, len(saga.Data))
+	for k, v := range saga.Data {
+		copied.Data[k] = v
+	}
+	return copied
+}
+
+// recorder builds steps that log their calls
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) step(name string, fail error) Step {
+	return Step{
+		Name: name,
+		Action: func(ctx context.Context, state *State) error {
+			r.calls = append(r.calls, name)
+			if fail != nil {
+				return fail
+			}
+			return state.Set(name, true)
+		},
+		Compensate: func(ctx context.Context, state *State) error {
+			r.calls = append(r.calls, "undo "+name)
+			return nil
+		},
+	}
+}
+
+func TestRunCompletesEveryStep(t *testing.T) {
+	service := newMemorySagaService()
+	orchestrator := NewOrchestrator(service, Config{})
+	rec := &recorder{}
+	orchestrator.Register(Definition{Type: "test", Steps: []Step{rec.step("a", nil), rec.step("b", nil)}})
+
+	state := NewState()
+	assert.NoError(t, orchestrator.Run(context.Background(), "test", "s1", state))
+
+	assert.Equal(t, []string{"a", "b"}, rec.calls)
+	assert.True(t, state.Has("b"))
+	saga := service.get("s1")
+	assert.Equal(t, domainsaga.StatusCompleted, saga.Status)
+	assert.Equal(t, "true", saga.Data["a"])
+}
+
+func TestRunCompensatesInReverseOnFailure(t *testing.T) {
+	service := newMemorySagaService()
+	orchestrator := NewOrchestrator(service, Config{})
+	rec := &recorder{}
+	declined := errors.New("declined")
+	orchestrator.Register(Definition{Type: "test", Steps: []Step{
+		rec.step("a", nil), rec.step("b", nil), rec.step("c", declined), rec.step("d", nil),
+	}})
+
+	err := orchestrator.Run(context.Background(), "test", "s1", NewState())
+
+	assert.ErrorIs(t, err, declined)
+	assert.Equal(t, []string{"a", "b", "c", "undo c", "undo b", "undo a"}, rec.calls)
+	saga := service.get("s1")
+	assert.Equal(t, domainsaga.StatusCompensated, saga.Status)
+	assert.Equal(t, "declined", saga.Error)
+	assert.Equal(t, domainsaga.StepPending, saga.Steps[3].Status)
+}
+
+func TestRunTimesOutSlowSteps(t *testing.T) {
+	service := newMemorySagaService()
+	orchestrator := NewOrchestrator(service, Config{})
+	orchestrator.Register(Definition{Type: "test", Steps: []Step{{
+		Name:    "slow",
+		Timeout: 10 * time.Millisecond,
+		Action: func(ctx context.Context, state *State) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}}})
+
+	err := orchestrator.Run(context.Background(), "test", "s1", NewState())
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, domainsaga.StatusCompensated, service.get("s1").Status)
+}
+
+func TestResumeAbandonedRetriesFailedCompensations(t *testing.T) {
+	service := newMemorySagaService()
+	orchestrator := NewOrchestrator(service, Config{LeaseDuration: time.Millisecond})
+	rec := &recorder{}
+	unreachable := true
+	flaky := rec.step("a", nil)
+	flaky.Compensate = func(ctx context.Context, state *State) error {
+		if unreachable {
+			return errors.New("unreachable")
+		}
+		rec.calls = append(rec.calls, "undo a")
+		return nil
+	}
+	orchestrator.Register(Definition{Type: "test", Steps: []Step{flaky, rec.step("b", errors.New("declined"))}})
+
+	assert.Error(t, orchestrator.Run(context.Background(), "test", "s1", NewState()))
+	assert.Equal(t, domainsaga.StatusCompensating, service.get("s1").Status)
+
+	unreachable = false
+	time.Sleep(5 * time.Millisecond)
+	resumed, err := orchestrator.ResumeAbandoned(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, []string{"a", "b", "undo b", "undo a"}, rec.calls)
+	assert.Equal(t, domainsaga.StatusCompensated, service.get("s1").Status)
+}
+
+func TestResumeAbandonedRetriesInterruptedSteps(t *testing.T) {
+	service := newMemorySagaService()
+	orchestrator := NewOrchestrator(service, Config{})
+	rec := &recorder{}
+	orchestrator.Register(Definition{Type: "test", Steps: []Step{rec.step("a", nil), rec.step("b", nil)}})
+
+	// An instance stopped while running step b
+	saga := domainsaga.NewSaga("s1", "test", []string{"a", "b"}, map[string]string{"a": "true"}, time.Now())
+	saga.Steps[0].Status = domainsaga.StepDone
+	saga.Steps[1].Status = domainsaga.StepRunning
+	saga.LeaseUntil = time.Now().Add(-time.Second)
+	assert.NoError(t, service.CreateSaga(context.Background(), saga))
+
+	resumed, err := orchestrator.ResumeAbandoned(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, []string{"b"}, rec.calls)
+	assert.Equal(t, domainsaga.StatusCompleted, service.get("s1").Status)
+}
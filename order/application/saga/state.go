@@ -0,0 +1,44 @@
+package saga
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// State holds the values the steps of a saga pass to each other. It is saved
+// with the saga after every step, so values must survive a JSON round trip.
+type State struct {
+	data map[string]string
+}
+
+func NewState() *State {
+	return &State{data: make(map[string]string)}
+}
+
+// Set stores a value under key
+func (s *State) Set(key string, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode saga data %q: %w", key, err)
+	}
+	s.data[key] = string(encoded)
+	return nil
+}
+
+// Get decodes the value stored under key into value
+func (s *State) Get(key string, value any) error {
+	encoded, ok := s.data[key]
+	if !ok {
+		return fmt.Errorf("saga data %q is missing", key)
+	}
+	if err := json.Unmarshal([]byte(encoded), value); err != nil {
+		return fmt.Errorf("failed to decode saga data %q: %w", key, err)
+	}
+	return nil
+}
+
+// Has reports whether a value is stored under key
+func (s *State) Has(key string) bool {
+	_, ok := s.data[key]
+	return ok
+}
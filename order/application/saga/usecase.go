@@ -0,0 +1,43 @@
+package saga
+
+import (
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+)
+
+type UseCase struct {
+	mapper      *Mapper
+	sagaService domainsaga.Service
+}
+
+func NewSagaUseCase(sagaService domainsaga.Service) *UseCase {
+	return &UseCase{
+		mapper:      &Mapper{},
+		sagaService: sagaService,
+	}
+}
+
+func (uc *UseCase) GetSaga(ctx appcontext.AppContext, sagaID string) (*SagaResponse, error) {
+	saga, err := uc.sagaService.GetSaga(ctx.GetDefaultContext(), sagaID)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToSagaResponse(saga)
+	return &response, nil
+}
+
+func (uc *UseCase) GetSagas(ctx appcontext.AppContext, filter domainsaga.Filter) (*SagaListResponse, error) {
+	sagas, err := uc.sagaService.GetSagas(ctx.GetDefaultContext(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]SagaResponse, 0, len(sagas))
+	for _, saga := range sagas {
+		responses = append(responses, uc.mapper.ToSagaResponse(&saga))
+	}
+	return &SagaListResponse{
+		Sagas: responses,
+		Count: len(responses),
+	}, nil
+}
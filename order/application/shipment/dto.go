@@ -0,0 +1,52 @@
+package shipment
+
+import "time"
+
+// CreateShipmentRequest defines the payload for shipping items of an order.
+// No items ships everything not shipped yet.
+type CreateShipmentRequest struct {
+	Items []ShipmentItemDTO `json:"items,omitempty" validate:"omitempty,dive"`
+
+	// Actor is who ships the order, recorded in the order history
+	Actor string `json:"-"`
+}
+
+type ShipmentItemDTO struct {
+	ProductID string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type ShipmentResponse struct {
+	ShipmentID     string             `json:"shipmentId"`
+	OrderID        int64              `json:"orderId"`
+	Carrier        string             `json:"carrier"`
+	TrackingNumber string             `json:"trackingNumber"`
+	Items          []ShipmentItemDTO  `json:"items"`
+	Status         string             `json:"status"`
+	Events         []TrackingEventDTO `json:"events"`
+	ShippedAt      *time.Time         `json:"shippedAt,omitempty"`
+	DeliveredAt    *time.Time         `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
+
+type TrackingEventDTO struct {
+	EventID     string    `json:"eventId"`
+	Status      string    `json:"status"`
+	Description string    `json:"description,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+type ShipmentListResponse struct {
+	OrderID   int64              `json:"orderId"`
+	Shipments []ShipmentResponse `json:"shipments"`
+	Count     int                `json:"count"`
+}
+
+// TrackingUpdateResponse acknowledges a tracking update. Status is
+// "processed" or "duplicate" when the update had already been recorded.
+type TrackingUpdateResponse struct {
+	Status   string           `json:"status"`
+	Shipment ShipmentResponse `json:"shipment"`
+}
@@ -0,0 +1,60 @@
+package shipment
+
+import (
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+)
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToItems(dtos []ShipmentItemDTO) []domainshipment.Item {
+	items := make([]domainshipment.Item, len(dtos))
+	for i, dto := range dtos {
+		items[i] = domainshipment.Item{ProductID: dto.ProductID, Quantity: dto.Quantity}
+	}
+	return items
+}
+
+func (m *Mapper) ToResponse(shipment *domainshipment.Shipment) ShipmentResponse {
+	items := make([]ShipmentItemDTO, len(shipment.Items))
+	for i, item := range shipment.Items {
+		items[i] = ShipmentItemDTO{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+
+	events := make([]TrackingEventDTO, len(shipment.Events))
+	for i, event := range shipment.Events {
+		events[i] = TrackingEventDTO{
+			EventID:     event.EventID,
+			Status:      string(event.Status),
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt,
+		}
+	}
+
+	return ShipmentResponse{
+		ShipmentID:     shipment.ShipmentID,
+		OrderID:        shipment.OrderID,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Items:          items,
+		Status:         string(shipment.Status),
+		Events:         events,
+		ShippedAt:      shipment.ShippedAt,
+		DeliveredAt:    shipment.DeliveredAt,
+		CreatedAt:      shipment.CreatedAt,
+		UpdatedAt:      shipment.UpdatedAt,
+	}
+}
+
+func (m *Mapper) ToListResponse(orderID int64, shipments []domainshipment.Shipment) ShipmentListResponse {
+	responses := make([]ShipmentResponse, len(shipments))
+	for i := range shipments {
+		responses[i] = m.ToResponse(&shipments[i])
+	}
+	return ShipmentListResponse{
+		OrderID:   orderID,
+		Shipments: responses,
+		Count:     len(responses),
+	}
+}
@@ -0,0 +1,241 @@
+package shipment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+)
+
+const (
+	trackingProcessed = "processed"
+	trackingDuplicate = "duplicate"
+)
+
+// OrderFulfiller moves an order through fulfilment within the caller's
+// transaction, see orderusecase.UseCase.AdvanceFulfilment
+type OrderFulfiller interface {
+	AdvanceFulfilment(ctx context.Context, order *domainorder.Order, next domainorder.OrderStatus, actor string) error
+}
+
+type UseCase struct {
+	mapper          *Mapper
+	shipmentService domainshipment.Service
+	orderService    domainorder.Service
+	orderFulfiller  OrderFulfiller
+	carrier         port.Carrier
+	txManager       port.TransactionManager
+}
+
+func NewShipmentUseCase(
+	shipmentService domainshipment.Service,
+	orderService domainorder.Service,
+	orderFulfiller OrderFulfiller,
+	carrier port.Carrier,
+	txManager port.TransactionManager,
+) *UseCase {
+	return &UseCase{
+		mapper:          &Mapper{},
+		shipmentService: shipmentService,
+		orderService:    orderService,
+		orderFulfiller:  orderFulfiller,
+		carrier:         carrier,
+		txManager:       txManager,
+	}
+}
+
+// CreateShipment books a parcel with the carrier for items of a paid order
+// and moves the order to processing. Orders may be shipped in several
+// parcels; each item is shipped once.
+//
+// The parcel is booked before the transaction, under the ID the shipment will
+// be recorded with: the carrier returns the same parcel for the same
+// reference, so neither a transaction retry nor a retried request books it
+// twice.
+func (uc *UseCase) CreateShipment(ctx appcontext.AppContext, orderID int64, req CreateShipmentRequest) (*ShipmentResponse, error) {
+	order, shipments, items, err := uc.allocate(ctx.GetDefaultContext(), orderID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The order version serializes shipments, so the number is only taken by
+	// a booking of the same parcel
+	shipmentID := fmt.Sprintf("SHP-%d-%d", orderID, len(shipments)+1)
+	booked, err := uc.carrier.CreateShipment(ctx.GetDefaultContext(), port.ShipmentRequest{
+		Reference: shipmentID,
+		OrderID:   orderID,
+		Address:   order.ShippingAddress,
+		Items:     items,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to book shipment with %s: %w", uc.carrier.Name(), err)
+	}
+
+	var shipment *domainshipment.Shipment
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		order, current, allocated, err := uc.allocate(txCtx, orderID, req)
+		if err != nil {
+			return err
+		}
+		// The booked parcel no longer matches what is left to ship
+		if len(current) != len(shipments) || !slices.Equal(allocated, items) {
+			return fmt.Errorf("%w: order %d changed while booking its shipment", domainshipment.ErrVersionConflict, orderID)
+		}
+
+		now := time.Now()
+		shipment = &domainshipment.Shipment{
+			ShipmentID:     shipmentID,
+			OrderID:        orderID,
+			Carrier:        uc.carrier.Name(),
+			TrackingNumber: booked.TrackingNumber,
+			Items:          items,
+			Status:         domainshipment.StatusPending,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := uc.shipmentService.CreateShipment(txCtx, shipment); err != nil {
+			return err
+		}
+		return uc.orderFulfiller.AdvanceFulfilment(txCtx, order, domainorder.StatusProcessing, req.Actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := uc.mapper.ToResponse(shipment)
+	return &response, nil
+}
+
+// allocate checks that the order can be shipped to its address and returns
+// it with its shipments so far and the items of the requested shipment
+func (uc *UseCase) allocate(ctx context.Context, orderID int64, req CreateShipmentRequest) (*domainorder.Order, []domainshipment.Shipment, []domainshipment.Item, error) {
+	order, err := uc.orderService.GetOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if order.Status != domainorder.StatusPaid && order.Status != domainorder.StatusProcessing {
+		return nil, nil, nil, fmt.Errorf("%w: order is %s", domainshipment.ErrOrderNotShippable, order.Status)
+	}
+	if order.ShippingAddress == nil {
+		return nil, nil, nil, fmt.Errorf("%w: order has no shipping address", domainshipment.ErrOrderNotShippable)
+	}
+
+	shipments, err := uc.shipmentService.GetShipmentsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	items, err := domainshipment.Allocate(orderedQuantities(order), shipments, uc.mapper.ToItems(req.Items))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return order, shipments, items, nil
+}
+
+// GetShipmentsByOrder returns every shipment of an order, oldest first
+func (uc *UseCase) GetShipmentsByOrder(ctx appcontext.AppContext, orderID int64) (*ShipmentListResponse, error) {
+	if _, err := uc.orderService.GetOrder(ctx.GetDefaultContext(), orderID); err != nil {
+		return nil, err
+	}
+
+	shipments, err := uc.shipmentService.GetShipmentsByOrder(ctx.GetDefaultContext(), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments: %w", err)
+	}
+
+	response := uc.mapper.ToListResponse(orderID, shipments)
+	return &response, nil
+}
+
+// TrackShipment records a scan reported by the carrier in a signed callback.
+// The order moves to shipped once every item left the warehouse and to
+// delivered once every parcel arrived. A scan reported twice is recorded once.
+func (uc *UseCase) TrackShipment(ctx appcontext.AppContext, payload []byte, signature string) (*TrackingUpdateResponse, error) {
+	update, err := uc.carrier.ParseTrackingUpdate(payload, signature)
+	if err != nil {
+		return nil, err
+	}
+	event := update.Event
+	event.ReceivedAt = time.Now()
+	carrier := uc.carrier.Name()
+
+	var shipment *domainshipment.Shipment
+	result := trackingProcessed
+	err = uc.txManager.ExecuteInTx(ctx.GetDefaultContext(), func(txCtx context.Context) error {
+		var err error
+		shipment, err = uc.shipmentService.GetShipmentByTracking(txCtx, carrier, update.TrackingNumber)
+		if err != nil {
+			return err
+		}
+
+		changed, err := shipment.Track(event)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			result = trackingDuplicate
+			return nil
+		}
+		if err := uc.shipmentService.UpdateShipment(txCtx, shipment); err != nil {
+			return err
+		}
+
+		return uc.advanceOrder(txCtx, shipment.OrderID, "carrier:"+carrier)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TrackingUpdateResponse{
+		Status:   result,
+		Shipment: uc.mapper.ToResponse(shipment),
+	}, nil
+}
+
+// advanceOrder moves the order of a shipment as far as its shipments went
+func (uc *UseCase) advanceOrder(ctx context.Context, orderID int64, actor string) error {
+	order, err := uc.orderService.GetOrderForUpdate(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	shipments, err := uc.shipmentService.GetShipmentsByOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	next := domainorder.StatusProcessing
+	switch dispatched, delivered := domainshipment.Progress(orderedQuantities(order), shipments); {
+	case delivered:
+		next = domainorder.StatusDelivered
+	case dispatched:
+		next = domainorder.StatusShipped
+	}
+	if order.Status == next || next.CanReach(order.Status) {
+		return nil
+	}
+
+	err = uc.orderFulfiller.AdvanceFulfilment(ctx, order, next, actor)
+	if errors.Is(err, domainorder.ErrInvalidStatusTransition) {
+		// The order was cancelled while its parcels were on their way; the
+		// scans are kept but the order stays closed
+		return nil
+	}
+	return err
+}
+
+// orderedQuantities returns the quantities of an order left to deliver, by
+// product. Refunded items are not shipped.
+func orderedQuantities(order *domainorder.Order) map[string]int {
+	ordered := make(map[string]int, len(order.Items))
+	for _, item := range order.Items {
+		if left := item.Quantity - item.RefundedQuantity; left > 0 {
+			ordered[item.ProductID] += left
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,50 @@
+package voucher
+
+import (
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// VoucherRequest defines the payload for creating or updating a voucher
+type VoucherRequest struct {
+	Code             string      `json:"code" validate:"required"`
+	Description      string      `json:"description,omitempty"`
+	Type             string      `json:"type" validate:"required,oneof=percentage fixed_amount free_item"`
+	Active           bool        `json:"active"`
+	Percentage       float64     `json:"percentage,omitempty" validate:"gte=0,lte=100"`
+	Amount           money.Money `json:"amount"`
+	MaxDiscount      money.Money `json:"maxDiscount"`
+	FreeProductID    string      `json:"freeProductId,omitempty"`
+	FreeQuantity     int         `json:"freeQuantity,omitempty" validate:"gte=0"`
+	MinSpend         money.Money `json:"minSpend"`
+	UsageLimit       int         `json:"usageLimit,omitempty" validate:"gte=0"`
+	PerCustomerLimit int         `json:"perCustomerLimit,omitempty" validate:"gte=0"`
+	StartsAt         time.Time   `json:"startsAt,omitempty"`
+	EndsAt           time.Time   `json:"endsAt,omitempty"`
+}
+
+type VoucherResponse struct {
+	Code             string      `json:"code"`
+	Description      string      `json:"description,omitempty"`
+	Type             string      `json:"type"`
+	Active           bool        `json:"active"`
+	Percentage       float64     `json:"percentage,omitempty"`
+	Amount           money.Money `json:"amount"`
+	MaxDiscount      money.Money `json:"maxDiscount"`
+	FreeProductID    string      `json:"freeProductId,omitempty"`
+	FreeQuantity     int         `json:"freeQuantity,omitempty"`
+	MinSpend         money.Money `json:"minSpend"`
+	UsageLimit       int         `json:"usageLimit,omitempty"`
+	PerCustomerLimit int         `json:"perCustomerLimit,omitempty"`
+	UsedCount        int         `json:"usedCount"`
+	StartsAt         time.Time   `json:"startsAt,omitempty"`
+	EndsAt           time.Time   `json:"endsAt,omitempty"`
+	CreatedAt        time.Time   `json:"createdAt"`
+	UpdatedAt        time.Time   `json:"updatedAt"`
+}
+
+type VoucherListResponse struct {
+	Vouchers []VoucherResponse `json:"vouchers"`
+	Count    int               `json:"count"`
+}
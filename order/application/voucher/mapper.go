@@ -0,0 +1,47 @@
+package voucher
+
+import domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+
+// Mapper converts between DTOs and Domain entities
+type Mapper struct{}
+
+func (m *Mapper) ToEntity(dto VoucherRequest) *domainvoucher.Voucher {
+	return &domainvoucher.Voucher{
+		Code:             dto.Code,
+		Description:      dto.Description,
+		Type:             domainvoucher.VoucherType(dto.Type),
+		Active:           dto.Active,
+		Percentage:       dto.Percentage,
+		Amount:           dto.Amount,
+		MaxDiscount:      dto.MaxDiscount,
+		FreeProductID:    dto.FreeProductID,
+		FreeQuantity:     dto.FreeQuantity,
+		MinSpend:         dto.MinSpend,
+		UsageLimit:       dto.UsageLimit,
+		PerCustomerLimit: dto.PerCustomerLimit,
+		StartsAt:         dto.StartsAt,
+		EndsAt:           dto.EndsAt,
+	}
+}
+
+func (m *Mapper) ToResponse(voucher *domainvoucher.Voucher) VoucherResponse {
+	return VoucherResponse{
+		Code:             voucher.Code,
+		Description:      voucher.Description,
+		Type:             string(voucher.Type),
+		Active:           voucher.Active,
+		Percentage:       voucher.Percentage,
+		Amount:           voucher.Amount,
+		MaxDiscount:      voucher.MaxDiscount,
+		FreeProductID:    voucher.FreeProductID,
+		FreeQuantity:     voucher.FreeQuantity,
+		MinSpend:         voucher.MinSpend,
+		UsageLimit:       voucher.UsageLimit,
+		PerCustomerLimit: voucher.PerCustomerLimit,
+		UsedCount:        voucher.UsedCount,
+		StartsAt:         voucher.StartsAt,
+		EndsAt:           voucher.EndsAt,
+		CreatedAt:        voucher.CreatedAt,
+		UpdatedAt:        voucher.UpdatedAt,
+	}
+}
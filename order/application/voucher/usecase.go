@@ -0,0 +1,60 @@
+package voucher
+
+import (
+	appcontext "github.com/DuongVu089x/interview/order/component/appctx"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+)
+
+type UseCase struct {
+	mapper         *Mapper
+	voucherService domainvoucher.Service
+}
+
+func NewVoucherUseCase(voucherService domainvoucher.Service) *UseCase {
+	return &UseCase{
+		mapper:         &Mapper{},
+		voucherService: voucherService,
+	}
+}
+
+func (uc *UseCase) CreateVoucher(ctx appcontext.AppContext, req VoucherRequest) (*VoucherResponse, error) {
+	voucher := uc.mapper.ToEntity(req)
+	if err := uc.voucherService.CreateVoucher(ctx.GetDefaultContext(), voucher); err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToResponse(voucher)
+	return &response, nil
+}
+
+func (uc *UseCase) UpdateVoucher(ctx appcontext.AppContext, req VoucherRequest) (*VoucherResponse, error) {
+	voucher := uc.mapper.ToEntity(req)
+	if err := uc.voucherService.UpdateVoucher(ctx.GetDefaultContext(), voucher); err != nil {
+		return nil, err
+	}
+	return uc.GetVoucher(ctx, req.Code)
+}
+
+func (uc *UseCase) GetVoucher(ctx appcontext.AppContext, code string) (*VoucherResponse, error) {
+	voucher, err := uc.voucherService.GetVoucher(ctx.GetDefaultContext(), code)
+	if err != nil {
+		return nil, err
+	}
+	response := uc.mapper.ToResponse(voucher)
+	return &response, nil
+}
+
+func (uc *UseCase) GetVouchers(ctx appcontext.AppContext) (*VoucherListResponse, error) {
+	vouchers, err := uc.voucherService.GetVouchers(ctx.GetDefaultContext())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]VoucherResponse, 0, len(vouchers))
+	for _, voucher := range vouchers {
+		responses = append(responses, uc.mapper.ToResponse(&voucher))
+	}
+	return &VoucherListResponse{
+		Vouchers: responses,
+		Count:    len(responses),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package watch
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+)
+
+// subscriptionBuffer is how many updates a watcher may fall behind before it
+// is dropped
+const subscriptionBuffer = 16
+
+// Update is a status an order reached, as announced by one of its events on
+// the orders topic
+type Update struct {
+	OrderID     int64
+	Status      domainorder.OrderStatus
+	MessageCode string
+	OccurredAt  time.Time
+}
+
+// Hub fans the order events consumed by this instance out to the watchers of
+// each order. Watchers live in memory, so every instance must consume the
+// whole orders topic rather than share a consumer group.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the updates of a single order until it is closed
+type Subscription struct {
+	hub     *Hub
+	orderID int64
+	updates chan Update
+	closed  bool
+}
+
+// Subscribe starts receiving the updates of an order. Subscribe before reading
+// the current state so that no change slips in between.
+func (h *Hub) Subscribe(orderID int64) *Subscription {
+	sub := &Subscription{
+		hub:     h,
+		orderID: orderID,
+		updates: make(chan Update, subscriptionBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[orderID] == nil {
+		h.subscribers[orderID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[orderID][sub] = struct{}{}
+	return sub
+}
+
+// Updates is closed when the subscription is closed or has fallen behind
+func (s *Subscription) Updates() <-chan Update {
+	return s.updates
+}
+
+// Close stops the subscription; it is safe to call more than once
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
+
+// remove must be called with the hub lock held
+func (h *Hub) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.updates)
+
+	delete(h.subscribers[sub.orderID], sub)
+	if len(h.subscribers[sub.orderID]) == 0 {
+		delete(h.subscribers, sub.orderID)
+	}
+}
+
+// Publish delivers an update to the watchers of its order without blocking.
+// A watcher whose buffer is full is dropped and has to watch again.
+func (h *Hub) Publish(update Update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[update.OrderID] {
+		select {
+		case sub.updates <- update:
+		default:
+			h.remove(sub)
+		}
+	}
+}
+
+// HandleMessage publishes the status carried by an order event. It is meant
+// to be registered as the orders topic handler of a Kafka consumer.
+func (h *Hub) HandleMessage(msg domain.Message) error {
+	payload, ok := msg.Value.Payload.(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected payload for %s: %T", msg.Value.MessageCode, msg.Value.Payload)
+	}
+
+	orderID, err := strconv.ParseInt(fmt.Sprint(payload["order_id"]), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid order_id in %s: %w", msg.Value.MessageCode, err)
+	}
+
+	status := domainorder.OrderStatus(fmt.Sprint(payload["status"]))
+	if !status.IsValid() {
+		// Not a status carrying event; nothing to tell the watchers
+		return nil
+	}
+
+	occurredAt := time.Now()
+	if msg.Value.Meta != nil && msg.Value.Meta.Timestamp > 0 {
+		occurredAt = time.Unix(0, msg.Value.Meta.Timestamp)
+	}
+
+	h.Publish(Update{
+		OrderID:     orderID,
+		Status:      status,
+		MessageCode: msg.Value.MessageCode,
+		OccurredAt:  occurredAt,
+	})
+	return nil
+}
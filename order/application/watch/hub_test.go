@@ -0,0 +1,64 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/DuongVu089x/interview/order/domain"
+	domainorder "github.com/DuongVu089x/interview/order/domain/order"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderEvent(code, orderID, status string) domain.Message {
+	return domain.Message{
+		Topic: "orders-topic",
+		Value: domain.MessageValue{
+			Meta:        &domain.MetaData{Timestamp: 1700000000000000000},
+			MessageCode: code,
+			Payload:     map[string]any{"order_id": orderID, "user_id": "u1", "status": status},
+		},
+	}
+}
+
+func TestHandleMessageDeliversToWatchersOfTheOrder(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	other := hub.Subscribe(2)
+	defer sub.Close()
+	defer other.Close()
+
+	assert.NoError(t, hub.HandleMessage(orderEvent("ORDER_PAID", "1", "paid")))
+
+	update := <-sub.Updates()
+	assert.Equal(t, int64(1), update.OrderID)
+	assert.Equal(t, domainorder.StatusPaid, update.Status)
+	assert.Equal(t, "ORDER_PAID", update.MessageCode)
+	assert.Equal(t, int64(1700000000000000000), update.OccurredAt.UnixNano())
+	assert.Empty(t, other.Updates())
+}
+
+func TestHandleMessageRejectsMalformedEvents(t *testing.T) {
+	hub := NewHub()
+
+	assert.Error(t, hub.HandleMessage(domain.Message{Value: domain.MessageValue{Payload: "oops"}}))
+	assert.Error(t, hub.HandleMessage(orderEvent("ORDER_PAID", "abc", "paid")))
+	assert.NoError(t, hub.HandleMessage(orderEvent("ORDER_PAID", "1", "")))
+}
+
+func TestPublishDropsWatchersThatFallBehind(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+
+	for i := 0; i <= subscriptionBuffer; i++ {
+		hub.Publish(Update{OrderID: 1, Status: domainorder.StatusPaid})
+	}
+
+	received := 0
+	for range sub.Updates() {
+		received++
+	}
+	assert.Equal(t, subscriptionBuffer, received)
+	assert.Empty(t, hub.subscribers)
+
+	// Closing a dropped subscription is a no-op
+	sub.Close()
+}
@@ -0,0 +1,156 @@
+// Command migrate-money converts amounts stored as float64 major units into
+// money documents ({amount: <minor units>, currency: <ISO code>}).
+//
+// Run it once against each environment before rolling out the order service
+// version that reads money documents. It only touches fields that are still
+// stored as doubles, so it is safe to run again after a partial failure.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"math"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/config"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const databaseName = "orders"
+
+var (
+	currency = flag.String("currency", "USD", "ISO 4217 currency of the legacy amounts")
+	dryRun   = flag.Bool("dry-run", false, "only count the documents that need migrating")
+	timeout  = flag.Duration("timeout", 10*time.Minute, "timeout for the whole migration")
+)
+
+// migration rewrites the legacy amounts of one collection
+type migration struct {
+	collection string
+	filter     bson.M
+	pipeline   mongo.Pipeline
+}
+
+func main() {
+	flag.Parse()
+
+	if err := money.ValidateCurrency(*currency); err != nil {
+		log.Fatalf("Invalid currency: %v", err)
+	}
+
+	cfg := config.LoadConfig()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDB.URI))
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	db := client.Database(databaseName)
+	for _, m := range migrations(*currency) {
+		coll := db.Collection(m.collection)
+
+		if *dryRun {
+			count, err := coll.CountDocuments(ctx, m.filter)
+			if err != nil {
+				log.Fatalf("Failed to count %s: %v", m.collection, err)
+			}
+			log.Printf("%s: %d documents to migrate", m.collection, count)
+			continue
+		}
+
+		result, err := coll.UpdateMany(ctx, m.filter, m.pipeline)
+		if err != nil {
+			log.Fatalf("Failed to migrate %s: %v", m.collection, err)
+		}
+		log.Printf("%s: migrated %d of %d matched documents", m.collection, result.ModifiedCount, result.MatchedCount)
+	}
+}
+
+func migrations(currency string) []migration {
+	return []migration{
+		{
+			collection: "orders",
+			filter:     anyDouble("subtotal", "discount", "total_amount", "items.price"),
+			pipeline: mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"subtotal":     toMoney("$subtotal", currency),
+				"discount":     toMoney("$discount", currency),
+				"total_amount": toMoney("$total_amount", currency),
+				"items": bson.M{"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$items", bson.A{}}},
+					"as":    "item",
+					"in": bson.M{"$mergeObjects": bson.A{
+						"$$item",
+						bson.M{"price": toMoney("$$item.price", currency)},
+					}},
+				}},
+			}}}},
+		},
+		{
+			collection: "products",
+			filter:     anyDouble("price"),
+			pipeline: mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"price": toMoney("$price", currency),
+			}}}},
+		},
+		{
+			// value held the percentage of percentage vouchers and the amount of
+			// fixed amount vouchers; they now have a field each
+			collection: "vouchers",
+			filter: bson.M{"$or": bson.A{
+				bson.M{"value": bson.M{"$exists": true}},
+				anyDouble("max_discount", "min_spend"),
+			}},
+			pipeline: mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{
+					"percentage": bson.M{"$cond": bson.A{
+						bson.M{"$eq": bson.A{"$type", "percentage"}}, "$value", "$percentage",
+					}},
+					"amount": bson.M{"$cond": bson.A{
+						bson.M{"$eq": bson.A{"$type", "fixed_amount"}}, toMoney("$value", currency), "$amount",
+					}},
+					"max_discount": toMoney("$max_discount", currency),
+					"min_spend":    toMoney("$min_spend", currency),
+				}}},
+				{{Key: "$unset", Value: "value"}},
+			},
+		},
+		{
+			collection: "voucher_redemptions",
+			filter:     anyDouble("discount"),
+			pipeline: mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"discount": toMoney("$discount", currency),
+			}}}},
+		},
+	}
+}
+
+// anyDouble matches documents where any of the fields is still a double
+func anyDouble(fields ...string) bson.M {
+	conditions := make(bson.A, len(fields))
+	for i, field := range fields {
+		conditions[i] = bson.M{field: bson.M{"$type": "double"}}
+	}
+	return bson.M{"$or": conditions}
+}
+
+// toMoney converts the double at path into a money document and leaves any
+// other value, including a missing field, unchanged
+func toMoney(path string, currency string) bson.M {
+	factor := math.Pow10(money.MinorUnits(currency))
+	return bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{bson.M{"$type": path}, "double"}},
+		bson.M{
+			"amount":   bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{path, factor}}, 0}}},
+			"currency": currency,
+		},
+		path,
+	}}
+}
@@ -3,6 +3,7 @@ package appctx
 import (
 	"context"
 
+	"github.com/DuongVu089x/interview/order/application/port"
 	"github.com/DuongVu089x/interview/order/infrastructure/kafka"
 	pb "github.com/DuongVu089x/interview/order/proto/customer"
 	"github.com/redis/go-redis/v9"
@@ -22,6 +23,11 @@ type AppContext interface {
 	WithContext(c context.Context) AppContext
 
 	GetCustomerClient() pb.CustomerServiceClient
+
+	GetPaymentGateway() port.PaymentGateway
+	GetRefundProvider() port.RefundProvider
+
+	GetCarrier() port.Carrier
 }
 
 type appCtx struct {
@@ -36,6 +42,11 @@ type appCtx struct {
 	redisClient *redis.Client
 
 	customerClient pb.CustomerServiceClient
+
+	paymentGateway port.PaymentGateway
+	refundProvider port.RefundProvider
+
+	carrier port.Carrier
 }
 
 func NewAppContext(
@@ -45,6 +56,9 @@ func NewAppContext(
 	kafkaConsumer *kafka.RetryableConsumer,
 	redisClient *redis.Client,
 	customerClient pb.CustomerServiceClient,
+	paymentGateway port.PaymentGateway,
+	refundProvider port.RefundProvider,
+	carrier port.Carrier,
 ) *appCtx {
 	return &appCtx{
 		mainDB:         mainDB,
@@ -53,6 +67,9 @@ func NewAppContext(
 		kafkaConsumer:  kafkaConsumer,
 		redisClient:    redisClient,
 		customerClient: customerClient,
+		paymentGateway: paymentGateway,
+		refundProvider: refundProvider,
+		carrier:        carrier,
 	}
 }
 
@@ -87,6 +104,18 @@ func (ctx *appCtx) GetCustomerClient() pb.CustomerServiceClient {
 	return ctx.customerClient
 }
 
+func (ctx *appCtx) GetPaymentGateway() port.PaymentGateway {
+	return ctx.paymentGateway
+}
+
+func (ctx *appCtx) GetRefundProvider() port.RefundProvider {
+	return ctx.refundProvider
+}
+
+func (ctx *appCtx) GetCarrier() port.Carrier {
+	return ctx.carrier
+}
+
 // WithContext creates a new AppContext with the given context
 func (ctx *appCtx) WithContext(c context.Context) AppContext {
 	clone := *ctx
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // CustomerServiceConfig holds customer service configuration
@@ -18,6 +21,22 @@ type Config struct {
 	Redis           RedisConfig
 	Server          ServerConfig
 	CustomerService CustomerServiceConfig
+	Outbox          OutboxConfig
+	Idempotency     IdempotencyConfig
+	Payment         PaymentConfig
+	Shipping        ShippingConfig
+	OrderExpiry     OrderExpiryConfig
+	GRPC            GRPCConfig
+	OrderCode       OrderCodeConfig
+	IDGen           IDGenConfig
+	Saga            SagaConfig
+	Cart            CartConfig
+	OrderRules      OrderRulesConfig
+	Risk            RiskConfig
+
+	// DevMode enables local conveniences such as the routes that settle fake
+	// payments on demand; it must never be set in production
+	DevMode bool
 }
 
 // MongoDBConfig holds MongoDB configuration
@@ -49,6 +68,120 @@ type RedisConfig struct {
 	DB       int
 }
 
+// OutboxConfig holds configuration for the outbox relay
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	// SentRetention is how long relayed messages are kept before Mongo
+	// deletes them; changing it needs the sent_at index to be dropped first
+	SentRetention time.Duration
+}
+
+// IdempotencyConfig holds configuration for Idempotency-Key handling
+type IdempotencyConfig struct {
+	TTL time.Duration
+}
+
+// PaymentConfig holds payment gateway configuration. Both settings are
+// required, so a deployment never falls back to the fake gateway or to a
+// webhook secret anyone can read.
+type PaymentConfig struct {
+	// Provider selects the gateway; "fake" settles payments in memory for local runs
+	Provider      string
+	WebhookSecret string
+}
+
+// ShippingConfig holds shipping carrier configuration. The carrier is
+// required outside dev mode, so a deployment never books parcels with the
+// fake carrier by accident, and the webhook secret is always required.
+type ShippingConfig struct {
+	// Carrier selects the carrier; "fake" moves parcels along a script for local runs
+	Carrier string
+	// WebhookSecret verifies the tracking updates posted by the carrier
+	WebhookSecret string
+}
+
+// OrderExpiryConfig holds configuration for expiring unpaid pending orders
+type OrderExpiryConfig struct {
+	// PendingTTL is how long an order may wait for payment before it expires
+	PendingTTL time.Duration
+	Interval   time.Duration
+	BatchSize  int
+}
+
+// OrderCodeConfig holds the format of the public order codes
+type OrderCodeConfig struct {
+	Prefix string
+	// Alphabet is a private permutation of the code characters; changing it
+	// changes the codes of new orders only. It is required outside dev mode,
+	// as anyone knowing it can decode codes into order IDs.
+	Alphabet string
+	Length   int
+}
+
+// devOrderCodeAlphabet is the public alphabet of dev mode. Its codes can be
+// decoded by anyone, so it is never used in production.
+const devOrderCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// devShippingCarrier is the carrier of dev mode when none is configured
+const devShippingCarrier = "fake"
+
+// IDGenConfig holds ID generator configuration
+type IDGenConfig struct {
+	// Backend is "mongo" or "redis"; the Redis counters start above the Mongo ones
+	Backend string
+	// BlockSize is how many IDs are leased at once; unused IDs of a block are
+	// skipped on restart
+	BlockSize int
+}
+
+// SagaConfig holds configuration for the saga orchestrator
+type SagaConfig struct {
+	StepTimeout time.Duration
+	// LeaseDuration is how long a saga stays with the instance running it
+	// before another may resume it; it must exceed StepTimeout
+	LeaseDuration           time.Duration
+	MaxCompensationAttempts int
+	RecoveryInterval        time.Duration
+	RecoveryBatchSize       int
+}
+
+// CartConfig holds configuration for shopping carts
+type CartConfig struct {
+	// TTL is how long a cart is kept after its last change
+	TTL time.Duration
+}
+
+// OrderRulesConfig holds configuration for the order validation rules
+type OrderRulesConfig struct {
+	// ReloadInterval is how often rules are reloaded, so that rules changed
+	// through another instance apply here too
+	ReloadInterval time.Duration
+}
+
+// RiskConfig holds configuration for the fraud risk assessment of new orders
+type RiskConfig struct {
+	// Assessor selects the assessor; "heuristic" scores orders from their
+	// velocity, amount and account age, "none" holds no order
+	Assessor       string
+	VelocityWindow time.Duration
+	VelocityLimit  int
+	// OutlierFactor is how many times the average past order an order may
+	// total before it stands out
+	OutlierFactor int
+	NewAccountAge time.Duration
+	// MediumScore and HighScore grade risk scores out of 100; orders scored
+	// high are held for review
+	MediumScore int
+	HighScore   int
+}
+
+// GRPCConfig holds gRPC configuration
+type GRPCConfig struct {
+	Port string
+}
+
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Port string
@@ -56,6 +189,18 @@ type ServerConfig struct {
 
 // LoadConfig loads configuration from environment variables
 func LoadConfig() *Config {
+	devMode := getEnvAsBool("DEV_MODE", false)
+
+	orderCodeAlphabet := getEnv("ORDER_CODE_ALPHABET", "")
+	if orderCodeAlphabet == "" && devMode {
+		orderCodeAlphabet = devOrderCodeAlphabet
+	}
+
+	shippingCarrier := getEnv("SHIPPING_CARRIER", "")
+	if shippingCarrier == "" && devMode {
+		shippingCarrier = devShippingCarrier
+	}
+
 	return &Config{
 		MongoDB: MongoDBConfig{
 			URI:      getEnv("MONGODB_URI", "mongodb://localhost:27017"),
@@ -72,6 +217,11 @@ func LoadConfig() *Config {
 					NumPartitions:     3,
 					ReplicationFactor: 3,
 				},
+				{
+					Name:              "carts-topic",
+					NumPartitions:     3,
+					ReplicationFactor: 3,
+				},
 			},
 		},
 		Redis: RedisConfig{
@@ -86,9 +236,90 @@ func LoadConfig() *Config {
 			Host: getEnv("CUSTOMER_SERVICE_HOST", "localhost"),
 			Port: getEnv("CUSTOMER_SERVICE_PORT", "8080"),
 		},
+		Outbox: OutboxConfig{
+			PollInterval:  getEnvAsDuration("OUTBOX_POLL_INTERVAL", 1*time.Second),
+			BatchSize:     getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			MaxAttempts:   getEnvAsInt("OUTBOX_MAX_ATTEMPTS", 10),
+			SentRetention: getEnvAsDuration("OUTBOX_SENT_RETENTION", 7*24*time.Hour),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: getEnvAsDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		},
+		Payment: PaymentConfig{
+			Provider:      getEnv("PAYMENT_PROVIDER", ""),
+			WebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		},
+		Shipping: ShippingConfig{
+			Carrier:       shippingCarrier,
+			WebhookSecret: getEnv("SHIPPING_WEBHOOK_SECRET", ""),
+		},
+		OrderExpiry: OrderExpiryConfig{
+			PendingTTL: getEnvAsDuration("ORDER_PENDING_TTL", 30*time.Minute),
+			Interval:   getEnvAsDuration("ORDER_EXPIRY_INTERVAL", 1*time.Minute),
+			BatchSize:  getEnvAsInt("ORDER_EXPIRY_BATCH_SIZE", 100),
+		},
+		GRPC: GRPCConfig{
+			Port: getEnv("GRPC_PORT", "50052"),
+		},
+		OrderCode: OrderCodeConfig{
+			Prefix:   getEnv("ORDER_CODE_PREFIX", "O"),
+			Alphabet: orderCodeAlphabet,
+			Length:   getEnvAsInt("ORDER_CODE_LENGTH", 8),
+		},
+		IDGen: IDGenConfig{
+			Backend:   getEnv("ID_GEN_BACKEND", "mongo"),
+			BlockSize: getEnvAsInt("ID_GEN_BLOCK_SIZE", 1000),
+		},
+		Saga: SagaConfig{
+			StepTimeout:             getEnvAsDuration("SAGA_STEP_TIMEOUT", 10*time.Second),
+			LeaseDuration:           getEnvAsDuration("SAGA_LEASE_DURATION", 1*time.Minute),
+			MaxCompensationAttempts: getEnvAsInt("SAGA_MAX_COMPENSATION_ATTEMPTS", 10),
+			RecoveryInterval:        getEnvAsDuration("SAGA_RECOVERY_INTERVAL", 30*time.Second),
+			RecoveryBatchSize:       getEnvAsInt("SAGA_RECOVERY_BATCH_SIZE", 50),
+		},
+		Cart: CartConfig{
+			TTL: getEnvAsDuration("CART_TTL", 7*24*time.Hour),
+		},
+		OrderRules: OrderRulesConfig{
+			ReloadInterval: getEnvAsDuration("ORDER_RULES_RELOAD_INTERVAL", 30*time.Second),
+		},
+		Risk: RiskConfig{
+			Assessor:       getEnv("RISK_ASSESSOR", "heuristic"),
+			VelocityWindow: getEnvAsDuration("RISK_VELOCITY_WINDOW", 1*time.Hour),
+			VelocityLimit:  getEnvAsInt("RISK_VELOCITY_LIMIT", 3),
+			OutlierFactor:  getEnvAsInt("RISK_OUTLIER_FACTOR", 5),
+			NewAccountAge:  getEnvAsDuration("RISK_NEW_ACCOUNT_AGE", 7*24*time.Hour),
+			MediumScore:    getEnvAsInt("RISK_MEDIUM_SCORE", 40),
+			HighScore:      getEnvAsInt("RISK_HIGH_SCORE", 70),
+		},
+		DevMode: devMode,
 	}
 }
 
+// Validate reports the required settings that are missing
+func (c *Config) Validate() error {
+	var missing []string
+	if c.Payment.Provider == "" {
+		missing = append(missing, "PAYMENT_PROVIDER")
+	}
+	if c.Payment.WebhookSecret == "" {
+		missing = append(missing, "PAYMENT_WEBHOOK_SECRET")
+	}
+	if c.OrderCode.Alphabet == "" {
+		missing = append(missing, "ORDER_CODE_ALPHABET")
+	}
+	if c.Shipping.Carrier == "" {
+		missing = append(missing, "SHIPPING_CARRIER")
+	}
+	if c.Shipping.WebhookSecret == "" {
+		missing = append(missing, "SHIPPING_WEBHOOK_SECRET")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required settings: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // Helper function to get an environment variable with a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -110,3 +341,29 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return value
 }
+
+// Helper function to get an environment variable as a boolean with a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// Helper function to get an environment variable as a duration (e.g. "30s") with a default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+package cart
+
+import "time"
+
+const (
+	// MaxLines bounds the number of distinct products in a cart
+	MaxLines = 50
+	// MaxQuantity bounds the quantity of a product in a cart
+	MaxQuantity = 999
+)
+
+// Cart holds the products a user intends to order. Prices are not stored:
+// they are read from the catalog whenever the cart is shown or checked out.
+type Cart struct {
+	UserID string `json:"userId"`
+	Items  []Item `json:"items"`
+
+	// Version is incremented on every change, letting clients order updates
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Item struct {
+	ProductID string    `json:"productId"`
+	Quantity  int       `json:"quantity"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+// NewCart returns the empty cart of a user
+func NewCart(userID string) *Cart {
+	return &Cart{UserID: userID, Items: []Item{}}
+}
+
+// AddItem adds quantity to the product's line, creating the line if needed
+func (c *Cart) AddItem(productID string, quantity int, at time.Time) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if i := c.find(productID); i >= 0 {
+		return c.SetQuantity(productID, c.Items[i].Quantity+quantity, at)
+	}
+	if len(c.Items) >= MaxLines {
+		return ErrCartFull
+	}
+	if quantity > MaxQuantity {
+		return ErrInvalidQuantity
+	}
+
+	c.Items = append(c.Items, Item{ProductID: productID, Quantity: quantity, AddedAt: at})
+	c.touch(at)
+	return nil
+}
+
+// SetQuantity replaces the quantity of a product in the cart; zero removes it
+func (c *Cart) SetQuantity(productID string, quantity int, at time.Time) error {
+	if quantity < 0 || quantity > MaxQuantity {
+		return ErrInvalidQuantity
+	}
+	i := c.find(productID)
+	if i < 0 {
+		return ErrItemNotInCart
+	}
+
+	if quantity == 0 {
+		c.Items = append(c.Items[:i], c.Items[i+1:]...)
+	} else {
+		c.Items[i].Quantity = quantity
+	}
+	c.touch(at)
+	return nil
+}
+
+// RemoveItem removes the product from the cart
+func (c *Cart) RemoveItem(productID string, at time.Time) error {
+	return c.SetQuantity(productID, 0, at)
+}
+
+// Clear empties the cart
+func (c *Cart) Clear(at time.Time) {
+	c.Items = []Item{}
+	c.touch(at)
+}
+
+// Subtract takes ordered quantities out of the cart, leaving what was added
+// meanwhile
+func (c *Cart) Subtract(ordered map[string]int, at time.Time) {
+	items := c.Items[:0]
+	for _, item := range c.Items {
+		item.Quantity -= ordered[item.ProductID]
+		if item.Quantity > 0 {
+			items = append(items, item)
+		}
+	}
+	c.Items = items
+	c.touch(at)
+}
+
+// IsEmpty reports whether the cart has no items
+func (c *Cart) IsEmpty() bool {
+	return len(c.Items) == 0
+}
+
+func (c *Cart) find(productID string) int {
+	for i, item := range c.Items {
+		if item.ProductID == productID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Cart) touch(at time.Time) {
+	c.Version++
+	c.UpdatedAt = at
+}
@@ -0,0 +1,44 @@
+package cart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddItemMergesLines(t *testing.T) {
+	now := time.Now()
+	cart := NewCart("user-1")
+
+	assert.NoError(t, cart.AddItem("sku-1", 2, now))
+	assert.NoError(t, cart.AddItem("sku-1", 3, now))
+	assert.NoError(t, cart.AddItem("sku-2", 1, now))
+
+	assert.Len(t, cart.Items, 2)
+	assert.Equal(t, 5, cart.Items[0].Quantity)
+	assert.Equal(t, int64(3), cart.Version)
+	assert.ErrorIs(t, cart.AddItem("sku-1", MaxQuantity, now), ErrInvalidQuantity)
+}
+
+func TestSetQuantityZeroRemovesTheLine(t *testing.T) {
+	now := time.Now()
+	cart := NewCart("user-1")
+	assert.NoError(t, cart.AddItem("sku-1", 2, now))
+
+	assert.ErrorIs(t, cart.SetQuantity("sku-2", 1, now), ErrItemNotInCart)
+	assert.NoError(t, cart.SetQuantity("sku-1", 0, now))
+	assert.True(t, cart.IsEmpty())
+}
+
+func TestSubtractKeepsItemsAddedSinceCheckout(t *testing.T) {
+	now := time.Now()
+	cart := NewCart("user-1")
+	assert.NoError(t, cart.AddItem("sku-1", 2, now))
+	assert.NoError(t, cart.AddItem("sku-2", 1, now))
+	assert.NoError(t, cart.AddItem("sku-1", 1, now))
+
+	cart.Subtract(map[string]int{"sku-1": 2, "sku-2": 1}, now)
+
+	assert.Equal(t, []Item{{ProductID: "sku-1", Quantity: 1, AddedAt: now}}, cart.Items)
+}
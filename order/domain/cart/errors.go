@@ -0,0 +1,12 @@
+package cart
+
+import "errors"
+
+var (
+	ErrCartEmpty       = errors.New("cart is empty")
+	ErrCartFull        = errors.New("cart cannot hold more products")
+	ErrItemNotInCart   = errors.New("product is not in the cart")
+	ErrInvalidQuantity = errors.New("invalid quantity")
+	// ErrCartBusy is returned when a cart kept changing concurrently
+	ErrCartBusy = errors.New("cart is being changed concurrently")
+)
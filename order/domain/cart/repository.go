@@ -0,0 +1,16 @@
+package cart
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// GetCart returns the cart of a user, or nil when the user has none
+	GetCart(ctx context.Context, userID string) (*Cart, error)
+
+	// UpdateCart applies fn to the cart of a user, an empty one if it has
+	// none, and saves it for ttl. fn is called again if the cart changed
+	// concurrently; nothing is saved when it returns an error.
+	UpdateCart(ctx context.Context, userID string, ttl time.Duration, fn func(cart *Cart) error) (*Cart, error)
+}
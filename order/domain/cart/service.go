@@ -0,0 +1,16 @@
+package cart
+
+import "context"
+
+// Service defines the business operations for carts
+type Service interface {
+	// GetCart returns the cart of a user, empty if the user has none
+	GetCart(ctx context.Context, userID string) (*Cart, error)
+	AddItem(ctx context.Context, userID, productID string, quantity int) (*Cart, error)
+	SetQuantity(ctx context.Context, userID, productID string, quantity int) (*Cart, error)
+	RemoveItem(ctx context.Context, userID, productID string) (*Cart, error)
+	Clear(ctx context.Context, userID string) (*Cart, error)
+
+	// Subtract takes the quantities of a placed order out of the cart
+	Subtract(ctx context.Context, userID string, ordered map[string]int) (*Cart, error)
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Action names the kind of mutation an entry records
+type Action string
+
+const (
+	ActionCreated       Action = "created"
+	ActionStatusChanged Action = "status_changed"
+	ActionCancelled     Action = "cancelled"
+	ActionPaid          Action = "paid"
+	ActionRefunded      Action = "refunded"
+	ActionExpired       Action = "expired"
+	ActionReviewed      Action = "reviewed"
+)
+
+// Entry records one mutation of an order in the order_history collection
+type Entry struct {
+	ID             *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	OrderID        int64               `json:"orderId,omitempty" bson:"order_id,omitempty"`
+	Action         Action              `json:"action,omitempty" bson:"action,omitempty"`
+	Actor          string              `json:"actor,omitempty" bson:"actor,omitempty"`
+	PreviousStatus string              `json:"previousStatus,omitempty" bson:"previous_status,omitempty"`
+	NewStatus      string              `json:"newStatus,omitempty" bson:"new_status,omitempty"`
+	Changes        []Change            `json:"changes,omitempty" bson:"changes,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+}
+
+// Change is the before and after value of one field, each held as JSON.
+// From is empty for fields that did not exist before.
+type Change struct {
+	Field string `json:"field" bson:"field"`
+	From  string `json:"from,omitempty" bson:"from,omitempty"`
+	To    string `json:"to,omitempty" bson:"to,omitempty"`
+}
+
+// ignoredFields change on every mutation and would only add noise to a diff
+var ignoredFields = map[string]bool{
+	"updatedAt": true,
+}
+
+// Diff compares the JSON representation of before and after field by field
+// and returns the top level fields that differ, sorted by name
+func Diff(before, after any) ([]Change, error) {
+	from, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(from)+len(to))
+	for name := range from {
+		names[name] = true
+	}
+	for name := range to {
+		names[name] = true
+	}
+
+	var changes []Change
+	for name := range names {
+		if ignoredFields[name] || reflect.DeepEqual(from[name], to[name]) {
+			continue
+		}
+		changes = append(changes, Change{
+			Field: name,
+			From:  string(from[name]),
+			To:    string(to[name]),
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+func toFields(value any) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if v := reflect.ValueOf(value); !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return fields, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
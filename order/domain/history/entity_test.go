@@ -0,0 +1,31 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type document struct {
+	Status    string `json:"status"`
+	Total     int    `json:"total,omitempty"`
+	Note      string `json:"note,omitempty"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func TestDiff(t *testing.T) {
+	before := &document{Status: "pending", Total: 10, UpdatedAt: "monday"}
+	after := &document{Status: "paid", Total: 10, Note: "paid by card", UpdatedAt: "tuesday"}
+
+	changes, err := Diff(before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Field: "note", To: `"paid by card"`},
+		{Field: "status", From: `"pending"`, To: `"paid"`},
+	}, changes)
+
+	var missing *document
+	changes, err = Diff(missing, before)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2, "every field is new when there is nothing before")
+}
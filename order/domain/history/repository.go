@@ -0,0 +1,10 @@
+package history
+
+import "context"
+
+type Repository interface {
+	CreateEntry(ctx context.Context, entry *Entry) error
+
+	// GetEntries returns the history of an order, oldest first
+	GetEntries(ctx context.Context, orderID int64) ([]Entry, error)
+}
@@ -0,0 +1,129 @@
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+var ErrInvalidCodeFormat = errors.New("invalid code format")
+
+// codeMultiplier spreads consecutive IDs across the code space. It is prime
+// and larger than any alphabet, so it is coprime with every capacity and the
+// mapping stays one to one.
+const codeMultiplier = 2147483647
+
+// CodeFormat turns sequential IDs into public codes that do not reveal how
+// many IDs were issued. A code is the prefix, Length characters of the
+// scrambled ID and a check character that catches single typos and most
+// swapped neighbours.
+type CodeFormat struct {
+	prefix   string
+	alphabet string
+	length   int
+	capacity uint64
+}
+
+// NewCodeFormat builds a code format. The alphabet doubles as the scrambling
+// key, so it should be a private permutation of upper case letters and digits.
+func NewCodeFormat(prefix, alphabet string, length int) (*CodeFormat, error) {
+	if len(alphabet) < 2 {
+		return nil, fmt.Errorf("%w: alphabet needs at least 2 characters", ErrInvalidCodeFormat)
+	}
+	for i, c := range alphabet {
+		if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z') {
+			return nil, fmt.Errorf("%w: alphabet may only hold 0-9 and A-Z, got %q", ErrInvalidCodeFormat, c)
+		}
+		if strings.IndexRune(alphabet, c) != i {
+			return nil, fmt.Errorf("%w: alphabet repeats %q", ErrInvalidCodeFormat, c)
+		}
+	}
+	if length < 1 {
+		return nil, fmt.Errorf("%w: length must be positive", ErrInvalidCodeFormat)
+	}
+
+	capacity := uint64(1)
+	for i := 0; i < length; i++ {
+		if capacity > math.MaxInt64/uint64(len(alphabet)) {
+			return nil, fmt.Errorf("%w: %d characters of a %d character alphabet overflow an ID", ErrInvalidCodeFormat, length, len(alphabet))
+		}
+		capacity *= uint64(len(alphabet))
+	}
+
+	return &CodeFormat{
+		prefix:   strings.ToUpper(prefix),
+		alphabet: alphabet,
+		length:   length,
+		capacity: capacity,
+	}, nil
+}
+
+// Encode returns the code of an ID. IDs beyond the capacity of the format
+// wrap around, so the length must leave room for every ID ever issued.
+func (f *CodeFormat) Encode(id int64) string {
+	// Multiplying by a number coprime with the capacity permutes the code space
+	hi, lo := bits.Mul64(uint64(id)%f.capacity, codeMultiplier%f.capacity)
+	_, number := bits.Div64(hi, lo, f.capacity)
+
+	ln := uint64(len(f.alphabet))
+	body := make([]byte, f.length)
+	for i := range body {
+		cur := number % ln
+		if i > 0 {
+			// Chain every character to the previous one so that neighbouring
+			// IDs differ throughout the code
+			cur = (cur + uint64(body[i-1])) % ln
+		}
+		body[i] = f.alphabet[cur]
+		number /= ln
+	}
+
+	return f.prefix + string(body) + string(f.checkChar(body))
+}
+
+// IsValid reports whether the code has the shape of this format and a
+// matching check character. Codes are compared upper case.
+func (f *CodeFormat) IsValid(code string) bool {
+	code = strings.ToUpper(code)
+	if !strings.HasPrefix(code, f.prefix) || len(code) != len(f.prefix)+f.length+1 {
+		return false
+	}
+
+	body := []byte(code[len(f.prefix) : len(code)-1])
+	for _, c := range body {
+		if strings.IndexByte(f.alphabet, c) < 0 {
+			return false
+		}
+	}
+	return code[len(code)-1] == f.checkChar(body)
+}
+
+// IsLegacyCode reports whether the code has the shape of the codes issued
+// before codes were scrambled: "O" followed by the zero padded order ID
+func IsLegacyCode(code string) bool {
+	digits, ok := strings.CutPrefix(strings.ToUpper(code), "O")
+	if !ok || len(digits) < 8 {
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkChar computes the Luhn mod N check character of a code body
+func (f *CodeFormat) checkChar(body []byte) byte {
+	n := len(f.alphabet)
+	factor := 2
+	sum := 0
+	for i := len(body) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(f.alphabet, body[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return f.alphabet[(n-sum%n)%n]
+}
@@ -0,0 +1,70 @@
+package idgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAlphabet = "JRMGWV3L48CZNH6FE925APKYQS7XDBTU"
+
+func TestEncodeIsUniqueAndNonSequential(t *testing.T) {
+	format, err := NewCodeFormat("o", testAlphabet, 8)
+	assert.NoError(t, err)
+
+	seen := make(map[string]bool)
+	for id := int64(1); id <= 10000; id++ {
+		code := format.Encode(id)
+		assert.Len(t, code, 10)
+		assert.Equal(t, byte('O'), code[0])
+		assert.True(t, format.IsValid(code), code)
+		assert.False(t, seen[code], "duplicate code %s", code)
+		seen[code] = true
+	}
+
+	// Neighbouring IDs share no position except the prefix
+	first, second := format.Encode(1000), format.Encode(1001)
+	for i := 1; i < len(first)-1; i++ {
+		assert.NotEqual(t, first[i], second[i], "%s and %s share position %d", first, second, i)
+	}
+}
+
+func TestIsValidCatchesTypos(t *testing.T) {
+	format, err := NewCodeFormat("O", testAlphabet, 8)
+	assert.NoError(t, err)
+
+	code := format.Encode(42)
+	assert.True(t, format.IsValid(code))
+	assert.False(t, format.IsValid(code[:len(code)-1]))
+	assert.False(t, format.IsValid("X"+code[1:]))
+
+	for i := 1; i < len(code); i++ {
+		for _, c := range []byte(testAlphabet) {
+			if c == code[i] {
+				continue
+			}
+			typo := []byte(code)
+			typo[i] = c
+			assert.False(t, format.IsValid(string(typo)), "substitution %s accepted", typo)
+		}
+	}
+}
+
+func TestIsLegacyCode(t *testing.T) {
+	assert.True(t, IsLegacyCode("O00000042"))
+	assert.True(t, IsLegacyCode("o123456789"))
+	assert.False(t, IsLegacyCode("O0000042"))
+	assert.False(t, IsLegacyCode("X00000042"))
+	assert.False(t, IsLegacyCode("O0000004A"))
+}
+
+func TestNewCodeFormatRejectsBadAlphabets(t *testing.T) {
+	_, err := NewCodeFormat("O", "ABCA", 4)
+	assert.ErrorIs(t, err, ErrInvalidCodeFormat)
+
+	_, err = NewCodeFormat("O", "abc", 4)
+	assert.ErrorIs(t, err, ErrInvalidCodeFormat)
+
+	_, err = NewCodeFormat("O", testAlphabet, 13)
+	assert.ErrorIs(t, err, ErrInvalidCodeFormat)
+}
@@ -3,3 +3,10 @@ package idgen
 type Repository interface {
 	GenerateID(string) (int64, error)
 }
+
+// BlockAllocator leases ranges of IDs so that they can be handed out from
+// memory instead of with a round trip per ID
+type BlockAllocator interface {
+	// AllocateBlock reserves the next size IDs of the key and returns the last one
+	AllocateBlock(key string, size int64) (int64, error)
+}
@@ -1,5 +1,8 @@
 package idgen
 
 type Service interface {
+	// GenerateID returns the next ID of the key and its public code
 	GenerateID(string) (int64, string, error)
+	// IsValidCode reports whether a code could have been generated, without a lookup
+	IsValidCode(string) bool
 }
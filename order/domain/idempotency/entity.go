@@ -0,0 +1,21 @@
+package idempotency
+
+import "time"
+
+// Record stores the outcome of a request made with an Idempotency-Key so that
+// retries of the same request can be answered without executing it again
+type Record struct {
+	RequestHash string       `json:"requestHash"`
+	Status      RecordStatus `json:"status"`
+	StatusCode  int          `json:"statusCode,omitempty"`
+	ContentType string       `json:"contentType,omitempty"`
+	Body        []byte       `json:"body,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+type RecordStatus string
+
+const (
+	StatusProcessing RecordStatus = "processing"
+	StatusCompleted  RecordStatus = "completed"
+)
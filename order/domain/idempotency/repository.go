@@ -0,0 +1,15 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Reserve stores record under key if the key is unused. When the key is
+	// already taken the existing record is returned instead.
+	Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (*Record, error)
+
+	Save(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	Release(ctx context.Context, key string) error
+}
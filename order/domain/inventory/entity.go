@@ -0,0 +1,43 @@
+package inventory
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Stock is the stock level of a single product. Available is what can still be
+// sold, Reserved is held by orders that have not shipped yet.
+type Stock struct {
+	ID        *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	ProductID string              `json:"productId,omitempty" bson:"product_id,omitempty"`
+	Available int                 `json:"available" bson:"available"`
+	Reserved  int                 `json:"reserved" bson:"reserved"`
+	CreatedAt time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time           `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+// Reservation records the quantity of a product held for an order
+type Reservation struct {
+	ID        *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	OrderID   int64               `json:"orderId,omitempty" bson:"order_id,omitempty"`
+	ProductID string              `json:"productId,omitempty" bson:"product_id,omitempty"`
+	Quantity  int                 `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Status    ReservationStatus   `json:"status,omitempty" bson:"status,omitempty"`
+	CreatedAt time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time           `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+type ReservationStatus string
+
+const (
+	ReservationReserved  ReservationStatus = "reserved"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationCommitted ReservationStatus = "committed"
+)
+
+// ReservationItem is a product and quantity to reserve
+type ReservationItem struct {
+	ProductID string
+	Quantity  int
+}
@@ -0,0 +1,9 @@
+package inventory
+
+import "errors"
+
+var (
+	ErrStockNotFound     = errors.New("stock not found")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrInvalidQuantity   = errors.New("quantity must not be negative")
+)
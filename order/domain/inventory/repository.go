@@ -0,0 +1,25 @@
+package inventory
+
+import "context"
+
+type Repository interface {
+	GetStock(ctx context.Context, productID string) (*Stock, error)
+	GetStocks(ctx context.Context) ([]Stock, error)
+
+	// SetAvailable overwrites the available quantity, creating the stock if needed
+	SetAvailable(ctx context.Context, productID string, available int) (*Stock, error)
+	// AdjustAvailable adds delta to the available quantity without letting it drop below zero
+	AdjustAvailable(ctx context.Context, productID string, delta int) (*Stock, error)
+
+	// Reserve atomically moves quantity from available to reserved, failing
+	// with ErrInsufficientStock when not enough is available
+	Reserve(ctx context.Context, productID string, quantity int) error
+	// Release moves quantity from reserved back to available
+	Release(ctx context.Context, productID string, quantity int) error
+	// Commit removes quantity from reserved once the goods have left the warehouse
+	Commit(ctx context.Context, productID string, quantity int) error
+
+	CreateReservations(ctx context.Context, reservations []Reservation) error
+	GetReservationsByOrder(ctx context.Context, orderID int64, status ReservationStatus) ([]Reservation, error)
+	UpdateReservationsStatus(ctx context.Context, orderID int64, from, to ReservationStatus) error
+}
@@ -0,0 +1,21 @@
+package inventory
+
+import "context"
+
+// Service defines the business operations for inventory
+type Service interface {
+	GetStock(ctx context.Context, productID string) (*Stock, error)
+	GetStocks(ctx context.Context) ([]Stock, error)
+	SetStock(ctx context.Context, productID string, available int) (*Stock, error)
+	AdjustStock(ctx context.Context, productID string, delta int) (*Stock, error)
+
+	// ReserveForOrder holds stock for every item of an order. It should run in the
+	// same transaction as the order so that a failure reserves nothing.
+	ReserveForOrder(ctx context.Context, orderID int64, items []ReservationItem) error
+	// ReleaseForOrder returns the stock held by an order to available
+	ReleaseForOrder(ctx context.Context, orderID int64) error
+	// CommitForOrder consumes the stock held by an order once it ships
+	CommitForOrder(ctx context.Context, orderID int64) error
+
+	GetReservationsByOrder(ctx context.Context, orderID int64) ([]Reservation, error)
+}
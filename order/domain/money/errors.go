@@ -0,0 +1,8 @@
+package money
+
+import "errors"
+
+var (
+	ErrInvalidCurrency  = errors.New("invalid currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
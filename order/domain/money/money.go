@@ -0,0 +1,174 @@
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of a currency, held as an integer number of the
+// currency's minor units (cents for USD, yen for JPY).
+//
+// The zero value has no currency and acts as zero in any currency, so it can
+// be used as the starting point of a sum.
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount"`
+	Currency string `json:"currency,omitempty" bson:"currency,omitempty"`
+}
+
+// minorUnits lists the currencies whose minor unit is not a hundredth
+var minorUnits = map[string]int{
+	"BHD": 3,
+	"JOD": 3,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+}
+
+// New returns amount minor units of currency
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// FromMajor converts an amount in major units, such as a legacy float price,
+// rounding half away from zero to the nearest minor unit
+func FromMajor(value float64, currency string) Money {
+	factor := math.Pow10(MinorUnits(currency))
+	return New(int64(math.Round(value*factor)), currency)
+}
+
+// MinorUnits returns the number of decimal places of the currency
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[currency]; ok {
+		return units
+	}
+	return 2
+}
+
+// ValidateCurrency checks that currency looks like an ISO 4217 code
+func ValidateCurrency(currency string) error {
+	if len(currency) != 3 || strings.ToUpper(currency) != currency {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether the amount is zero. It also lets the BSON encoder
+// omit zero amounts tagged omitempty.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Add returns m + other. Both must be in the same currency unless one of them
+// is the zero value.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount+other.Amount, currency), nil
+}
+
+// Sub returns m - other. Both must be in the same currency unless one of them
+// is the zero value.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount-other.Amount, currency), nil
+}
+
+// Multiply returns m times quantity
+func (m Money) Multiply(quantity int) Money {
+	return New(m.Amount*int64(quantity), m.Currency)
+}
+
+// Percent returns percent per cent of m, rounded half away from zero to the
+// nearest minor unit
+func (m Money) Percent(percent float64) Money {
+	return New(int64(math.Round(float64(m.Amount)*percent/100)), m.Currency)
+}
+
+// Share returns the fraction part/whole of m, rounded towards zero to the
+// minor unit. Rounding down means shares of an amount never add up to more
+// than the amount.
+func (m Money) Share(part, whole int64) Money {
+	if whole == 0 {
+		return New(0, m.Currency)
+	}
+	share := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(part))
+	share.Quo(share, big.NewInt(whole))
+	return New(share.Int64(), m.Currency)
+}
+
+// Compare returns -1, 0 or 1 depending on whether m is less than, equal to or
+// greater than other
+func (m Money) Compare(other Money) (int, error) {
+	if _, err := m.commonCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// String formats the amount in major units followed by the currency, e.g. "12.30 USD"
+func (m Money) String() string {
+	units := MinorUnits(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	value := strconv.FormatInt(amount, 10)
+	if units > 0 {
+		if len(value) <= units {
+			value = strings.Repeat("0", units-len(value)+1) + value
+		}
+		value = value[:len(value)-units] + "." + value[len(value)-units:]
+	}
+
+	if m.Currency == "" {
+		return sign + value
+	}
+	return sign + value + " " + m.Currency
+}
+
+func (m Money) commonCurrency(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.Amount == 0:
+		return other.Currency, nil
+	case other.Currency == "" && other.Amount == 0:
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+}
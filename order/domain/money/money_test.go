@@ -0,0 +1,49 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMajor(t *testing.T) {
+	assert.Equal(t, New(1999, "USD"), FromMajor(19.99, "USD"))
+	assert.Equal(t, New(30, "USD"), FromMajor(0.1+0.2, "USD"))
+	assert.Equal(t, New(1500, "JPY"), FromMajor(1500, "JPY"))
+	assert.Equal(t, New(1235, "KWD"), FromMajor(1.2345, "KWD"))
+}
+
+func TestArithmetic(t *testing.T) {
+	total, err := Money{}.Add(New(1050, "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, New(1050, "USD"), total, "the zero value adopts the other currency")
+
+	total, err = total.Sub(New(50, "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, New(1000, "USD"), total)
+
+	_, err = total.Add(New(1, "EUR"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	assert.Equal(t, New(3000, "USD"), total.Multiply(3))
+	assert.Equal(t, New(125, "USD"), New(833, "USD").Percent(15))
+	assert.Equal(t, New(666, "USD"), New(1000, "USD").Share(2, 3), "shares round down")
+	assert.Equal(t, New(0, "USD"), New(1000, "USD").Share(1, 0))
+
+	cmp, err := total.Compare(New(999, "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "12.30 USD", New(1230, "USD").String())
+	assert.Equal(t, "0.05 USD", New(5, "USD").String())
+	assert.Equal(t, "-1.00 USD", New(-100, "USD").String())
+	assert.Equal(t, "1500 JPY", New(1500, "JPY").String())
+}
+
+func TestValidateCurrency(t *testing.T) {
+	assert.NoError(t, ValidateCurrency("USD"))
+	assert.ErrorIs(t, ValidateCurrency("usd"), ErrInvalidCurrency)
+	assert.ErrorIs(t, ValidateCurrency("US"), ErrInvalidCurrency)
+}
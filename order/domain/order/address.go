@@ -0,0 +1,17 @@
+package order
+
+// ShippingAddress is the address an order is delivered to, copied from the
+// customer's address book when the order is placed. Later edits to the
+// address book do not change it.
+type ShippingAddress struct {
+	AddressID     string `json:"addressId,omitempty" bson:"address_id,omitempty"`
+	Label         string `json:"label,omitempty" bson:"label,omitempty"`
+	RecipientName string `json:"recipientName,omitempty" bson:"recipient_name,omitempty"`
+	Phone         string `json:"phone,omitempty" bson:"phone,omitempty"`
+	Line1         string `json:"line1,omitempty" bson:"line1,omitempty"`
+	Line2         string `json:"line2,omitempty" bson:"line2,omitempty"`
+	City          string `json:"city,omitempty" bson:"city,omitempty"`
+	Region        string `json:"region,omitempty" bson:"region,omitempty"`
+	PostalCode    string `json:"postalCode,omitempty" bson:"postal_code,omitempty"`
+	CountryCode   string `json:"countryCode,omitempty" bson:"country_code,omitempty"`
+}
@@ -0,0 +1,65 @@
+package order
+
+import (
+	"fmt"
+	"time"
+)
+
+// CancelReason is the reason code recorded when an order is cancelled
+type CancelReason string
+
+const (
+	CancelReasonCustomerRequest CancelReason = "customer_request"
+	CancelReasonOutOfStock      CancelReason = "out_of_stock"
+	CancelReasonPaymentFailed   CancelReason = "payment_failed"
+	CancelReasonFraudSuspected  CancelReason = "fraud_suspected"
+	CancelReasonDuplicateOrder  CancelReason = "duplicate_order"
+	CancelReasonOther           CancelReason = "other"
+)
+
+func (r CancelReason) IsValid() bool {
+	switch r {
+	case CancelReasonCustomerRequest, CancelReasonOutOfStock, CancelReasonPaymentFailed,
+		CancelReasonFraudSuspected, CancelReasonDuplicateOrder, CancelReasonOther:
+		return true
+	}
+	return false
+}
+
+// Cancellation records why and when an order was cancelled
+type Cancellation struct {
+	Reason      CancelReason `json:"reason,omitempty" bson:"reason,omitempty"`
+	Note        string       `json:"note,omitempty" bson:"note,omitempty"`
+	CancelledBy string       `json:"cancelledBy,omitempty" bson:"cancelled_by,omitempty"`
+	CancelledAt time.Time    `json:"cancelledAt,omitempty" bson:"cancelled_at,omitempty"`
+
+	// RefundDue flags a cancelled order whose payment is still to be refunded.
+	// It is cleared once the refund is recorded.
+	RefundDue bool `json:"refundDue,omitempty" bson:"refund_due,omitempty"`
+}
+
+// IsCancellable reports whether an order in the status may still be cancelled
+func (s OrderStatus) IsCancellable() bool {
+	return s.CanTransitionTo(StatusCancelled)
+}
+
+// Cancel moves the order to cancelled and records the reason. Orders can only
+// be cancelled until they are shipped.
+func (o *Order) Cancel(reason CancelReason, note string, actor string, at time.Time) error {
+	if !reason.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidCancelReason, reason)
+	}
+	if !o.Status.IsCancellable() {
+		return fmt.Errorf("%w: order is %s", ErrOrderNotCancellable, o.Status)
+	}
+
+	o.Status = StatusCancelled
+	o.Cancellation = &Cancellation{
+		Reason:      reason,
+		Note:        note,
+		CancelledBy: actor,
+		CancelledAt: at,
+	}
+	o.UpdatedAt = at
+	return nil
+}
@@ -0,0 +1,26 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancel(t *testing.T) {
+	now := time.Now()
+
+	order := &Order{Status: StatusPaid}
+	assert.NoError(t, order.Cancel(CancelReasonCustomerRequest, "changed my mind", "user-1", now))
+	assert.Equal(t, StatusCancelled, order.Status)
+	assert.Equal(t, CancelReasonCustomerRequest, order.Cancellation.Reason)
+	assert.Equal(t, now, order.UpdatedAt)
+
+	shipped := &Order{Status: StatusShipped}
+	assert.ErrorIs(t, shipped.Cancel(CancelReasonOther, "", "admin", now), ErrOrderNotCancellable)
+	assert.Nil(t, shipped.Cancellation)
+
+	pending := &Order{Status: StatusPending}
+	assert.ErrorIs(t, pending.Cancel("bored", "", "user-1", now), ErrInvalidCancelReason)
+	assert.Equal(t, StatusPending, pending.Status)
+}
@@ -3,6 +3,7 @@ package order
 import (
 	"time"
 
+	"github.com/DuongVu089x/interview/order/domain/money"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -12,21 +13,36 @@ type Order struct {
 	OrderCode   string              `json:"orderCode,omitempty" bson:"order_code,omitempty"`
 	UserID      string              `json:"userId,omitempty" bson:"user_id,omitempty"`
 	Items       []OrderItem         `json:"items,omitempty" bson:"items,omitempty"`
-	TotalAmount float64             `json:"totalAmount,omitempty" bson:"total_amount,omitempty"`
+	Subtotal    money.Money         `json:"subtotal" bson:"subtotal,omitempty"`
+	Discount    money.Money         `json:"discount" bson:"discount,omitempty"`
+	VoucherCode string              `json:"voucherCode,omitempty" bson:"voucher_code,omitempty"`
+	TotalAmount money.Money         `json:"totalAmount" bson:"total_amount,omitempty"`
 	Status      OrderStatus         `json:"status,omitempty" bson:"status,omitempty"`
 	CreatedAt   time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
-}
+	UpdatedAt   time.Time           `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
 
-type OrderItem struct {
-	ProductID string  `json:"productId,omitempty" bson:"product_id,omitempty"`
-	Quantity  int     `json:"quantity,omitempty" bson:"quantity,omitempty"`
-	Price     float64 `json:"price,omitempty" bson:"price,omitempty"`
+	// Version is incremented on every update and guards against lost updates
+	Version int64 `json:"version" bson:"version"`
+
+	// Cancellation is set once the order is cancelled
+	Cancellation *Cancellation `json:"cancellation,omitempty" bson:"cancellation,omitempty"`
+
+	// RefundedAmount is the part of TotalAmount returned to the customer
+	RefundedAmount money.Money `json:"refundedAmount" bson:"refunded_amount,omitempty"`
+
+	// ShippingAddress is nil for orders placed by users with no address
+	ShippingAddress *ShippingAddress `json:"shippingAddress,omitempty" bson:"shipping_address,omitempty"`
+
+	// Risk is the fraud risk assessment made when the order was placed
+	Risk *Risk `json:"risk,omitempty" bson:"risk,omitempty"`
 }
 
-type OrderStatus string
+type OrderItem struct {
+	ProductID string      `json:"productId,omitempty" bson:"product_id,omitempty"`
+	Name      string      `json:"name,omitempty" bson:"name,omitempty"`
+	Quantity  int         `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Price     money.Money `json:"price" bson:"price,omitempty"`
 
-const (
-	StatusPending OrderStatus = "pending"
-	StatusPaid    OrderStatus = "paid"
-	StatusShipped OrderStatus = "shipped"
-)
+	// RefundedQuantity is how many of Quantity were refunded
+	RefundedQuantity int `json:"refundedQuantity,omitempty" bson:"refunded_quantity,omitempty"`
+}
@@ -0,0 +1,40 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrCustomerNotFound        = errors.New("customer not found")
+	ErrInvalidOrderCode        = errors.New("invalid order code")
+	ErrInvalidStatus           = errors.New("invalid order status")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrInvalidFilter           = errors.New("invalid order filter")
+	ErrInvalidCancelReason     = errors.New("invalid cancel reason")
+	ErrOrderNotCancellable     = errors.New("order can no longer be cancelled")
+	ErrOrderNotRefundable      = errors.New("order cannot be refunded")
+	ErrInvalidRefund           = errors.New("invalid refund")
+	ErrRefundExceedsTotal      = errors.New("refund exceeds the order total")
+	ErrNothingToReorder        = errors.New("no item of the order can be ordered again")
+	ErrAddressNotFound         = errors.New("shipping address not found")
+	ErrOrderNotOnHold          = errors.New("order is not on hold for review")
+	ErrVersionConflict         = errors.New("order was modified concurrently")
+	ErrVersionMismatch         = errors.New("order version does not match")
+)
+
+// VersionConflictError is returned when an order is updated from a version
+// that is no longer the stored one. It matches ErrVersionConflict.
+type VersionConflictError struct {
+	OrderID int64
+	Version int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%v: order %d is no longer at version %d", ErrVersionConflict, e.OrderID, e.Version)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
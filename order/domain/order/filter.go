@@ -0,0 +1,114 @@
+package order
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// SortField is the order attribute a listing is sorted on. Ties are broken by
+// order ID so that every position in a listing is unique.
+type SortField string
+
+const (
+	SortByCreatedAt   SortField = "created_at"
+	SortByTotalAmount SortField = "total_amount"
+)
+
+func (f SortField) IsValid() bool {
+	return f == SortByCreatedAt || f == SortByTotalAmount
+}
+
+// Filter narrows down and pages through an order listing
+type Filter struct {
+	UserID   string
+	Statuses []OrderStatus
+
+	// CreatedFrom and CreatedTo bound the creation time, zero means unbounded
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// PendingBefore keeps orders waiting for payment since before this time:
+	// created before it, or approved before it if they were held for review.
+	// Zero means unbounded.
+	PendingBefore time.Time
+
+	// MinAmount and MaxAmount bound the total amount, nil means unbounded
+	MinAmount *money.Money
+	MaxAmount *money.Money
+
+	SortBy    SortField
+	Ascending bool
+
+	// After continues the listing past the position it points at
+	After *Cursor
+	// Limit is the maximum number of orders returned, 0 means no limit
+	Limit int
+}
+
+// Cursor is the position of an order in a listing. It remembers the sort it
+// was taken from because it is meaningless under any other.
+type Cursor struct {
+	SortBy      SortField `json:"s"`
+	Ascending   bool      `json:"asc,omitempty"`
+	CreatedAt   time.Time `json:"c,omitempty"`
+	TotalAmount int64     `json:"a,omitempty"`
+	OrderID     int64     `json:"o"`
+}
+
+// CursorOf returns the position of order in a listing sorted as filter is
+func CursorOf(order *Order, filter Filter) *Cursor {
+	cursor := &Cursor{
+		SortBy:    filter.SortBy,
+		Ascending: filter.Ascending,
+		OrderID:   order.OrderID,
+	}
+	if filter.SortBy == SortByTotalAmount {
+		cursor.TotalAmount = order.TotalAmount.Amount
+	} else {
+		cursor.CreatedAt = order.CreatedAt
+	}
+	return cursor
+}
+
+// Validate checks the filter and fills in the default sort
+func (f *Filter) Validate() error {
+	if f.SortBy == "" {
+		f.SortBy = SortByCreatedAt
+	}
+	if !f.SortBy.IsValid() {
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidFilter, f.SortBy)
+	}
+	for _, status := range f.Statuses {
+		if !status.IsValid() {
+			return fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+		}
+	}
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && f.CreatedTo.Before(f.CreatedFrom) {
+		return fmt.Errorf("%w: createdTo is before createdFrom", ErrInvalidFilter)
+	}
+	for _, amount := range []*money.Money{f.MinAmount, f.MaxAmount} {
+		if amount == nil {
+			continue
+		}
+		if err := money.ValidateCurrency(amount.Currency); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+		}
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil {
+		cmp, err := f.MinAmount.Compare(*f.MaxAmount)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+		}
+		if cmp > 0 {
+			return fmt.Errorf("%w: minAmount is greater than maxAmount", ErrInvalidFilter)
+		}
+	}
+	if f.After != nil && (f.After.SortBy != f.SortBy || f.After.Ascending != f.Ascending) {
+		return fmt.Errorf("%w: cursor belongs to a different sort", ErrInvalidFilter)
+	}
+	if f.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", ErrInvalidFilter)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterValidate(t *testing.T) {
+	filter := Filter{}
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, SortByCreatedAt, filter.SortBy, "sorts by creation time by default")
+
+	assert.ErrorIs(t, (&Filter{SortBy: "name"}).Validate(), ErrInvalidFilter)
+	assert.ErrorIs(t, (&Filter{Statuses: []OrderStatus{"lost"}}).Validate(), ErrInvalidStatus)
+
+	now := time.Now()
+	assert.ErrorIs(t, (&Filter{CreatedFrom: now, CreatedTo: now.Add(-time.Hour)}).Validate(), ErrInvalidFilter)
+
+	minAmount, maxAmount := money.New(500, "USD"), money.New(100, "USD")
+	assert.ErrorIs(t, (&Filter{MinAmount: &minAmount, MaxAmount: &maxAmount}).Validate(), ErrInvalidFilter)
+
+	cursor := CursorOf(&Order{OrderID: 7, CreatedAt: now}, Filter{SortBy: SortByCreatedAt})
+	assert.NoError(t, (&Filter{After: cursor}).Validate())
+	assert.ErrorIs(t, (&Filter{SortBy: SortByTotalAmount, After: cursor}).Validate(), ErrInvalidFilter)
+}
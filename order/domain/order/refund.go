@@ -0,0 +1,140 @@
+package order
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// RefundLine is a quantity of an order line to refund. Amount is the share of
+// the order total it is worth, set when the refund is planned.
+type RefundLine struct {
+	ProductID string      `json:"productId,omitempty" bson:"product_id,omitempty"`
+	Quantity  int         `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Amount    money.Money `json:"amount" bson:"amount"`
+}
+
+// RefundPlan is the outcome of a refund computed by PlanRefund
+type RefundPlan struct {
+	Lines  []RefundLine
+	Amount money.Money
+	// Full is true when the plan refunds everything that is left of the order
+	Full bool
+}
+
+// IsRefundable reports whether money may be returned for an order in the
+// status. Cancelled and expired orders are refundable as their payment may
+// have been captured before or after they were closed.
+func (s OrderStatus) IsRefundable() bool {
+	return s == StatusCancelled || s == StatusExpired || s.CanTransitionTo(StatusRefunded)
+}
+
+// RefundableAmount returns the part of the order total not refunded yet
+func (o *Order) RefundableAmount() (money.Money, error) {
+	return o.TotalAmount.Sub(o.RefundedAmount)
+}
+
+// PlanRefund computes the refund of the given lines without changing the
+// order. No lines refunds everything not refunded yet. Lines are worth their
+// price times quantity, less their share of the order discount.
+func (o *Order) PlanRefund(lines []RefundLine) (*RefundPlan, error) {
+	if !o.Status.IsRefundable() {
+		return nil, fmt.Errorf("%w: order is %s", ErrOrderNotRefundable, o.Status)
+	}
+	remaining, err := o.RefundableAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		for _, item := range o.Items {
+			if left := item.Quantity - item.RefundedQuantity; left > 0 {
+				lines = append(lines, RefundLine{ProductID: item.ProductID, Quantity: left})
+			}
+		}
+	}
+
+	left := make(map[string]int, len(o.Items))
+	for _, item := range o.Items {
+		left[item.ProductID] += item.Quantity - item.RefundedQuantity
+	}
+
+	plan := &RefundPlan{Lines: make([]RefundLine, 0, len(lines))}
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of %s must be positive", ErrInvalidRefund, line.ProductID)
+		}
+		item := o.item(line.ProductID)
+		if item == nil {
+			return nil, fmt.Errorf("%w: product %s is not in the order", ErrInvalidRefund, line.ProductID)
+		}
+		if line.Quantity > left[line.ProductID] {
+			return nil, fmt.Errorf("%w: only %d of %s left to refund", ErrInvalidRefund, left[line.ProductID], line.ProductID)
+		}
+		left[line.ProductID] -= line.Quantity
+
+		line.Amount = item.Price.Multiply(line.Quantity).Share(o.TotalAmount.Amount, o.Subtotal.Amount)
+		if plan.Amount, err = plan.Amount.Add(line.Amount); err != nil {
+			return nil, err
+		}
+		plan.Lines = append(plan.Lines, line)
+	}
+
+	// Once every unit is refunded, the rounding left over by line shares is
+	// refunded too, so the order ends up refunded in full
+	plan.Full = true
+	for _, quantity := range left {
+		if quantity > 0 {
+			plan.Full = false
+		}
+	}
+	if plan.Full {
+		plan.Amount = remaining
+	}
+
+	if !plan.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: nothing left to refund", ErrInvalidRefund)
+	}
+	if cmp, err := plan.Amount.Compare(remaining); err != nil || cmp > 0 {
+		return nil, fmt.Errorf("%w: %s requested, %s left", ErrRefundExceedsTotal, plan.Amount, remaining)
+	}
+	return plan, nil
+}
+
+// ApplyRefund records a refund planned by PlanRefund. An order refunded in
+// full moves to refunded unless it was cancelled or expired.
+func (o *Order) ApplyRefund(plan *RefundPlan, at time.Time) error {
+	refunded, err := o.RefundedAmount.Add(plan.Amount)
+	if err != nil {
+		return err
+	}
+	o.RefundedAmount = refunded
+
+	for _, line := range plan.Lines {
+		quantity := line.Quantity
+		for i := range o.Items {
+			if o.Items[i].ProductID != line.ProductID || quantity == 0 {
+				continue
+			}
+			n := min(quantity, o.Items[i].Quantity-o.Items[i].RefundedQuantity)
+			o.Items[i].RefundedQuantity += n
+			quantity -= n
+		}
+	}
+
+	if plan.Full && o.Status.CanTransitionTo(StatusRefunded) {
+		o.Status = StatusRefunded
+	}
+	o.UpdatedAt = at
+	return nil
+}
+
+func (o *Order) item(productID string) *OrderItem {
+	for i := range o.Items {
+		if o.Items[i].ProductID == productID {
+			return &o.Items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRefundableOrder() *Order {
+	return &Order{
+		Status: StatusDelivered,
+		Items: []OrderItem{
+			{ProductID: "SKU-1", Quantity: 2, Price: money.New(1000, "USD")},
+			{ProductID: "SKU-2", Quantity: 1, Price: money.New(1000, "USD")},
+		},
+		Subtotal:    money.New(3000, "USD"),
+		Discount:    money.New(1000, "USD"),
+		TotalAmount: money.New(2000, "USD"),
+	}
+}
+
+func TestPartialRefund(t *testing.T) {
+	order := newRefundableOrder()
+
+	plan, err := order.PlanRefund([]RefundLine{{ProductID: "SKU-1", Quantity: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(666, "USD"), plan.Amount, "lines carry their share of the discount")
+	assert.False(t, plan.Full)
+
+	assert.NoError(t, order.ApplyRefund(plan, time.Now()))
+	assert.Equal(t, money.New(666, "USD"), order.RefundedAmount)
+	assert.Equal(t, 1, order.Items[0].RefundedQuantity)
+	assert.Equal(t, StatusDelivered, order.Status)
+
+	_, err = order.PlanRefund([]RefundLine{{ProductID: "SKU-1", Quantity: 2}})
+	assert.ErrorIs(t, err, ErrInvalidRefund)
+	_, err = order.PlanRefund([]RefundLine{{ProductID: "SKU-9", Quantity: 1}})
+	assert.ErrorIs(t, err, ErrInvalidRefund)
+
+	// Refunding the rest includes the rounding left over by the shares
+	plan, err = order.PlanRefund(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(1334, "USD"), plan.Amount)
+	assert.True(t, plan.Full)
+
+	assert.NoError(t, order.ApplyRefund(plan, time.Now()))
+	assert.Equal(t, order.TotalAmount, order.RefundedAmount)
+	assert.Equal(t, StatusRefunded, order.Status)
+
+	_, err = order.PlanRefund(nil)
+	assert.ErrorIs(t, err, ErrOrderNotRefundable)
+}
+
+func TestRefundCancelledOrder(t *testing.T) {
+	order := newRefundableOrder()
+	order.Status = StatusCancelled
+
+	plan, err := order.PlanRefund(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, order.ApplyRefund(plan, time.Now()))
+	assert.Equal(t, order.TotalAmount, order.RefundedAmount)
+	assert.Equal(t, StatusCancelled, order.Status)
+
+	_, err = order.PlanRefund(nil)
+	assert.ErrorIs(t, err, ErrInvalidRefund)
+
+	pending := newRefundableOrder()
+	pending.Status = StatusPending
+	_, err = pending.PlanRefund(nil)
+	assert.ErrorIs(t, err, ErrOrderNotRefundable)
+}
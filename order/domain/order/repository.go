@@ -1,10 +1,18 @@
 package order
 
+import "context"
+
 type Repository interface {
-	GetOrder(id int64) (*Order, error)
-	GetOrders(conditions Order) ([]Order, error)
+	GetOrder(ctx context.Context, id int64) (*Order, error)
+	GetOrderByCode(ctx context.Context, code string) (*Order, error)
+	GetOrders(ctx context.Context, filter Filter) ([]Order, error)
+
+	CreateOrder(ctx context.Context, order *Order) error
+	// GetOrderForUpdate reads an order from the primary, for read-modify-write cycles
+	GetOrderForUpdate(ctx context.Context, id int64) (*Order, error)
 
-	CreateOrder(order *Order) error
-	UpdateOrder(order *Order) error
-	DeleteOrder(id string) error
+	// UpdateOrder saves the order if it is still at order.Version and bumps the
+	// version, or returns a *VersionConflictError
+	UpdateOrder(ctx context.Context, order *Order) error
+	DeleteOrder(ctx context.Context, id int64) error
 }
@@ -0,0 +1,87 @@
+package order
+
+import (
+	"fmt"
+	"time"
+)
+
+// Risk is the fraud risk assessment of an order and, for an order held
+// because of it, the outcome of its review
+type Risk struct {
+	Score      int       `json:"score" bson:"score"`
+	Level      string    `json:"level,omitempty" bson:"level,omitempty"`
+	Signals    []string  `json:"signals,omitempty" bson:"signals,omitempty"`
+	AssessedAt time.Time `json:"assessedAt,omitempty" bson:"assessed_at,omitempty"`
+
+	// Decision, ReviewedBy, ReviewedAt and Note are set once a held order is reviewed
+	Decision   ReviewDecision `json:"decision,omitempty" bson:"decision,omitempty"`
+	ReviewedBy string         `json:"reviewedBy,omitempty" bson:"reviewed_by,omitempty"`
+	ReviewedAt time.Time      `json:"reviewedAt,omitempty" bson:"reviewed_at,omitempty"`
+	Note       string         `json:"note,omitempty" bson:"note,omitempty"`
+}
+
+// ReviewDecision is the outcome of the review of a held order
+type ReviewDecision string
+
+const (
+	ReviewApproved ReviewDecision = "approved"
+	ReviewRejected ReviewDecision = "rejected"
+)
+
+// Hold puts an order being placed on hold for review because of its risk
+func (o *Order) Hold(risk Risk) error {
+	if o.Status != StatusPending {
+		return fmt.Errorf("%w: only new orders can be held, order is %s", ErrInvalidStatusTransition, o.Status)
+	}
+	o.Status = StatusOnHold
+	o.Risk = &risk
+	return nil
+}
+
+// Approve releases a held order, which then waits for payment like any new order
+func (o *Order) Approve(actor, note string, at time.Time) error {
+	if err := o.review(); err != nil {
+		return err
+	}
+	if err := o.TransitionTo(StatusPending); err != nil {
+		return err
+	}
+	o.recordReview(ReviewApproved, actor, note, at)
+	return nil
+}
+
+// Reject cancels a held order as suspected fraud
+func (o *Order) Reject(actor, note string, at time.Time) error {
+	if err := o.review(); err != nil {
+		return err
+	}
+	if err := o.Cancel(CancelReasonFraudSuspected, note, actor, at); err != nil {
+		return err
+	}
+	o.recordReview(ReviewRejected, actor, note, at)
+	return nil
+}
+
+// PendingSince returns when the order started waiting for payment: when it
+// was created, or when it was approved if it was held
+func (o *Order) PendingSince() time.Time {
+	if o.Risk != nil && o.Risk.Decision == ReviewApproved && o.Risk.ReviewedAt.After(o.CreatedAt) {
+		return o.Risk.ReviewedAt
+	}
+	return o.CreatedAt
+}
+
+func (o *Order) review() error {
+	if o.Status != StatusOnHold || o.Risk == nil {
+		return fmt.Errorf("%w: order is %s", ErrOrderNotOnHold, o.Status)
+	}
+	return nil
+}
+
+func (o *Order) recordReview(decision ReviewDecision, actor, note string, at time.Time) {
+	o.Risk.Decision = decision
+	o.Risk.ReviewedBy = actor
+	o.Risk.ReviewedAt = at
+	o.Risk.Note = note
+	o.UpdatedAt = at
+}
@@ -0,0 +1,39 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproveHeldOrder(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	order := &Order{Status: StatusPending, CreatedAt: created}
+	assert.NoError(t, order.Hold(Risk{Score: 80, Level: "high"}))
+	assert.Equal(t, StatusOnHold, order.Status)
+	assert.Equal(t, created, order.PendingSince())
+
+	now := time.Now()
+	assert.NoError(t, order.Approve("admin", "known customer", now))
+	assert.Equal(t, StatusPending, order.Status)
+	assert.Equal(t, ReviewApproved, order.Risk.Decision)
+	assert.Equal(t, now, order.PendingSince())
+
+	assert.ErrorIs(t, order.Approve("admin", "", now), ErrOrderNotOnHold)
+}
+
+func TestRejectHeldOrder(t *testing.T) {
+	now := time.Now()
+	order := &Order{Status: StatusPending}
+	assert.NoError(t, order.Hold(Risk{Score: 90, Level: "high"}))
+
+	assert.NoError(t, order.Reject("admin", "stolen card", now))
+	assert.Equal(t, StatusCancelled, order.Status)
+	assert.Equal(t, CancelReasonFraudSuspected, order.Cancellation.Reason)
+	assert.Equal(t, ReviewRejected, order.Risk.Decision)
+
+	paid := &Order{Status: StatusPaid}
+	assert.ErrorIs(t, paid.Hold(Risk{}), ErrInvalidStatusTransition)
+	assert.ErrorIs(t, paid.Reject("admin", "", now), ErrOrderNotOnHold)
+}
@@ -1,17 +1,27 @@
 package order
 
+import (
+	"context"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
 // Service defines the business operations for orders
 type Service interface {
 	ValidateOrder(order *Order) error
-	CalculateTotal(items []OrderItem) float64
+	CalculateTotal(items []OrderItem) (money.Money, error)
 
 	// Calculate total of customer
-	CalculateTotalOfCustomer(customerID string, status OrderStatus) (float64, error)
+	CalculateTotalOfCustomer(ctx context.Context, customerID string, status OrderStatus) (money.Money, error)
 
-	GetOrderByCustomerID(customerID string, conditions map[string]any) ([]Order, error)
-	GetOrder(id int64) (*Order, error)
+	// GetOrders lists the orders matching the filter. When the filter has a
+	// limit and more orders follow, it also returns the cursor of the next page.
+	GetOrders(ctx context.Context, filter Filter) ([]Order, *Cursor, error)
+	GetOrder(ctx context.Context, id int64) (*Order, error)
+	GetOrderByCode(ctx context.Context, code string) (*Order, error)
+	GetOrderForUpdate(ctx context.Context, id int64) (*Order, error)
 
-	CreateOrder(order *Order) error
-	UpdateOrder(order *Order) error
-	DeleteOrder(id string) error
+	CreateOrder(ctx context.Context, order *Order) error
+	UpdateOrder(ctx context.Context, order *Order) error
+	DeleteOrder(ctx context.Context, id int64) error
 }
@@ -0,0 +1,76 @@
+package order
+
+import "fmt"
+
+type OrderStatus string
+
+const (
+	StatusPending    OrderStatus = "pending"
+	StatusOnHold     OrderStatus = "on_hold"
+	StatusPaid       OrderStatus = "paid"
+	StatusProcessing OrderStatus = "processing"
+	StatusShipped    OrderStatus = "shipped"
+	StatusDelivered  OrderStatus = "delivered"
+	StatusCancelled  OrderStatus = "cancelled"
+	StatusRefunded   OrderStatus = "refunded"
+	StatusExpired    OrderStatus = "expired"
+)
+
+// transitions lists, for every status, the statuses an order may move to next.
+// A status without outgoing transitions is terminal.
+var transitions = map[OrderStatus][]OrderStatus{
+	StatusPending:    {StatusPaid, StatusCancelled, StatusExpired},
+	StatusOnHold:     {StatusPending, StatusCancelled},
+	StatusPaid:       {StatusProcessing, StatusCancelled, StatusRefunded},
+	StatusProcessing: {StatusShipped, StatusCancelled, StatusRefunded},
+	StatusShipped:    {StatusDelivered, StatusRefunded},
+	StatusDelivered:  {StatusRefunded},
+	StatusCancelled:  {},
+	StatusRefunded:   {},
+	StatusExpired:    {},
+}
+
+// IsValid reports whether the status is known to the state machine
+func (s OrderStatus) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transition is possible from the status
+func (s OrderStatus) IsTerminal() bool {
+	return s.IsValid() && len(transitions[s]) == 0
+}
+
+// CanTransitionTo reports whether moving from s to next is allowed
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CanReach reports whether next can be reached from s through one or more
+// transitions. Statuses only move forward, so it also orders two statuses of
+// the same order.
+func (s OrderStatus) CanReach(next OrderStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next || allowed.CanReach(next) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the order to the next status if the state machine allows it
+func (o *Order) TransitionTo(next OrderStatus) error {
+	if !next.IsValid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, next)
+	}
+	if !o.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, o.Status, next)
+	}
+	o.Status = next
+	return nil
+}
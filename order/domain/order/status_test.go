@@ -0,0 +1,49 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionTo(t *testing.T) {
+	order := &Order{Status: StatusPending}
+
+	assert.NoError(t, order.TransitionTo(StatusPaid))
+	assert.NoError(t, order.TransitionTo(StatusProcessing))
+	assert.NoError(t, order.TransitionTo(StatusShipped))
+	assert.NoError(t, order.TransitionTo(StatusDelivered))
+	assert.NoError(t, order.TransitionTo(StatusRefunded))
+	assert.Equal(t, StatusRefunded, order.Status)
+}
+
+func TestTransitionToRejectsIllegalMoves(t *testing.T) {
+	order := &Order{Status: StatusPending}
+
+	err := order.TransitionTo(StatusShipped)
+	assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	assert.Equal(t, StatusPending, order.Status)
+
+	err = order.TransitionTo(OrderStatus("lost"))
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+
+	order.Status = StatusCancelled
+	assert.ErrorIs(t, order.TransitionTo(StatusPaid), ErrInvalidStatusTransition)
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.True(t, StatusCancelled.IsTerminal())
+	assert.True(t, StatusRefunded.IsTerminal())
+	assert.True(t, StatusExpired.IsTerminal())
+	assert.False(t, StatusPending.IsTerminal())
+	assert.False(t, OrderStatus("lost").IsTerminal())
+}
+
+func TestCanReach(t *testing.T) {
+	assert.True(t, StatusPending.CanReach(StatusPaid))
+	assert.True(t, StatusPending.CanReach(StatusShipped))
+	assert.True(t, StatusPaid.CanReach(StatusRefunded))
+	assert.False(t, StatusShipped.CanReach(StatusPaid))
+	assert.False(t, StatusPaid.CanReach(StatusPaid))
+	assert.False(t, StatusExpired.CanReach(StatusPaid))
+}
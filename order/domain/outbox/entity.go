@@ -0,0 +1,76 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Message is an event recorded in the same transaction as the state change it
+// describes, waiting to be relayed to the message broker
+type Message struct {
+	ID            *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	MessageID     string              `json:"messageId,omitempty" bson:"message_id,omitempty"`
+	Topic         string              `json:"topic,omitempty" bson:"topic,omitempty"`
+	Key           string              `json:"key,omitempty" bson:"key,omitempty"`
+	MessageCode   string              `json:"messageCode,omitempty" bson:"message_code,omitempty"`
+	Payload       string              `json:"payload,omitempty" bson:"payload,omitempty"`
+	Status        MessageStatus       `json:"status,omitempty" bson:"status,omitempty"`
+	Attempts      int                 `json:"attempts" bson:"attempts"`
+	LastError     string              `json:"lastError,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt time.Time           `json:"nextAttemptAt,omitempty" bson:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt     time.Time           `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+	SentAt        *time.Time          `json:"sentAt,omitempty" bson:"sent_at,omitempty"`
+}
+
+type MessageStatus string
+
+const (
+	StatusPending MessageStatus = "pending"
+	StatusSent    MessageStatus = "sent"
+	StatusFailed  MessageStatus = "failed"
+)
+
+// NewMessage builds a pending outbox message, serialising the payload up front
+// so it is stored exactly as it will be published
+func NewMessage(topic, key, messageCode, messageID string, payload any) (*Message, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	now := time.Now()
+	return &Message{
+		MessageID:     messageID,
+		Topic:         topic,
+		Key:           key,
+		MessageCode:   messageCode,
+		Payload:       string(body),
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// ToDomainMessage converts the outbox record into the message handed to the
+// producer. The timestamp is when the event was recorded, not when it is relayed.
+func (m *Message) ToDomainMessage(serviceID string) domain.Message {
+	return domain.Message{
+		Key:   m.Key,
+		Topic: m.Topic,
+		Value: domain.MessageValue{
+			Meta: &domain.MetaData{
+				MessageID: m.MessageID,
+				ServiceID: serviceID,
+				Timestamp: m.CreatedAt.UnixNano(),
+			},
+			MessageCode: m.MessageCode,
+			Payload:     json.RawMessage(m.Payload),
+		},
+	}
+}
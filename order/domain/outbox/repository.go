@@ -0,0 +1,23 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrNoPendingMessage = errors.New("no pending outbox message")
+
+type Repository interface {
+	CreateMessage(ctx context.Context, message *Message) error
+
+	// ClaimPending leases the oldest due pending message until the given time so
+	// that other relay instances skip it while it is being published
+	ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time) (*Message, error)
+
+	MarkAsSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error
+	ScheduleRetry(ctx context.Context, id primitive.ObjectID, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkAsFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string) error
+}
@@ -0,0 +1,100 @@
+package payment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type IntentStatus string
+
+const (
+	IntentRequiresPayment IntentStatus = "requires_payment"
+	IntentSucceeded       IntentStatus = "succeeded"
+	IntentFailed          IntentStatus = "failed"
+	IntentCancelled       IntentStatus = "cancelled"
+)
+
+// Intent is an attempt to collect the amount of an order through a payment
+// gateway. IntentID is the gateway's reference for it.
+type Intent struct {
+	ID            *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	IntentID      string              `json:"intentId,omitempty" bson:"intent_id,omitempty"`
+	OrderID       int64               `json:"orderId,omitempty" bson:"order_id,omitempty"`
+	UserID        string              `json:"userId,omitempty" bson:"user_id,omitempty"`
+	Provider      string              `json:"provider,omitempty" bson:"provider,omitempty"`
+	Amount        money.Money         `json:"amount" bson:"amount"`
+	Status        IntentStatus        `json:"status,omitempty" bson:"status,omitempty"`
+	ClientSecret  string              `json:"clientSecret,omitempty" bson:"client_secret,omitempty"`
+	FailureReason string              `json:"failureReason,omitempty" bson:"failure_reason,omitempty"`
+	// RefundDue flags a payment collected for an order that was closed
+	// meanwhile; it is cleared once the payment has been refunded
+	RefundDue bool      `json:"refundDue,omitempty" bson:"refund_due,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+// IsOpen reports whether the intent can still be paid
+func (i *Intent) IsOpen() bool {
+	return i.Status == IntentRequiresPayment
+}
+
+// Succeed records that the gateway collected the payment. It reports false when
+// the intent had already succeeded, so repeated callbacks are harmless.
+func (i *Intent) Succeed(at time.Time) (bool, error) {
+	if i.Status == IntentSucceeded {
+		return false, nil
+	}
+	if !i.IsOpen() {
+		return false, fmt.Errorf("%w: intent is %s", ErrInvalidIntentTransition, i.Status)
+	}
+	i.Status = IntentSucceeded
+	i.UpdatedAt = at
+	return true, nil
+}
+
+// Fail records that the gateway declined the payment
+func (i *Intent) Fail(reason string, at time.Time) (bool, error) {
+	if i.Status == IntentFailed {
+		return false, nil
+	}
+	if !i.IsOpen() {
+		return false, fmt.Errorf("%w: intent is %s", ErrInvalidIntentTransition, i.Status)
+	}
+	i.Status = IntentFailed
+	i.FailureReason = reason
+	i.UpdatedAt = at
+	return true, nil
+}
+
+// Cancel records that the intent was voided before it was paid
+func (i *Intent) Cancel(at time.Time) error {
+	if !i.IsOpen() {
+		return fmt.Errorf("%w: intent is %s", ErrInvalidIntentTransition, i.Status)
+	}
+	i.Status = IntentCancelled
+	i.UpdatedAt = at
+	return nil
+}
+
+type WebhookEventType string
+
+const (
+	WebhookPaymentSucceeded WebhookEventType = "payment.succeeded"
+	WebhookPaymentFailed    WebhookEventType = "payment.failed"
+)
+
+// WebhookEvent is a verified gateway callback. EventID is unique per provider
+// and is used to drop callbacks delivered more than once.
+type WebhookEvent struct {
+	ID            *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	EventID       string              `json:"eventId,omitempty" bson:"event_id,omitempty"`
+	Provider      string              `json:"provider,omitempty" bson:"provider,omitempty"`
+	Type          WebhookEventType    `json:"type,omitempty" bson:"type,omitempty"`
+	IntentID      string              `json:"intentId,omitempty" bson:"intent_id,omitempty"`
+	Amount        money.Money         `json:"amount" bson:"amount"`
+	FailureReason string              `json:"failureReason,omitempty" bson:"failure_reason,omitempty"`
+	ReceivedAt    time.Time           `json:"receivedAt,omitempty" bson:"received_at,omitempty"`
+}
@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntentTransitions(t *testing.T) {
+	now := time.Now()
+
+	intent := &Intent{Status: IntentRequiresPayment}
+	changed, err := intent.Succeed(now)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = intent.Succeed(now)
+	assert.NoError(t, err)
+	assert.False(t, changed, "a repeated success is a no-op")
+
+	_, err = intent.Fail("declined", now)
+	assert.ErrorIs(t, err, ErrInvalidIntentTransition)
+	assert.ErrorIs(t, intent.Cancel(now), ErrInvalidIntentTransition)
+
+	declined := &Intent{Status: IntentRequiresPayment}
+	changed, err = declined.Fail("card declined", now)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "card declined", declined.FailureReason)
+}
@@ -0,0 +1,15 @@
+package payment
+
+import "errors"
+
+var (
+	ErrIntentNotFound          = errors.New("payment intent not found")
+	ErrInvalidIntentTransition = errors.New("invalid payment intent transition")
+	ErrOrderNotPayable         = errors.New("order is not awaiting payment")
+	ErrInvalidSignature        = errors.New("invalid webhook signature")
+	ErrInvalidWebhook          = errors.New("invalid webhook payload")
+	ErrDuplicateWebhook        = errors.New("webhook already processed")
+	ErrAmountMismatch          = errors.New("paid amount does not match the payment intent")
+	ErrNoCapturedPayment       = errors.New("order has no captured payment to refund")
+	ErrRefundNotFound          = errors.New("refund not found")
+)
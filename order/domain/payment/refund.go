@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefundItem is a quantity of an order line covered by a refund
+type RefundItem struct {
+	ProductID string      `json:"productId,omitempty" bson:"product_id,omitempty"`
+	Quantity  int         `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Amount    money.Money `json:"amount" bson:"amount"`
+}
+
+// Refund is money returned to the customer against a captured payment
+// intent. RefundID is the provider's reference for it; IdempotencyKey makes a
+// retried refund return the first one instead of paying out twice.
+type Refund struct {
+	ID             *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	RefundID       string              `json:"refundId,omitempty" bson:"refund_id,omitempty"`
+	OrderID        int64               `json:"orderId,omitempty" bson:"order_id,omitempty"`
+	IntentID       string              `json:"intentId,omitempty" bson:"intent_id,omitempty"`
+	Provider       string              `json:"provider,omitempty" bson:"provider,omitempty"`
+	Amount         money.Money         `json:"amount" bson:"amount"`
+	Items          []RefundItem        `json:"items,omitempty" bson:"items,omitempty"`
+	Reason         string              `json:"reason,omitempty" bson:"reason,omitempty"`
+	RequestedBy    string              `json:"requestedBy,omitempty" bson:"requested_by,omitempty"`
+	IdempotencyKey string              `json:"idempotencyKey,omitempty" bson:"idempotency_key,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+}
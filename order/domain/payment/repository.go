@@ -0,0 +1,20 @@
+package payment
+
+import "context"
+
+type Repository interface {
+	CreateIntent(ctx context.Context, intent *Intent) error
+	// GetIntent reads from the primary, as intents are read to be updated
+	GetIntent(ctx context.Context, intentID string) (*Intent, error)
+	GetIntentsByOrder(ctx context.Context, orderID int64) ([]Intent, error)
+	UpdateIntent(ctx context.Context, intent *Intent) error
+
+	// RecordWebhookEvent stores a processed callback, or returns
+	// ErrDuplicateWebhook when the provider already delivered it
+	RecordWebhookEvent(ctx context.Context, event *WebhookEvent) error
+
+	CreateRefund(ctx context.Context, refund *Refund) error
+	// GetRefundByKey reads from the primary to detect retried refunds
+	GetRefundByKey(ctx context.Context, idempotencyKey string) (*Refund, error)
+	GetRefundsByOrder(ctx context.Context, orderID int64) ([]Refund, error)
+}
@@ -0,0 +1,24 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// Service defines the business operations for payments
+type Service interface {
+	CreateIntent(ctx context.Context, intent *Intent) error
+	GetIntent(ctx context.Context, intentID string) (*Intent, error)
+	GetIntentsByOrder(ctx context.Context, orderID int64) ([]Intent, error)
+	// GetOpenIntent returns the intent of the order that can still be paid, if any
+	GetOpenIntent(ctx context.Context, orderID int64) (*Intent, error)
+	UpdateIntent(ctx context.Context, intent *Intent) error
+	RecordWebhookEvent(ctx context.Context, event *WebhookEvent) error
+
+	CreateRefund(ctx context.Context, refund *Refund) error
+	GetRefundByKey(ctx context.Context, idempotencyKey string) (*Refund, error)
+	GetRefundsByOrder(ctx context.Context, orderID int64) ([]Refund, error)
+	// RefundedAmount returns how much of the intent was refunded so far
+	RefundedAmount(ctx context.Context, intent *Intent) (money.Money, error)
+}
@@ -0,0 +1,25 @@
+package product
+
+import (
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Product is an entry of the catalog. Orders and inventory refer to a product
+// by its SKU through their ProductID fields.
+type Product struct {
+	ID        *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	SKU       string              `json:"sku,omitempty" bson:"sku,omitempty"`
+	Name      string              `json:"name,omitempty" bson:"name,omitempty"`
+	Price     money.Money         `json:"price" bson:"price"`
+	Active    bool                `json:"active" bson:"active"`
+	CreatedAt time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time           `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+// Filter narrows down a product listing
+type Filter struct {
+	Active *bool
+}
@@ -0,0 +1,10 @@
+package product
+
+import "errors"
+
+var (
+	ErrProductNotFound      = errors.New("product not found")
+	ErrProductAlreadyExists = errors.New("product already exists")
+	ErrProductUnavailable   = errors.New("product is not available")
+	ErrInvalidProduct       = errors.New("invalid product")
+)
@@ -0,0 +1,13 @@
+package product
+
+import "context"
+
+type Repository interface {
+	GetProduct(ctx context.Context, sku string) (*Product, error)
+	GetProducts(ctx context.Context, filter Filter) ([]Product, error)
+	GetProductsBySKUs(ctx context.Context, skus []string) ([]Product, error)
+
+	CreateProduct(ctx context.Context, product *Product) error
+	UpdateProduct(ctx context.Context, product *Product) error
+	DeleteProduct(ctx context.Context, sku string) error
+}
@@ -0,0 +1,20 @@
+package product
+
+import "context"
+
+// Service defines the business operations for the product catalog
+type Service interface {
+	GetProduct(ctx context.Context, sku string) (*Product, error)
+	GetProducts(ctx context.Context, filter Filter) ([]Product, error)
+
+	// GetSellableProducts returns the active products with the given SKUs keyed by
+	// SKU, failing with ErrProductUnavailable if any of them is missing or inactive
+	GetSellableProducts(ctx context.Context, skus []string) (map[string]Product, error)
+	// GetProductsBySKUs returns the products with the given SKUs keyed by SKU,
+	// inactive ones included; unknown SKUs are left out
+	GetProductsBySKUs(ctx context.Context, skus []string) (map[string]Product, error)
+
+	CreateProduct(ctx context.Context, product *Product) error
+	UpdateProduct(ctx context.Context, product *Product) error
+	DeleteProduct(ctx context.Context, sku string) error
+}
@@ -0,0 +1,66 @@
+package risk
+
+import "time"
+
+// Level grades a risk score
+type Level string
+
+const (
+	LevelLow    Level = "low"
+	LevelMedium Level = "medium"
+	LevelHigh   Level = "high"
+
+	// MaxScore is the highest risk score
+	MaxScore = 100
+)
+
+// Signal is a reason an order looks suspicious and the score it adds
+type Signal struct {
+	Name   string
+	Score  int
+	Detail string
+}
+
+// Thresholds are the lowest scores of the medium and high levels
+type Thresholds struct {
+	Medium int
+	High   int
+}
+
+// Assessment is the fraud risk of an order
+type Assessment struct {
+	Score      int
+	Level      Level
+	Signals    []Signal
+	AssessedAt time.Time
+}
+
+// NewAssessment adds up the scores of the signals, capped at MaxScore, and
+// grades the total
+func NewAssessment(signals []Signal, thresholds Thresholds, at time.Time) Assessment {
+	score := 0
+	for _, signal := range signals {
+		score += signal.Score
+	}
+	score = min(score, MaxScore)
+
+	level := LevelLow
+	switch {
+	case score >= thresholds.High:
+		level = LevelHigh
+	case score >= thresholds.Medium:
+		level = LevelMedium
+	}
+
+	return Assessment{
+		Score:      score,
+		Level:      level,
+		Signals:    signals,
+		AssessedAt: at,
+	}
+}
+
+// IsHigh reports whether the order should be held for review
+func (a *Assessment) IsHigh() bool {
+	return a.Level == LevelHigh
+}
@@ -0,0 +1,25 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAssessment(t *testing.T) {
+	thresholds := Thresholds{Medium: 40, High: 70}
+	now := time.Now()
+
+	low := NewAssessment(nil, thresholds, now)
+	assert.Equal(t, 0, low.Score)
+	assert.Equal(t, LevelLow, low.Level)
+
+	medium := NewAssessment([]Signal{{Name: "velocity", Score: 40}}, thresholds, now)
+	assert.Equal(t, LevelMedium, medium.Level)
+	assert.False(t, medium.IsHigh())
+
+	high := NewAssessment([]Signal{{Name: "velocity", Score: 40}, {Name: "amount_outlier", Score: 40}, {Name: "new_account", Score: 30}}, thresholds, now)
+	assert.Equal(t, MaxScore, high.Score)
+	assert.True(t, high.IsHigh())
+}
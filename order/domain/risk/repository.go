@@ -0,0 +1,14 @@
+package risk
+
+import (
+	"context"
+	"time"
+)
+
+// VelocityRepository counts the recent orders of each customer
+type VelocityRepository interface {
+	// RecordOrder counts an order of the user and returns how many orders
+	// they placed within the window up to at, this one included. Recording
+	// the same order again does not count it twice.
+	RecordOrder(ctx context.Context, userID string, orderID int64, at time.Time, window time.Duration) (int64, error)
+}
@@ -0,0 +1,214 @@
+package rule
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultTier is the tier of customers who have none
+const DefaultTier = "standard"
+
+// Rule is a business rule every new order must pass. Rules are evaluated by
+// ascending priority.
+type Rule struct {
+	ID          *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	RuleID      string              `json:"ruleId,omitempty" bson:"rule_id,omitempty"`
+	Type        RuleType            `json:"type,omitempty" bson:"type,omitempty"`
+	Description string              `json:"description,omitempty" bson:"description,omitempty"`
+	Priority    int                 `json:"priority" bson:"priority"`
+	Enabled     bool                `json:"enabled" bson:"enabled"`
+
+	// Tiers limits the rule to customers of these tiers, empty means every customer
+	Tiers []string `json:"tiers,omitempty" bson:"tiers,omitempty"`
+
+	// ProductIDs are the products of blocked product rules, and the products
+	// capped by max quantity rules, empty meaning every product
+	ProductIDs []string `json:"productIds,omitempty" bson:"product_ids,omitempty"`
+	// MaxQuantity is the most units of one product an order may hold
+	MaxQuantity int `json:"maxQuantity,omitempty" bson:"max_quantity,omitempty"`
+	// Amount is the highest total of max order value rules and the lowest
+	// subtotal of minimum basket rules
+	Amount money.Money `json:"amount" bson:"amount,omitempty"`
+	// MinItems is the fewest units a minimum basket rule accepts
+	MinItems int `json:"minItems,omitempty" bson:"min_items,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+type RuleType string
+
+const (
+	TypeMaxQuantityPerSKU RuleType = "max_quantity_per_sku"
+	TypeMaxOrderValue     RuleType = "max_order_value"
+	TypeBlockedProducts   RuleType = "blocked_products"
+	TypeMinBasket         RuleType = "min_basket"
+)
+
+// Order is what rules are evaluated against
+type Order struct {
+	UserID   string
+	Tier     string
+	Items    []Item
+	Subtotal money.Money
+	Total    money.Money
+}
+
+// Item is a line of the order being evaluated
+type Item struct {
+	ProductID string
+	Quantity  int
+}
+
+// Violation is a rule an order breaks. Field names the part of the order at
+// fault, as it is named in order requests.
+type Violation struct {
+	RuleID  string   `json:"ruleId"`
+	Type    RuleType `json:"type"`
+	Field   string   `json:"field"`
+	Message string   `json:"message"`
+}
+
+// Validate checks the definition of the rule itself
+func (r *Rule) Validate() error {
+	if r.RuleID == "" {
+		return fmt.Errorf("%w: rule ID is required", ErrInvalidRule)
+	}
+	switch r.Type {
+	case TypeMaxQuantityPerSKU:
+		if r.MaxQuantity <= 0 {
+			return fmt.Errorf("%w: max quantity must be greater than 0", ErrInvalidRule)
+		}
+	case TypeMaxOrderValue:
+		if !r.Amount.IsPositive() {
+			return fmt.Errorf("%w: amount must be greater than 0", ErrInvalidRule)
+		}
+	case TypeBlockedProducts:
+		if len(r.ProductIDs) == 0 {
+			return fmt.Errorf("%w: blocked products are required", ErrInvalidRule)
+		}
+	case TypeMinBasket:
+		if !r.Amount.IsPositive() && r.MinItems <= 0 {
+			return fmt.Errorf("%w: a minimum amount or number of items is required", ErrInvalidRule)
+		}
+		if r.MinItems < 0 {
+			return fmt.Errorf("%w: minimum items must not be negative", ErrInvalidRule)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidRule, r.Type)
+	}
+	if r.Amount.IsNegative() {
+		return fmt.Errorf("%w: amount must not be negative", ErrInvalidRule)
+	}
+	if !r.Amount.IsZero() {
+		if err := money.ValidateCurrency(r.Amount.Currency); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+	return nil
+}
+
+// AppliesTo reports whether the rule is enabled for customers of the tier
+func (r *Rule) AppliesTo(tier string) bool {
+	if !r.Enabled {
+		return false
+	}
+	if tier == "" {
+		tier = DefaultTier
+	}
+	return len(r.Tiers) == 0 || slices.Contains(r.Tiers, tier)
+}
+
+// Check returns the violations of the rule by the order. Amounts are only
+// compared in the currency of the rule, so a rule does not apply to orders in
+// another currency.
+func (r *Rule) Check(order Order) []Violation {
+	var violations []Violation
+	violate := func(field, format string, args ...any) {
+		violations = append(violations, Violation{
+			RuleID:  r.RuleID,
+			Type:    r.Type,
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	switch r.Type {
+	case TypeMaxQuantityPerSKU:
+		// Lines of the same product count together, reported on the first one
+		quantities := make(map[string]int)
+		first := make(map[string]int)
+		for i, item := range order.Items {
+			if _, ok := first[item.ProductID]; !ok {
+				first[item.ProductID] = i
+			}
+			quantities[item.ProductID] += item.Quantity
+		}
+		for i, item := range order.Items {
+			if first[item.ProductID] != i || !r.covers(item.ProductID) {
+				continue
+			}
+			if quantities[item.ProductID] > r.MaxQuantity {
+				violate(fmt.Sprintf("items[%d].quantity", i), "at most %d units of product %s can be ordered", r.MaxQuantity, item.ProductID)
+			}
+		}
+
+	case TypeMaxOrderValue:
+		if cmp, err := order.Total.Compare(r.Amount); err == nil && cmp > 0 {
+			violate("totalAmount", "order total must not exceed %s", r.Amount)
+		}
+
+	case TypeBlockedProducts:
+		for i, item := range order.Items {
+			if slices.Contains(r.ProductIDs, item.ProductID) {
+				violate(fmt.Sprintf("items[%d].productId", i), "product %s cannot be ordered", item.ProductID)
+			}
+		}
+
+	case TypeMinBasket:
+		if r.Amount.IsPositive() {
+			if cmp, err := order.Subtotal.Compare(r.Amount); err == nil && cmp < 0 {
+				violate("subtotal", "order subtotal must be at least %s", r.Amount)
+			}
+		}
+		if r.MinItems > 0 {
+			units := 0
+			for _, item := range order.Items {
+				units += item.Quantity
+			}
+			if units < r.MinItems {
+				violate("items", "order must hold at least %d items", r.MinItems)
+			}
+		}
+	}
+	return violations
+}
+
+func (r *Rule) covers(productID string) bool {
+	return len(r.ProductIDs) == 0 || slices.Contains(r.ProductIDs, productID)
+}
+
+// Evaluate checks the order against every rule that applies to its customer,
+// by ascending priority, and returns all the violations found
+func Evaluate(rules []Rule, order Order) []Violation {
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b Rule) int {
+		if a.Priority != b.Priority {
+			return a.Priority - b.Priority
+		}
+		return strings.Compare(a.RuleID, b.RuleID)
+	})
+
+	var violations []Violation
+	for _, rule := range sorted {
+		if rule.AppliesTo(order.Tier) {
+			violations = append(violations, rule.Check(order)...)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,79 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func TestEvaluateReportsEveryViolationByPriority(t *testing.T) {
+	rules := []Rule{
+		{RuleID: "min-basket", Type: TypeMinBasket, Priority: 20, Enabled: true, Amount: usd(10000)},
+		{RuleID: "blocked", Type: TypeBlockedProducts, Priority: 10, Enabled: true, ProductIDs: []string{"p2"}},
+		{RuleID: "max-qty", Type: TypeMaxQuantityPerSKU, Priority: 10, Enabled: true, MaxQuantity: 5},
+	}
+	order := Order{
+		Items:    []Item{{ProductID: "p1", Quantity: 4}, {ProductID: "p2", Quantity: 1}, {ProductID: "p1", Quantity: 2}},
+		Subtotal: usd(5000),
+		Total:    usd(5000),
+	}
+
+	violations := Evaluate(rules, order)
+
+	assert.Len(t, violations, 3)
+	assert.Equal(t, "blocked", violations[0].RuleID)
+	assert.Equal(t, "items[1].productId", violations[0].Field)
+	assert.Equal(t, "max-qty", violations[1].RuleID)
+	assert.Equal(t, "items[0].quantity", violations[1].Field)
+	assert.Equal(t, "min-basket", violations[2].RuleID)
+	assert.Equal(t, "subtotal", violations[2].Field)
+}
+
+func TestEvaluateSkipsDisabledRulesAndOtherTiers(t *testing.T) {
+	rules := []Rule{
+		{RuleID: "off", Type: TypeMaxOrderValue, Enabled: false, Amount: usd(100)},
+		{RuleID: "gold", Type: TypeMaxOrderValue, Enabled: true, Tiers: []string{"gold"}, Amount: usd(100)},
+		{RuleID: "standard", Type: TypeMaxOrderValue, Enabled: true, Tiers: []string{DefaultTier}, Amount: usd(1000)},
+	}
+	order := Order{Items: []Item{{ProductID: "p1", Quantity: 1}}, Subtotal: usd(500), Total: usd(500)}
+
+	assert.Empty(t, Evaluate(rules, order))
+
+	order.Total = usd(2000)
+	violations := Evaluate(rules, order)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "standard", violations[0].RuleID)
+
+	// Amounts in another currency do not apply
+	order.Total = money.New(2000, "EUR")
+	assert.Empty(t, Evaluate(rules, order))
+}
+
+func TestCheckReturnsValidationError(t *testing.T) {
+	rules := []Rule{{RuleID: "min-items", Type: TypeMinBasket, Enabled: true, MinItems: 3}}
+
+	err := Check(rules, Order{Items: []Item{{ProductID: "p1", Quantity: 2}}})
+
+	assert.ErrorIs(t, err, ErrRulesViolated)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "items", validationErr.Violations[0].Field)
+
+	assert.NoError(t, Check(rules, Order{Items: []Item{{ProductID: "p1", Quantity: 3}}}))
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Rule{RuleID: "r", Type: TypeMaxQuantityPerSKU, MaxQuantity: 10}).Validate())
+	assert.NoError(t, (&Rule{RuleID: "r", Type: TypeMinBasket, MinItems: 2}).Validate())
+
+	assert.ErrorIs(t, (&Rule{Type: TypeMinBasket, MinItems: 2}).Validate(), ErrInvalidRule)
+	assert.ErrorIs(t, (&Rule{RuleID: "r", Type: TypeMaxOrderValue}).Validate(), ErrInvalidRule)
+	assert.ErrorIs(t, (&Rule{RuleID: "r", Type: TypeBlockedProducts}).Validate(), ErrInvalidRule)
+	assert.ErrorIs(t, (&Rule{RuleID: "r", Type: TypeMinBasket}).Validate(), ErrInvalidRule)
+	assert.ErrorIs(t, (&Rule{RuleID: "r", Type: "max_weight"}).Validate(), ErrInvalidRule)
+}
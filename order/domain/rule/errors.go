@@ -0,0 +1,40 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrRuleNotFound  = errors.New("order rule not found")
+	ErrInvalidRule   = errors.New("invalid order rule")
+	ErrRulesViolated = errors.New("order violates order rules")
+)
+
+// ValidationError is returned when an order breaks order rules. It lists every
+// violation and matches ErrRulesViolated.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return fmt.Sprintf("%v: %s", ErrRulesViolated, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrRulesViolated
+}
+
+// Check returns a *ValidationError listing the violations of the rules by the
+// order, or nil when it breaks none
+func Check(rules []Rule, order Order) error {
+	if violations := Evaluate(rules, order); len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
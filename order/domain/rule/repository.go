@@ -0,0 +1,12 @@
+package rule
+
+import "context"
+
+type Repository interface {
+	GetRule(ctx context.Context, ruleID string) (*Rule, error)
+	GetRules(ctx context.Context) ([]Rule, error)
+
+	// SaveRule creates the rule or replaces the rule with the same ID
+	SaveRule(ctx context.Context, rule *Rule) error
+	DeleteRule(ctx context.Context, ruleID string) error
+}
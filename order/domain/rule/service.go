@@ -0,0 +1,14 @@
+package rule
+
+import "context"
+
+// Service defines the business operations for order rules
+type Service interface {
+	GetRule(ctx context.Context, ruleID string) (*Rule, error)
+	GetRules(ctx context.Context) ([]Rule, error)
+
+	// SaveRule validates the rule, then creates it or replaces the rule with
+	// the same ID
+	SaveRule(ctx context.Context, rule *Rule) error
+	DeleteRule(ctx context.Context, ruleID string) error
+}
@@ -0,0 +1,170 @@
+package saga
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Status string
+
+const (
+	StatusRunning      Status = "running"
+	StatusCompensating Status = "compensating"
+	StatusCompleted    Status = "completed"
+	StatusCompensated  Status = "compensated"
+	// StatusFailed means compensations kept failing and an operator has to
+	// finish undoing the saga
+	StatusFailed Status = "failed"
+)
+
+// IsValid reports whether the status is known
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusRunning, StatusCompensating, StatusCompleted, StatusCompensated, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// IsFinished reports whether the orchestrator has nothing left to do
+func (s Status) IsFinished() bool {
+	return s == StatusCompleted || s == StatusCompensated || s == StatusFailed
+}
+
+type StepStatus string
+
+const (
+	StepPending StepStatus = "pending"
+	// StepRunning is a step whose action started but did not report back; it is
+	// retried on resume, so actions must be idempotent
+	StepRunning     StepStatus = "running"
+	StepDone        StepStatus = "done"
+	StepFailed      StepStatus = "failed"
+	StepCompensated StepStatus = "compensated"
+)
+
+// Saga is a multi-step operation that is either carried out completely or
+// undone by running the compensations of its steps in reverse. It is saved
+// after every step so that another instance can resume it.
+type Saga struct {
+	ID     *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	SagaID string              `json:"sagaId,omitempty" bson:"saga_id,omitempty"`
+	Type   string              `json:"type,omitempty" bson:"type,omitempty"`
+	Status Status              `json:"status,omitempty" bson:"status,omitempty"`
+	Steps  []Step              `json:"steps,omitempty" bson:"steps,omitempty"`
+
+	// Data holds the JSON encoded values the steps share, by key
+	Data map[string]string `json:"data,omitempty" bson:"data,omitempty"`
+
+	// Error is why the saga is being compensated
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+	// CompensationAttempts counts the runs of the compensations that failed
+	CompensationAttempts int `json:"compensationAttempts,omitempty" bson:"compensation_attempts,omitempty"`
+
+	// LeaseUntil is when the instance running the saga gives it up if it has
+	// not saved it again, letting another instance resume it
+	LeaseUntil time.Time `json:"leaseUntil,omitempty" bson:"lease_until,omitempty"`
+	Version    int64     `json:"version" bson:"version"`
+	CreatedAt  time.Time `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+// Step is the progress of one step of a saga
+type Step struct {
+	Name       string     `json:"name,omitempty" bson:"name,omitempty"`
+	Status     StepStatus `json:"status,omitempty" bson:"status,omitempty"`
+	Error      string     `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt  *time.Time `json:"startedAt,omitempty" bson:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty" bson:"finished_at,omitempty"`
+}
+
+// NewSaga creates a running saga with the given steps pending
+func NewSaga(sagaID, sagaType string, stepNames []string, data map[string]string, now time.Time) *Saga {
+	steps := make([]Step, len(stepNames))
+	for i, name := range stepNames {
+		steps[i] = Step{Name: name, Status: StepPending}
+	}
+	if data == nil {
+		data = make(map[string]string)
+	}
+
+	return &Saga{
+		SagaID:    sagaID,
+		Type:      sagaType,
+		Status:    StatusRunning,
+		Steps:     steps,
+		Data:      data,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NextStep returns the index of the step to run next, or -1 when every step is done
+func (s *Saga) NextStep() int {
+	for i, step := range s.Steps {
+		if step.Status != StepDone {
+			return i
+		}
+	}
+	return -1
+}
+
+// StartStep marks a step as running
+func (s *Saga) StartStep(i int, at time.Time) {
+	s.Steps[i].Status = StepRunning
+	s.Steps[i].Error = ""
+	s.Steps[i].StartedAt = &at
+	s.Steps[i].FinishedAt = nil
+}
+
+// CompleteStep marks a step as done, completing the saga after its last step
+func (s *Saga) CompleteStep(i int, at time.Time) {
+	s.Steps[i].Status = StepDone
+	s.Steps[i].FinishedAt = &at
+	if s.NextStep() < 0 {
+		s.Status = StatusCompleted
+	}
+}
+
+// FailStep marks a step as failed and starts compensating the saga
+func (s *Saga) FailStep(i int, err error, at time.Time) {
+	s.Steps[i].Status = StepFailed
+	s.Steps[i].Error = err.Error()
+	s.Steps[i].FinishedAt = &at
+	s.Status = StatusCompensating
+	s.Error = err.Error()
+}
+
+// NextCompensation returns the index of the step to compensate next, or -1
+// when nothing is left to undo. Failed and interrupted steps are compensated
+// too, since their action may have taken effect before failing.
+func (s *Saga) NextCompensation() int {
+	for i := len(s.Steps) - 1; i >= 0; i-- {
+		switch s.Steps[i].Status {
+		case StepDone, StepFailed, StepRunning:
+			return i
+		}
+	}
+	return -1
+}
+
+// CompensateStep marks a step as undone, completing the compensation after
+// the first step
+func (s *Saga) CompensateStep(i int, at time.Time) {
+	s.Steps[i].Status = StepCompensated
+	s.Steps[i].FinishedAt = &at
+	if s.NextCompensation() < 0 {
+		s.Status = StatusCompensated
+	}
+}
+
+// FailCompensation records a failed compensation. The saga stays compensating
+// to be retried until maxAttempts, and then fails for an operator to finish.
+func (s *Saga) FailCompensation(i int, err error, maxAttempts int) {
+	s.Steps[i].Error = err.Error()
+	s.CompensationAttempts++
+	if maxAttempts > 0 && s.CompensationAttempts >= maxAttempts {
+		s.Status = StatusFailed
+	}
+}
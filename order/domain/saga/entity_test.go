@@ -0,0 +1,59 @@
+package saga
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSagaCompletesAfterLastStep(t *testing.T) {
+	now := time.Now()
+	saga := NewSaga("s1", "test", []string{"a", "b"}, nil, now)
+
+	assert.Equal(t, 0, saga.NextStep())
+	saga.StartStep(0, now)
+	saga.CompleteStep(0, now)
+	assert.Equal(t, StatusRunning, saga.Status)
+
+	assert.Equal(t, 1, saga.NextStep())
+	saga.StartStep(1, now)
+	saga.CompleteStep(1, now)
+	assert.Equal(t, -1, saga.NextStep())
+	assert.Equal(t, StatusCompleted, saga.Status)
+}
+
+func TestSagaCompensatesInReverseIncludingTheFailedStep(t *testing.T) {
+	now := time.Now()
+	saga := NewSaga("s1", "test", []string{"a", "b", "c"}, nil, now)
+	saga.StartStep(0, now)
+	saga.CompleteStep(0, now)
+	saga.StartStep(1, now)
+	saga.FailStep(1, errors.New("timeout"), now)
+
+	assert.Equal(t, StatusCompensating, saga.Status)
+	assert.Equal(t, "timeout", saga.Error)
+
+	assert.Equal(t, 1, saga.NextCompensation())
+	saga.CompensateStep(1, now)
+	assert.Equal(t, 0, saga.NextCompensation())
+	saga.CompensateStep(0, now)
+
+	assert.Equal(t, -1, saga.NextCompensation())
+	assert.Equal(t, StatusCompensated, saga.Status)
+	assert.Equal(t, StepPending, saga.Steps[2].Status)
+}
+
+func TestSagaFailsAfterTooManyCompensationAttempts(t *testing.T) {
+	now := time.Now()
+	saga := NewSaga("s1", "test", []string{"a"}, nil, now)
+	saga.StartStep(0, now)
+	saga.FailStep(0, errors.New("declined"), now)
+
+	saga.FailCompensation(0, errors.New("unreachable"), 2)
+	assert.Equal(t, StatusCompensating, saga.Status)
+	saga.FailCompensation(0, errors.New("unreachable"), 2)
+	assert.Equal(t, StatusFailed, saga.Status)
+	assert.True(t, saga.Status.IsFinished())
+}
@@ -0,0 +1,13 @@
+package saga
+
+import "errors"
+
+var (
+	ErrSagaNotFound      = errors.New("saga not found")
+	ErrSagaExists        = errors.New("saga already exists")
+	ErrUnknownSagaType   = errors.New("unknown saga type")
+	ErrInvalidSagaFilter = errors.New("invalid saga filter")
+	// ErrLeaseLost is returned when another instance took over a saga, whose
+	// state then no longer belongs to the caller
+	ErrLeaseLost = errors.New("saga was taken over by another instance")
+)
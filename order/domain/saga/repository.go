@@ -0,0 +1,27 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+// Filter narrows down a saga listing
+type Filter struct {
+	Type     string
+	Statuses []Status
+	Limit    int
+}
+
+type Repository interface {
+	// CreateSaga returns ErrSagaExists when the saga ID is taken
+	CreateSaga(ctx context.Context, saga *Saga) error
+	GetSaga(ctx context.Context, sagaID string) (*Saga, error)
+	GetSagas(ctx context.Context, filter Filter) ([]Saga, error)
+
+	// UpdateSaga saves the saga if it is still at saga.Version and bumps the
+	// version, or returns ErrLeaseLost
+	UpdateSaga(ctx context.Context, saga *Saga) error
+
+	// GetAbandonedSagas returns unfinished sagas whose lease ran out before now
+	GetAbandonedSagas(ctx context.Context, now time.Time, limit int) ([]Saga, error)
+}
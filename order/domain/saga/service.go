@@ -0,0 +1,15 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+// Service defines the business operations for sagas
+type Service interface {
+	CreateSaga(ctx context.Context, saga *Saga) error
+	GetSaga(ctx context.Context, sagaID string) (*Saga, error)
+	GetSagas(ctx context.Context, filter Filter) ([]Saga, error)
+	UpdateSaga(ctx context.Context, saga *Saga) error
+	GetAbandonedSagas(ctx context.Context, now time.Time, limit int) ([]Saga, error)
+}
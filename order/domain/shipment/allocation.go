@@ -0,0 +1,86 @@
+package shipment
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Unshipped returns the quantities of ordered, by product, that no shipment
+// carries yet. Products fully shipped are left out.
+func Unshipped(ordered map[string]int, shipments []Shipment) map[string]int {
+	left := make(map[string]int, len(ordered))
+	for productID, quantity := range ordered {
+		left[productID] = quantity
+	}
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			left[item.ProductID] -= item.Quantity
+		}
+	}
+	for productID, quantity := range left {
+		if quantity <= 0 {
+			delete(left, productID)
+		}
+	}
+	return left
+}
+
+// Allocate checks that the items can be packed in a new shipment given the
+// shipments made so far, and merges lines of the same product. No items
+// allocates everything not shipped yet.
+func Allocate(ordered map[string]int, shipments []Shipment, items []Item) ([]Item, error) {
+	left := Unshipped(ordered, shipments)
+	if len(items) == 0 {
+		for productID, quantity := range left {
+			items = append(items, Item{ProductID: productID, Quantity: quantity})
+		}
+		if len(items) == 0 {
+			return nil, ErrNothingToShip
+		}
+		sortItems(items)
+		return items, nil
+	}
+
+	merged := make(map[string]int, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of %s must be positive", ErrInvalidAllocation, item.ProductID)
+		}
+		if _, ok := ordered[item.ProductID]; !ok {
+			return nil, fmt.Errorf("%w: %s is not in the order", ErrInvalidAllocation, item.ProductID)
+		}
+		merged[item.ProductID] += item.Quantity
+	}
+
+	allocated := make([]Item, 0, len(merged))
+	for productID, quantity := range merged {
+		if quantity > left[productID] {
+			return nil, fmt.Errorf("%w: %d of %s left to ship, %d requested", ErrInvalidAllocation, left[productID], productID, quantity)
+		}
+		allocated = append(allocated, Item{ProductID: productID, Quantity: quantity})
+	}
+	sortItems(allocated)
+	return allocated, nil
+}
+
+// Progress tells how far the shipments of an order went: dispatched once every
+// ordered item is in a shipment that left the warehouse, delivered once all
+// of them were delivered
+func Progress(ordered map[string]int, shipments []Shipment) (dispatched, delivered bool) {
+	if len(shipments) == 0 || len(Unshipped(ordered, shipments)) > 0 {
+		return false, false
+	}
+
+	dispatched, delivered = true, true
+	for _, shipment := range shipments {
+		dispatched = dispatched && shipment.Status.HasLeft()
+		delivered = delivered && shipment.Status == StatusDelivered
+	}
+	return dispatched, delivered
+}
+
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ProductID < items[j].ProductID
+	})
+}
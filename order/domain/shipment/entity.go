@@ -0,0 +1,106 @@
+package shipment
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Status string
+
+const (
+	StatusPending        Status = "pending" // Booked with the carrier, not picked up yet
+	StatusInTransit      Status = "in_transit"
+	StatusOutForDelivery Status = "out_for_delivery"
+	StatusDelivered      Status = "delivered"
+	StatusException      Status = "exception" // Delivery failed; the carrier tries again or returns the parcel
+)
+
+// IsValid reports whether the status is known
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusInTransit, StatusOutForDelivery, StatusDelivered, StatusException:
+		return true
+	}
+	return false
+}
+
+// HasLeft reports whether the parcel left the warehouse
+func (s Status) HasLeft() bool {
+	return s.IsValid() && s != StatusPending
+}
+
+// Shipment is a parcel sent to the customer with some or all of the items of
+// an order. TrackingNumber is the carrier's reference for it.
+type Shipment struct {
+	ID             *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	ShipmentID     string              `json:"shipmentId,omitempty" bson:"shipment_id,omitempty"`
+	OrderID        int64               `json:"orderId,omitempty" bson:"order_id,omitempty"`
+	Carrier        string              `json:"carrier,omitempty" bson:"carrier,omitempty"`
+	TrackingNumber string              `json:"trackingNumber,omitempty" bson:"tracking_number,omitempty"`
+	Items          []Item              `json:"items,omitempty" bson:"items,omitempty"`
+	Status         Status              `json:"status,omitempty" bson:"status,omitempty"`
+	Events         []TrackingEvent     `json:"events,omitempty" bson:"events,omitempty"`
+	ShippedAt      *time.Time          `json:"shippedAt,omitempty" bson:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time          `json:"deliveredAt,omitempty" bson:"delivered_at,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt      time.Time           `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+
+	// Version is incremented on every update and guards against lost updates
+	Version int64 `json:"version" bson:"version"`
+}
+
+// Item is a quantity of an order line packed in a shipment
+type Item struct {
+	ProductID string `json:"productId,omitempty" bson:"product_id,omitempty"`
+	Quantity  int    `json:"quantity,omitempty" bson:"quantity,omitempty"`
+}
+
+// TrackingEvent is a scan of a parcel reported by the carrier. EventID is
+// unique per carrier and is used to drop updates delivered more than once.
+type TrackingEvent struct {
+	EventID     string    `json:"eventId,omitempty" bson:"event_id,omitempty"`
+	Status      Status    `json:"status,omitempty" bson:"status,omitempty"`
+	Description string    `json:"description,omitempty" bson:"description,omitempty"`
+	Location    string    `json:"location,omitempty" bson:"location,omitempty"`
+	OccurredAt  time.Time `json:"occurredAt,omitempty" bson:"occurred_at,omitempty"`
+	ReceivedAt  time.Time `json:"receivedAt,omitempty" bson:"received_at,omitempty"`
+}
+
+// Track records a tracking event. Carriers may report scans late or out of
+// order, so the shipment takes the status of its latest scan, except that a
+// delivered shipment stays delivered. It reports false for an event recorded
+// before.
+func (s *Shipment) Track(event TrackingEvent) (bool, error) {
+	if event.EventID == "" || !event.Status.IsValid() {
+		return false, fmt.Errorf("%w: an event ID and a known status are required", ErrInvalidTrackingEvent)
+	}
+	for _, recorded := range s.Events {
+		if recorded.EventID == event.EventID {
+			return false, nil
+		}
+	}
+
+	s.Events = append(s.Events, event)
+	sort.SliceStable(s.Events, func(i, j int) bool {
+		return s.Events[i].OccurredAt.Before(s.Events[j].OccurredAt)
+	})
+
+	if event.Status.HasLeft() && (s.ShippedAt == nil || event.OccurredAt.Before(*s.ShippedAt)) {
+		shippedAt := event.OccurredAt
+		s.ShippedAt = &shippedAt
+	}
+	if s.Status != StatusDelivered {
+		if event.Status == StatusDelivered {
+			s.Status = StatusDelivered
+			deliveredAt := event.OccurredAt
+			s.DeliveredAt = &deliveredAt
+		} else {
+			s.Status = s.Events[len(s.Events)-1].Status
+		}
+	}
+	s.UpdatedAt = event.ReceivedAt
+	return true, nil
+}
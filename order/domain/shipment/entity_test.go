@@ -0,0 +1,84 @@
+package shipment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackTakesTheStatusOfTheLatestScan(t *testing.T) {
+	now := time.Now()
+	shipment := &Shipment{Status: StatusPending}
+
+	changed, err := shipment.Track(TrackingEvent{EventID: "e2", Status: StatusOutForDelivery, OccurredAt: now})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	// A late scan does not move the shipment back
+	changed, err = shipment.Track(TrackingEvent{EventID: "e1", Status: StatusInTransit, OccurredAt: now.Add(-time.Hour)})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, StatusOutForDelivery, shipment.Status)
+	assert.Equal(t, now.Add(-time.Hour), *shipment.ShippedAt)
+	assert.Equal(t, "e1", shipment.Events[0].EventID)
+
+	changed, err = shipment.Track(TrackingEvent{EventID: "e1", Status: StatusInTransit, OccurredAt: now})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Len(t, shipment.Events, 2)
+}
+
+func TestTrackKeepsDeliveredShipmentsDelivered(t *testing.T) {
+	now := time.Now()
+	shipment := &Shipment{Status: StatusInTransit}
+
+	_, err := shipment.Track(TrackingEvent{EventID: "e1", Status: StatusDelivered, OccurredAt: now})
+	assert.NoError(t, err)
+	_, err = shipment.Track(TrackingEvent{EventID: "e2", Status: StatusException, OccurredAt: now.Add(time.Minute)})
+	assert.NoError(t, err)
+
+	assert.Equal(t, StatusDelivered, shipment.Status)
+	assert.Equal(t, now, *shipment.DeliveredAt)
+
+	_, err = shipment.Track(TrackingEvent{EventID: "e3", Status: "lost"})
+	assert.ErrorIs(t, err, ErrInvalidTrackingEvent)
+}
+
+func TestAllocateChecksWhatIsLeftToShip(t *testing.T) {
+	ordered := map[string]int{"a": 3, "b": 1}
+	shipments := []Shipment{{Items: []Item{{ProductID: "a", Quantity: 2}}}}
+
+	items, err := Allocate(ordered, shipments, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []Item{{ProductID: "a", Quantity: 1}, {ProductID: "b", Quantity: 1}}, items)
+
+	_, err = Allocate(ordered, shipments, []Item{{ProductID: "a", Quantity: 1}, {ProductID: "a", Quantity: 1}})
+	assert.ErrorIs(t, err, ErrInvalidAllocation)
+
+	_, err = Allocate(ordered, shipments, []Item{{ProductID: "c", Quantity: 1}})
+	assert.ErrorIs(t, err, ErrInvalidAllocation)
+
+	shipments = append(shipments, Shipment{Items: items})
+	_, err = Allocate(ordered, shipments, nil)
+	assert.ErrorIs(t, err, ErrNothingToShip)
+}
+
+func TestProgressWaitsForEveryItem(t *testing.T) {
+	ordered := map[string]int{"a": 2}
+	shipments := []Shipment{{Status: StatusDelivered, Items: []Item{{ProductID: "a", Quantity: 1}}}}
+
+	dispatched, delivered := Progress(ordered, shipments)
+	assert.False(t, dispatched)
+	assert.False(t, delivered)
+
+	shipments = append(shipments, Shipment{Status: StatusInTransit, Items: []Item{{ProductID: "a", Quantity: 1}}})
+	dispatched, delivered = Progress(ordered, shipments)
+	assert.True(t, dispatched)
+	assert.False(t, delivered)
+
+	shipments[1].Status = StatusDelivered
+	dispatched, delivered = Progress(ordered, shipments)
+	assert.True(t, dispatched)
+	assert.True(t, delivered)
+}
@@ -0,0 +1,13 @@
+package shipment
+
+import "errors"
+
+var (
+	ErrShipmentNotFound     = errors.New("shipment not found")
+	ErrOrderNotShippable    = errors.New("order cannot be shipped")
+	ErrNothingToShip        = errors.New("every item of the order is already shipped")
+	ErrInvalidAllocation    = errors.New("invalid shipment items")
+	ErrInvalidTrackingEvent = errors.New("invalid tracking event")
+	ErrInvalidSignature     = errors.New("invalid tracking signature")
+	ErrVersionConflict      = errors.New("shipment was modified concurrently")
+)
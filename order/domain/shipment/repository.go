@@ -0,0 +1,14 @@
+package shipment
+
+import "context"
+
+type Repository interface {
+	CreateShipment(ctx context.Context, shipment *Shipment) error
+	// GetShipmentByTracking reads from the primary, as shipments are read to be updated
+	GetShipmentByTracking(ctx context.Context, carrier, trackingNumber string) (*Shipment, error)
+	// GetShipmentsByOrder reads from the primary, oldest first
+	GetShipmentsByOrder(ctx context.Context, orderID int64) ([]Shipment, error)
+	// UpdateShipment saves the shipment if it is still at its version, or
+	// returns ErrVersionConflict
+	UpdateShipment(ctx context.Context, shipment *Shipment) error
+}
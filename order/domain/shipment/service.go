@@ -0,0 +1,11 @@
+package shipment
+
+import "context"
+
+// Service defines the business operations for shipments
+type Service interface {
+	CreateShipment(ctx context.Context, shipment *Shipment) error
+	GetShipmentByTracking(ctx context.Context, carrier, trackingNumber string) (*Shipment, error)
+	GetShipmentsByOrder(ctx context.Context, orderID int64) ([]Shipment, error)
+	UpdateShipment(ctx context.Context, shipment *Shipment) error
+}
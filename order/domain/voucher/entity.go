@@ -0,0 +1,193 @@
+package voucher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Voucher struct {
+	ID          *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Code        string              `json:"code,omitempty" bson:"code,omitempty"`
+	Description string              `json:"description,omitempty" bson:"description,omitempty"`
+	Type        VoucherType         `json:"type,omitempty" bson:"type,omitempty"`
+	Active      bool                `json:"active" bson:"active"`
+
+	// Percentage is the discount rate of percentage vouchers
+	Percentage float64 `json:"percentage,omitempty" bson:"percentage,omitempty"`
+	// Amount is the discount of fixed amount vouchers
+	Amount money.Money `json:"amount" bson:"amount,omitempty"`
+	// MaxDiscount caps the discount of percentage vouchers, 0 means no cap
+	MaxDiscount money.Money `json:"maxDiscount" bson:"max_discount,omitempty"`
+	// FreeProductID and FreeQuantity describe the product given away by free item vouchers
+	FreeProductID string `json:"freeProductId,omitempty" bson:"free_product_id,omitempty"`
+	FreeQuantity  int    `json:"freeQuantity,omitempty" bson:"free_quantity,omitempty"`
+
+	MinSpend money.Money `json:"minSpend" bson:"min_spend,omitempty"`
+
+	// UsageLimit is the total number of redemptions allowed, 0 means unlimited
+	UsageLimit int `json:"usageLimit,omitempty" bson:"usage_limit"`
+	// PerCustomerLimit is the number of redemptions allowed per customer, 0 means unlimited
+	PerCustomerLimit int `json:"perCustomerLimit,omitempty" bson:"per_customer_limit"`
+	UsedCount        int `json:"usedCount" bson:"used_count"`
+
+	StartsAt  time.Time `json:"startsAt,omitempty" bson:"starts_at,omitempty"`
+	EndsAt    time.Time `json:"endsAt,omitempty" bson:"ends_at,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty" bson:"updated_at,omitempty"`
+}
+
+type VoucherType string
+
+const (
+	TypePercentage  VoucherType = "percentage"
+	TypeFixedAmount VoucherType = "fixed_amount"
+	TypeFreeItem    VoucherType = "free_item"
+)
+
+// Redemption records a voucher used on an order
+type Redemption struct {
+	ID          *primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	VoucherCode string              `json:"voucherCode,omitempty" bson:"voucher_code,omitempty"`
+	UserID      string              `json:"userId,omitempty" bson:"user_id,omitempty"`
+	OrderID     int64               `json:"orderId,omitempty" bson:"order_id,omitempty"`
+	Discount    money.Money         `json:"discount" bson:"discount,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt,omitempty" bson:"created_at,omitempty"`
+}
+
+// PricedItem is an order line the discount is calculated on
+type PricedItem struct {
+	ProductID string
+	Quantity  int
+	Price     money.Money
+}
+
+// Validate checks the definition of the voucher itself
+func (v *Voucher) Validate() error {
+	if v.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidVoucher)
+	}
+	switch v.Type {
+	case TypePercentage:
+		if v.Percentage <= 0 || v.Percentage > 100 {
+			return fmt.Errorf("%w: percentage must be between 0 and 100", ErrInvalidVoucher)
+		}
+	case TypeFixedAmount:
+		if !v.Amount.IsPositive() {
+			return fmt.Errorf("%w: amount must be greater than 0", ErrInvalidVoucher)
+		}
+	case TypeFreeItem:
+		if v.FreeProductID == "" || v.FreeQuantity <= 0 {
+			return fmt.Errorf("%w: free product and quantity are required", ErrInvalidVoucher)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidVoucher, v.Type)
+	}
+	if err := v.validateAmounts(); err != nil {
+		return err
+	}
+	if !v.StartsAt.IsZero() && !v.EndsAt.IsZero() && v.EndsAt.Before(v.StartsAt) {
+		return fmt.Errorf("%w: end date is before start date", ErrInvalidVoucher)
+	}
+	return nil
+}
+
+// validateAmounts checks that every amount set on the voucher is non negative
+// and that they all share one valid currency
+func (v *Voucher) validateAmounts() error {
+	currency := ""
+	for _, amount := range []money.Money{v.Amount, v.MaxDiscount, v.MinSpend} {
+		if amount.IsZero() {
+			continue
+		}
+		if amount.IsNegative() {
+			return fmt.Errorf("%w: amounts must not be negative", ErrInvalidVoucher)
+		}
+		if err := money.ValidateCurrency(amount.Currency); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidVoucher, err)
+		}
+		if currency != "" && amount.Currency != currency {
+			return fmt.Errorf("%w: amounts must share one currency", ErrInvalidVoucher)
+		}
+		currency = amount.Currency
+	}
+	return nil
+}
+
+// CheckApplicable reports why the voucher cannot be used at the given time on
+// an order with the given subtotal, or nil when it can
+func (v *Voucher) CheckApplicable(now time.Time, subtotal money.Money) error {
+	if !v.Active {
+		return ErrVoucherInactive
+	}
+	if !v.StartsAt.IsZero() && now.Before(v.StartsAt) {
+		return ErrVoucherNotStarted
+	}
+	if !v.EndsAt.IsZero() && now.After(v.EndsAt) {
+		return ErrVoucherExpired
+	}
+	if v.UsageLimit > 0 && v.UsedCount >= v.UsageLimit {
+		return ErrUsageLimitReached
+	}
+	if !v.MinSpend.IsZero() {
+		cmp, err := subtotal.Compare(v.MinSpend)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrVoucherCurrencyMismatch, err)
+		}
+		if cmp < 0 {
+			return fmt.Errorf("%w: minimum spend is %s", ErrMinimumSpendNotMet, v.MinSpend)
+		}
+	}
+	return nil
+}
+
+// CalculateDiscount returns the discount the voucher gives on the items. The
+// discount never exceeds the subtotal.
+func (v *Voucher) CalculateDiscount(subtotal money.Money, items []PricedItem) (money.Money, error) {
+	var discount money.Money
+
+	switch v.Type {
+	case TypePercentage:
+		discount = subtotal.Percent(v.Percentage)
+		if !v.MaxDiscount.IsZero() {
+			cmp, err := discount.Compare(v.MaxDiscount)
+			if err != nil {
+				return money.Money{}, fmt.Errorf("%w: %v", ErrVoucherCurrencyMismatch, err)
+			}
+			if cmp > 0 {
+				discount = v.MaxDiscount
+			}
+		}
+	case TypeFixedAmount:
+		discount = v.Amount
+	case TypeFreeItem:
+		remaining := v.FreeQuantity
+		for _, item := range items {
+			if item.ProductID != v.FreeProductID || remaining == 0 {
+				continue
+			}
+			quantity := min(item.Quantity, remaining)
+			var err error
+			if discount, err = discount.Add(item.Price.Multiply(quantity)); err != nil {
+				return money.Money{}, err
+			}
+			remaining -= quantity
+		}
+		if remaining == v.FreeQuantity {
+			return money.Money{}, fmt.Errorf("%w: product %s", ErrFreeItemNotInOrder, v.FreeProductID)
+		}
+	default:
+		return money.Money{}, fmt.Errorf("%w: unknown type %q", ErrInvalidVoucher, v.Type)
+	}
+
+	cmp, err := discount.Compare(subtotal)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%w: %v", ErrVoucherCurrencyMismatch, err)
+	}
+	if cmp > 0 {
+		discount = subtotal
+	}
+	return discount, nil
+}
@@ -0,0 +1,72 @@
+package voucher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func usd(amount int64) money.Money {
+	return money.New(amount, "USD")
+}
+
+func TestCalculateDiscount(t *testing.T) {
+	items := []PricedItem{
+		{ProductID: "p1", Quantity: 2, Price: usd(1000)},
+		{ProductID: "p2", Quantity: 1, Price: usd(3000)},
+	}
+	subtotal := usd(5000)
+
+	percentage := &Voucher{Type: TypePercentage, Percentage: 15}
+	discount, err := percentage.CalculateDiscount(subtotal, items)
+	assert.NoError(t, err)
+	assert.Equal(t, usd(750), discount)
+
+	capped := &Voucher{Type: TypePercentage, Percentage: 50, MaxDiscount: usd(500)}
+	discount, err = capped.CalculateDiscount(subtotal, items)
+	assert.NoError(t, err)
+	assert.Equal(t, usd(500), discount)
+
+	fixed := &Voucher{Type: TypeFixedAmount, Amount: usd(8000)}
+	discount, err = fixed.CalculateDiscount(subtotal, items)
+	assert.NoError(t, err)
+	assert.Equal(t, usd(5000), discount, "discount is capped at the subtotal")
+
+	freeItem := &Voucher{Type: TypeFreeItem, FreeProductID: "p1", FreeQuantity: 3}
+	discount, err = freeItem.CalculateDiscount(subtotal, items)
+	assert.NoError(t, err)
+	assert.Equal(t, usd(2000), discount, "only the quantity in the order is free")
+
+	missing := &Voucher{Type: TypeFreeItem, FreeProductID: "p3", FreeQuantity: 1}
+	_, err = missing.CalculateDiscount(subtotal, items)
+	assert.ErrorIs(t, err, ErrFreeItemNotInOrder)
+
+	euro := &Voucher{Type: TypeFixedAmount, Amount: money.New(500, "EUR")}
+	_, err = euro.CalculateDiscount(subtotal, items)
+	assert.ErrorIs(t, err, ErrVoucherCurrencyMismatch)
+}
+
+func TestCheckApplicable(t *testing.T) {
+	now := time.Now()
+	voucher := &Voucher{
+		Active:     true,
+		MinSpend:   usd(2000),
+		UsageLimit: 1,
+		StartsAt:   now.Add(-time.Hour),
+		EndsAt:     now.Add(time.Hour),
+	}
+
+	assert.NoError(t, voucher.CheckApplicable(now, usd(2000)))
+	assert.ErrorIs(t, voucher.CheckApplicable(now, usd(1999)), ErrMinimumSpendNotMet)
+	assert.ErrorIs(t, voucher.CheckApplicable(now, money.New(2000, "EUR")), ErrVoucherCurrencyMismatch)
+	assert.ErrorIs(t, voucher.CheckApplicable(now.Add(-2*time.Hour), usd(2000)), ErrVoucherNotStarted)
+	assert.ErrorIs(t, voucher.CheckApplicable(now.Add(2*time.Hour), usd(2000)), ErrVoucherExpired)
+
+	voucher.UsedCount = 1
+	assert.ErrorIs(t, voucher.CheckApplicable(now, usd(2000)), ErrUsageLimitReached)
+
+	voucher.Active = false
+	assert.ErrorIs(t, voucher.CheckApplicable(now, usd(2000)), ErrVoucherInactive)
+}
@@ -0,0 +1,39 @@
+package voucher
+
+import "errors"
+
+var (
+	ErrVoucherNotFound           = errors.New("voucher not found")
+	ErrVoucherAlreadyExists      = errors.New("voucher already exists")
+	ErrInvalidVoucher            = errors.New("invalid voucher")
+	ErrVoucherInactive           = errors.New("voucher is not active")
+	ErrVoucherNotStarted         = errors.New("voucher is not valid yet")
+	ErrVoucherExpired            = errors.New("voucher has expired")
+	ErrMinimumSpendNotMet        = errors.New("minimum spend not met")
+	ErrVoucherCurrencyMismatch   = errors.New("voucher does not apply to the order currency")
+	ErrUsageLimitReached         = errors.New("voucher usage limit reached")
+	ErrCustomerUsageLimitReached = errors.New("voucher already used by this customer")
+	ErrFreeItemNotInOrder        = errors.New("free item voucher requires its product in the order")
+	ErrRedemptionNotFound        = errors.New("voucher redemption not found")
+)
+
+// IsRejection reports whether err is a reason a voucher cannot be applied to
+// an order, as opposed to a failure to check it
+func IsRejection(err error) bool {
+	for _, target := range []error{
+		ErrVoucherNotFound,
+		ErrVoucherInactive,
+		ErrVoucherNotStarted,
+		ErrVoucherExpired,
+		ErrMinimumSpendNotMet,
+		ErrVoucherCurrencyMismatch,
+		ErrUsageLimitReached,
+		ErrCustomerUsageLimitReached,
+		ErrFreeItemNotInOrder,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
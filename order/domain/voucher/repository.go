@@ -0,0 +1,25 @@
+package voucher
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	GetVoucher(ctx context.Context, code string) (*Voucher, error)
+	GetVouchers(ctx context.Context) ([]Voucher, error)
+	CreateVoucher(ctx context.Context, voucher *Voucher) error
+	UpdateVoucher(ctx context.Context, voucher *Voucher) error
+
+	// IncrementUsage atomically counts one redemption of an active, in-date
+	// voucher, failing when its usage limit is already reached
+	IncrementUsage(ctx context.Context, code string, now time.Time) (*Voucher, error)
+
+	// DecrementUsage gives back one redemption of a voucher
+	DecrementUsage(ctx context.Context, code string, now time.Time) error
+
+	CountRedemptions(ctx context.Context, code string, userID string) (int64, error)
+	CreateRedemption(ctx context.Context, redemption *Redemption) error
+	GetRedemptionByOrder(ctx context.Context, orderID int64) (*Redemption, error)
+	DeleteRedemption(ctx context.Context, orderID int64) error
+}
@@ -0,0 +1,27 @@
+package voucher
+
+import (
+	"context"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+)
+
+// Service defines the business operations for vouchers
+type Service interface {
+	GetVoucher(ctx context.Context, code string) (*Voucher, error)
+	GetVouchers(ctx context.Context) ([]Voucher, error)
+	CreateVoucher(ctx context.Context, voucher *Voucher) error
+	UpdateVoucher(ctx context.Context, voucher *Voucher) error
+
+	// CalculateDiscount checks that the voucher applies to the order and returns the discount
+	CalculateDiscount(ctx context.Context, code string, userID string, subtotal money.Money, items []PricedItem) (money.Money, error)
+
+	// Redeem records the use of a voucher on an order, enforcing usage limits.
+	// It should run in a transaction. Redeeming again for the same order does
+	// nothing.
+	Redeem(ctx context.Context, code string, userID string, orderID int64, discount money.Money) error
+
+	// Release undoes the redemption made for an order, if any. It should run
+	// in a transaction.
+	Release(ctx context.Context, orderID int64) error
+}
@@ -48,11 +48,13 @@ func (p *Producer) Publish(message domain.Message) error {
 		topic = p.defaultTopic
 	}
 
-	// add timestamp to meta
+	// add timestamp to meta, unless the message carries when it happened
 	if message.Value.Meta == nil {
 		message.Value.Meta = &domain.MetaData{}
 	}
-	message.Value.Meta.Timestamp = time.Now().UnixNano()
+	if message.Value.Meta.Timestamp == 0 {
+		message.Value.Meta.Timestamp = time.Now().UnixNano()
+	}
 
 	// Create delivery channel for this specific message
 	deliveryChan := make(chan kafka.Event)
@@ -119,6 +119,19 @@ func (m *MongoAdapter) Update(ctx context.Context, collection string, filter any
 	return nil
 }
 
+// UpdateMany updates all documents matching the filter and returns how many matched
+func (m *MongoAdapter) UpdateMany(ctx context.Context, collection string, filter any, update any, opts ...*options.UpdateOptions) (int64, error) {
+	if isEmptyFilter(filter) {
+		return 0, fmt.Errorf("%w: update requires a non-empty filter", ErrEmptyFilter)
+	}
+
+	result, err := m.db.Collection(collection).UpdateMany(ctx, filter, update, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update documents: %w", err)
+	}
+	return result.MatchedCount, nil
+}
+
 func (m *MongoAdapter) Delete(ctx context.Context, collection string, filter any, opts ...*options.DeleteOptions) error {
 	if isEmptyFilter(filter) {
 		return fmt.Errorf("%w: delete requires a non-empty filter", ErrEmptyFilter)
@@ -153,3 +166,11 @@ func (m *MongoAdapter) Incr(ctx context.Context, collection string, filter any,
 	}
 	return nil
 }
+
+// CreateIndexes creates the indexes on the collection, leaving existing identical indexes untouched
+func (m *MongoAdapter) CreateIndexes(ctx context.Context, collection string, models ...mongo.IndexModel) error {
+	if _, err := m.db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+)
+
+// TransactionManager runs functions inside MongoDB multi-document transactions
+type TransactionManager struct {
+	client *mongo.Client
+}
+
+// NewTransactionManager creates a transaction manager on top of an existing client
+func NewTransactionManager(client *mongo.Client) *TransactionManager {
+	return &TransactionManager{client: client}
+}
+
+// Ensure TransactionManager implements TransactionManager port
+var _ port.TransactionManager = (*TransactionManager)(nil)
+
+// ExecuteInTx runs fn in a transaction, committing on success and aborting on error
+func (tm *TransactionManager) ExecuteInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := tm.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -0,0 +1,204 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+const (
+	FakeProviderName = "fake"
+	signaturePrefix  = "sha256="
+)
+
+// fakeWebhook is the callback body sent by the fake provider
+type fakeWebhook struct {
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	IntentID      string      `json:"intentId"`
+	Amount        money.Money `json:"amount"`
+	FailureReason string      `json:"failureReason,omitempty"`
+}
+
+type fakeIntent struct {
+	amount    money.Money
+	refunded  money.Money
+	cancelled bool
+}
+
+// FakeGateway is an in-memory payment and refund provider for local
+// development and tests. Nothing leaves the process: payments are settled by calling
+// SimulateWebhook, which produces a callback signed like a real provider's.
+//
+// Callbacks are signed with HMAC-SHA256 over the raw body using the webhook
+// secret and sent as "sha256=<hex>".
+type FakeGateway struct {
+	secret []byte
+
+	mu      sync.Mutex
+	intents map[string]*fakeIntent
+	keys    map[string]string
+	refunds map[string]string
+}
+
+// Ensure FakeGateway implements the payment ports
+var (
+	_ port.PaymentGateway = (*FakeGateway)(nil)
+	_ port.RefundProvider = (*FakeGateway)(nil)
+)
+
+func NewFakeGateway(webhookSecret string) *FakeGateway {
+	return &FakeGateway{
+		secret:  []byte(webhookSecret),
+		intents: make(map[string]*fakeIntent),
+		keys:    make(map[string]string),
+		refunds: make(map[string]string),
+	}
+}
+
+func (g *FakeGateway) Name() string {
+	return FakeProviderName
+}
+
+func (g *FakeGateway) CreateIntent(ctx context.Context, req port.PaymentIntentRequest) (*port.PaymentIntentResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	intentID, ok := g.keys[req.IdempotencyKey]
+	if !ok {
+		intentID = "pi_fake_" + randomHex(12)
+		g.intents[intentID] = &fakeIntent{amount: req.Amount}
+		if req.IdempotencyKey != "" {
+			g.keys[req.IdempotencyKey] = intentID
+		}
+	}
+
+	return &port.PaymentIntentResult{
+		IntentID:     intentID,
+		ClientSecret: intentID + "_secret",
+	}, nil
+}
+
+func (g *FakeGateway) CancelIntent(ctx context.Context, intentID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Intents created before a restart are unknown to the fake and are
+	// considered cancelled already
+	if intent, ok := g.intents[intentID]; ok {
+		intent.cancelled = true
+	}
+	return nil
+}
+
+// Refund pays back part of an intent. Like CancelIntent, it trusts intents it
+// does not know, but refuses to refund more than a known intent collected.
+func (g *FakeGateway) Refund(ctx context.Context, req port.RefundRequest) (*port.RefundResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if refundID, ok := g.refunds[req.IdempotencyKey]; ok {
+		return &port.RefundResult{RefundID: refundID}, nil
+	}
+
+	if intent, ok := g.intents[req.IntentID]; ok {
+		if intent.cancelled {
+			return nil, fmt.Errorf("intent %s is cancelled", req.IntentID)
+		}
+		refunded, err := intent.refunded.Add(req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if refunded.Amount > intent.amount.Amount {
+			return nil, fmt.Errorf("refund of %s exceeds what is left of intent %s", req.Amount, req.IntentID)
+		}
+		intent.refunded = refunded
+	}
+
+	refundID := "re_fake_" + randomHex(12)
+	if req.IdempotencyKey != "" {
+		g.refunds[req.IdempotencyKey] = refundID
+	}
+	return &port.RefundResult{RefundID: refundID}, nil
+}
+
+func (g *FakeGateway) ParseWebhook(payload []byte, signature string) (*domainpayment.WebhookEvent, error) {
+	if !hmac.Equal([]byte(signature), []byte(g.Sign(payload))) {
+		return nil, domainpayment.ErrInvalidSignature
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainpayment.ErrInvalidWebhook, err)
+	}
+	if webhook.ID == "" || webhook.IntentID == "" {
+		return nil, fmt.Errorf("%w: id and intentId are required", domainpayment.ErrInvalidWebhook)
+	}
+
+	eventType := domainpayment.WebhookEventType(webhook.Type)
+	if eventType != domainpayment.WebhookPaymentSucceeded && eventType != domainpayment.WebhookPaymentFailed {
+		return nil, fmt.Errorf("%w: unknown type %q", domainpayment.ErrInvalidWebhook, webhook.Type)
+	}
+
+	return &domainpayment.WebhookEvent{
+		EventID:       webhook.ID,
+		Provider:      FakeProviderName,
+		Type:          eventType,
+		IntentID:      webhook.IntentID,
+		Amount:        webhook.Amount,
+		FailureReason: webhook.FailureReason,
+	}, nil
+}
+
+// Sign returns the signature header value the fake provider sends with payload
+func (g *FakeGateway) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SimulateWebhook builds the signed callback the provider would send once the
+// customer pays (succeed true) or the payment is declined
+func (g *FakeGateway) SimulateWebhook(intentID string, amount money.Money, succeed bool) ([]byte, string, error) {
+	g.mu.Lock()
+	intent, ok := g.intents[intentID]
+	g.mu.Unlock()
+	if ok && intent.cancelled {
+		return nil, "", fmt.Errorf("intent %s is cancelled", intentID)
+	}
+
+	webhook := fakeWebhook{
+		ID:       "evt_fake_" + randomHex(12),
+		Type:     string(domainpayment.WebhookPaymentSucceeded),
+		IntentID: intentID,
+		Amount:   amount,
+	}
+	if !succeed {
+		webhook.Type = string(domainpayment.WebhookPaymentFailed)
+		webhook.FailureReason = "card_declined"
+	}
+
+	payload, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, g.Sign(payload), nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return strings.ToLower(hex.EncodeToString(b))
+}
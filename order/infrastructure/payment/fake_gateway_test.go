@@ -0,0 +1,67 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeGatewayWebhookRoundTrip(t *testing.T) {
+	gateway := NewFakeGateway("secret")
+
+	intent, err := gateway.CreateIntent(context.Background(), port.PaymentIntentRequest{
+		OrderID:        1,
+		Amount:         money.New(1999, "USD"),
+		IdempotencyKey: "order-1",
+	})
+	assert.NoError(t, err)
+
+	again, err := gateway.CreateIntent(context.Background(), port.PaymentIntentRequest{IdempotencyKey: "order-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, intent.IntentID, again.IntentID, "same idempotency key, same intent")
+
+	payload, signature, err := gateway.SimulateWebhook(intent.IntentID, money.New(1999, "USD"), true)
+	assert.NoError(t, err)
+
+	event, err := gateway.ParseWebhook(payload, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, domainpayment.WebhookPaymentSucceeded, event.Type)
+	assert.Equal(t, intent.IntentID, event.IntentID)
+	assert.Equal(t, money.New(1999, "USD"), event.Amount)
+
+	_, err = gateway.ParseWebhook(payload, NewFakeGateway("other").Sign(payload))
+	assert.ErrorIs(t, err, domainpayment.ErrInvalidSignature)
+}
+
+func TestFakeGatewayRefund(t *testing.T) {
+	gateway := NewFakeGateway("secret")
+
+	intent, err := gateway.CreateIntent(context.Background(), port.PaymentIntentRequest{Amount: money.New(1000, "USD")})
+	assert.NoError(t, err)
+
+	refund, err := gateway.Refund(context.Background(), port.RefundRequest{
+		IntentID:       intent.IntentID,
+		Amount:         money.New(600, "USD"),
+		IdempotencyKey: "refund-1",
+	})
+	assert.NoError(t, err)
+
+	again, err := gateway.Refund(context.Background(), port.RefundRequest{
+		IntentID:       intent.IntentID,
+		Amount:         money.New(600, "USD"),
+		IdempotencyKey: "refund-1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, refund.RefundID, again.RefundID, "same idempotency key, same refund")
+
+	_, err = gateway.Refund(context.Background(), port.RefundRequest{
+		IntentID:       intent.IntentID,
+		Amount:         money.New(600, "USD"),
+		IdempotencyKey: "refund-2",
+	})
+	assert.Error(t, err, "only 400 is left to refund")
+}
@@ -0,0 +1,172 @@
+package shipping
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+)
+
+const (
+	FakeCarrierName = "fake"
+	signaturePrefix = "sha256="
+)
+
+// fakeTrackingUpdate is the callback body sent by the fake carrier
+type fakeTrackingUpdate struct {
+	TrackingNumber string                `json:"trackingNumber"`
+	EventID        string                `json:"eventId"`
+	Status         domainshipment.Status `json:"status"`
+	Description    string                `json:"description,omitempty"`
+	Location       string                `json:"location,omitempty"`
+	OccurredAt     time.Time             `json:"occurredAt"`
+}
+
+// ScriptedEvent is a scan the fake carrier reports for its parcels
+type ScriptedEvent struct {
+	Status      domainshipment.Status
+	Description string
+	Location    string
+}
+
+// DefaultScript takes a parcel from pickup to the customer's door
+var DefaultScript = []ScriptedEvent{
+	{Status: domainshipment.StatusInTransit, Description: "Picked up by the carrier", Location: "Origin warehouse"},
+	{Status: domainshipment.StatusInTransit, Description: "Arrived at the sorting center", Location: "Regional hub"},
+	{Status: domainshipment.StatusOutForDelivery, Description: "Out for delivery", Location: "Local depot"},
+	{Status: domainshipment.StatusDelivered, Description: "Delivered to the recipient", Location: "Destination"},
+}
+
+// FakeCarrier is an in-memory carrier for local development and tests.
+// Nothing leaves the process: every parcel follows the same script, one scan
+// per call to SimulateTracking, which produces the tracking update a real
+// carrier would post.
+//
+// Tracking updates are signed with HMAC-SHA256 over the raw body using the
+// webhook secret and sent as "sha256=<hex>".
+type FakeCarrier struct {
+	secret []byte
+	script []ScriptedEvent
+
+	mu       sync.Mutex
+	parcels  map[string]int // Scans reported so far by tracking number
+	bookings map[string]string
+}
+
+// Ensure FakeCarrier implements the carrier port
+var _ port.Carrier = (*FakeCarrier)(nil)
+
+// NewFakeCarrier creates a carrier signing its tracking updates with
+// webhookSecret and following script, or DefaultScript when script is empty
+func NewFakeCarrier(webhookSecret string, script []ScriptedEvent) *FakeCarrier {
+	if len(script) == 0 {
+		script = DefaultScript
+	}
+	return &FakeCarrier{
+		secret:   []byte(webhookSecret),
+		script:   script,
+		parcels:  make(map[string]int),
+		bookings: make(map[string]string),
+	}
+}
+
+func (c *FakeCarrier) Name() string {
+	return FakeCarrierName
+}
+
+func (c *FakeCarrier) CreateShipment(ctx context.Context, req port.ShipmentRequest) (*port.ShipmentResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	trackingNumber, ok := c.bookings[req.Reference]
+	if !ok {
+		trackingNumber = "FAKE" + strings.ToUpper(randomHex(8))
+		c.parcels[trackingNumber] = 0
+		if req.Reference != "" {
+			c.bookings[req.Reference] = trackingNumber
+		}
+	}
+	return &port.ShipmentResult{TrackingNumber: trackingNumber}, nil
+}
+
+func (c *FakeCarrier) ParseTrackingUpdate(payload []byte, signature string) (*port.TrackingUpdate, error) {
+	if !hmac.Equal([]byte(signature), []byte(c.Sign(payload))) {
+		return nil, domainshipment.ErrInvalidSignature
+	}
+
+	var update fakeTrackingUpdate
+	if err := json.Unmarshal(payload, &update); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainshipment.ErrInvalidTrackingEvent, err)
+	}
+	if update.TrackingNumber == "" || update.EventID == "" || update.OccurredAt.IsZero() {
+		return nil, fmt.Errorf("%w: trackingNumber, eventId and occurredAt are required", domainshipment.ErrInvalidTrackingEvent)
+	}
+
+	return &port.TrackingUpdate{
+		TrackingNumber: update.TrackingNumber,
+		Event: domainshipment.TrackingEvent{
+			EventID:     update.EventID,
+			Status:      update.Status,
+			Description: update.Description,
+			Location:    update.Location,
+			OccurredAt:  update.OccurredAt,
+		},
+	}, nil
+}
+
+// Sign returns the signature header value the fake carrier sends with payload
+func (c *FakeCarrier) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SimulateTracking builds the signed callback reporting the next scan of the
+// script for a parcel. Parcels booked before a restart are unknown to the
+// fake.
+func (c *FakeCarrier) SimulateTracking(trackingNumber string) ([]byte, string, error) {
+	c.mu.Lock()
+	reported, ok := c.parcels[trackingNumber]
+	if ok && reported < len(c.script) {
+		c.parcels[trackingNumber] = reported + 1
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return nil, "", fmt.Errorf("%w: unknown parcel %s", domainshipment.ErrShipmentNotFound, trackingNumber)
+	}
+	if reported == len(c.script) {
+		return nil, "", fmt.Errorf("parcel %s has no scans left", trackingNumber)
+	}
+
+	scripted := c.script[reported]
+	payload, err := json.Marshal(fakeTrackingUpdate{
+		TrackingNumber: trackingNumber,
+		EventID:        "trk_fake_" + randomHex(12),
+		Status:         scripted.Status,
+		Description:    scripted.Description,
+		Location:       scripted.Location,
+		OccurredAt:     time.Now(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, c.Sign(payload), nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,46 @@
+package shipping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DuongVu089x/interview/order/application/port"
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeCarrierFollowsItsScript(t *testing.T) {
+	carrier := NewFakeCarrier("secret", []ScriptedEvent{
+		{Status: domainshipment.StatusInTransit},
+		{Status: domainshipment.StatusDelivered},
+	})
+
+	booked, err := carrier.CreateShipment(context.Background(), port.ShipmentRequest{Reference: "SHP-1-1"})
+	assert.NoError(t, err)
+	again, err := carrier.CreateShipment(context.Background(), port.ShipmentRequest{Reference: "SHP-1-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, booked.TrackingNumber, again.TrackingNumber)
+
+	payload, signature, err := carrier.SimulateTracking(booked.TrackingNumber)
+	assert.NoError(t, err)
+	first, err := carrier.ParseTrackingUpdate(payload, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, booked.TrackingNumber, first.TrackingNumber)
+	assert.Equal(t, domainshipment.StatusInTransit, first.Event.Status)
+
+	payload, signature, err = carrier.SimulateTracking(booked.TrackingNumber)
+	assert.NoError(t, err)
+	second, err := carrier.ParseTrackingUpdate(payload, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, domainshipment.StatusDelivered, second.Event.Status)
+	assert.NotEqual(t, first.Event.EventID, second.Event.EventID)
+
+	_, err = carrier.ParseTrackingUpdate(payload, NewFakeCarrier("other", nil).Sign(payload))
+	assert.ErrorIs(t, err, domainshipment.ErrInvalidSignature)
+
+	_, _, err = carrier.SimulateTracking(booked.TrackingNumber)
+	assert.Error(t, err)
+
+	_, _, err = carrier.SimulateTracking("UNKNOWN")
+	assert.ErrorIs(t, err, domainshipment.ErrShipmentNotFound)
+}
@@ -4,15 +4,55 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
 	"time"
 
+	ordergrpchandler "github.com/DuongVu089x/interview/order/api/grpc/order"
 	"github.com/DuongVu089x/interview/order/api/middleware"
+	"github.com/DuongVu089x/interview/order/api/rest/cart"
+	"github.com/DuongVu089x/interview/order/api/rest/inventory"
 	"github.com/DuongVu089x/interview/order/api/rest/order"
+	"github.com/DuongVu089x/interview/order/api/rest/payment"
+	"github.com/DuongVu089x/interview/order/api/rest/product"
+	"github.com/DuongVu089x/interview/order/api/rest/rule"
+	"github.com/DuongVu089x/interview/order/api/rest/saga"
+	"github.com/DuongVu089x/interview/order/api/rest/shipment"
+	"github.com/DuongVu089x/interview/order/api/rest/voucher"
+	expiryusecase "github.com/DuongVu089x/interview/order/application/expiry"
+	orderusecase "github.com/DuongVu089x/interview/order/application/order"
+	outboxusecase "github.com/DuongVu089x/interview/order/application/outbox"
+	"github.com/DuongVu089x/interview/order/application/port"
+	riskusecase "github.com/DuongVu089x/interview/order/application/risk"
+	ruleusecase "github.com/DuongVu089x/interview/order/application/rule"
+	sagausecase "github.com/DuongVu089x/interview/order/application/saga"
+	"github.com/DuongVu089x/interview/order/application/watch"
 	"github.com/DuongVu089x/interview/order/component/appctx"
 	"github.com/DuongVu089x/interview/order/config"
+	domainidgen "github.com/DuongVu089x/interview/order/domain/id_gen"
+	domainrisk "github.com/DuongVu089x/interview/order/domain/risk"
 	"github.com/DuongVu089x/interview/order/infrastructure/kafka"
+	paymentinfra "github.com/DuongVu089x/interview/order/infrastructure/payment"
+	"github.com/DuongVu089x/interview/order/infrastructure/shipping"
 	pb "github.com/DuongVu089x/interview/order/proto/customer"
+	orderpb "github.com/DuongVu089x/interview/order/proto/order"
+	historyrepository "github.com/DuongVu089x/interview/order/repository/history"
+	idgenrepository "github.com/DuongVu089x/interview/order/repository/id_gen"
+	inventoryrepository "github.com/DuongVu089x/interview/order/repository/inventory"
+	orderrepository "github.com/DuongVu089x/interview/order/repository/order"
+	outboxrepository "github.com/DuongVu089x/interview/order/repository/outbox"
+	paymentrepository "github.com/DuongVu089x/interview/order/repository/payment"
+	productrepository "github.com/DuongVu089x/interview/order/repository/product"
+	riskrepository "github.com/DuongVu089x/interview/order/repository/risk"
+	rulerepository "github.com/DuongVu089x/interview/order/repository/rule"
+	sagarepository "github.com/DuongVu089x/interview/order/repository/saga"
+	shipmentrepository "github.com/DuongVu089x/interview/order/repository/shipment"
+	voucherrepository "github.com/DuongVu089x/interview/order/repository/voucher"
+	idgenservice "github.com/DuongVu089x/interview/order/service/id_gen"
+	orderservice "github.com/DuongVu089x/interview/order/service/order"
+	ruleservice "github.com/DuongVu089x/interview/order/service/rule"
+	sagaservice "github.com/DuongVu089x/interview/order/service/saga"
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -147,6 +187,42 @@ func initKafkaConsumer(cfg *config.Config) (*kafka.RetryableConsumer, error) {
 	return consumer, nil
 }
 
+// Function to start the consumer feeding order events to the WatchOrder streams
+// of this instance
+func startOrderWatchConsumer(ctx context.Context, cfg *config.Config, hub *watch.Hub) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %v", err)
+	}
+
+	// Every instance needs every event, so each one consumes in its own group
+	// and only from the latest offset
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		BootstrapServers: cfg.Kafka.BootstrapServers,
+		SecurityProtocol: cfg.Kafka.SecurityProtocol,
+		GroupID:          fmt.Sprintf("order-watch-%s", hostname),
+		AutoOffsetReset:  "latest",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer: %s", err)
+	}
+
+	if err := consumer.RegisterHandler("orders-topic", hub.HandleMessage); err != nil {
+		consumer.Close()
+		return fmt.Errorf("failed to subscribe to orders-topic: %s", err)
+	}
+
+	go func() {
+		defer consumer.Close()
+
+		if err := consumer.Start(ctx); err != nil && err != context.Canceled {
+			log.Printf("Order watch consumer stopped: %s", err)
+		}
+	}()
+
+	return nil
+}
+
 func initRedis(cfg *config.Config) (*redis.Client, error) {
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     cfg.Redis.Addr,
@@ -172,9 +248,179 @@ func initCustomerClient(cfg *config.Config) (pb.CustomerServiceClient, error) {
 	return pb.NewCustomerServiceClient(conn), nil
 }
 
+// Function to initialize the payment gateway and refund provider selected by configuration
+func initPaymentGateway(cfg *config.Config) (port.PaymentGateway, port.RefundProvider, error) {
+	switch cfg.Payment.Provider {
+	case paymentinfra.FakeProviderName:
+		if cfg.DevMode {
+			log.Printf("Using the fake payment gateway, payments are settled through /admin/payments/fake")
+		} else {
+			log.Printf("Using the fake payment gateway, set DEV_MODE to settle payments through /admin/payments/fake")
+		}
+		gateway := paymentinfra.NewFakeGateway(cfg.Payment.WebhookSecret)
+		return gateway, gateway, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported payment provider %q", cfg.Payment.Provider)
+	}
+}
+
+// Function to initialize the shipping carrier selected by configuration
+func initCarrier(cfg *config.Config) (port.Carrier, error) {
+	switch cfg.Shipping.Carrier {
+	case shipping.FakeCarrierName:
+		if cfg.DevMode {
+			log.Printf("Using the fake carrier, parcels are moved along through /admin/shipments/fake")
+		} else {
+			log.Printf("Using the fake carrier, set DEV_MODE to move parcels along through /admin/shipments/fake")
+		}
+		return shipping.NewFakeCarrier(cfg.Shipping.WebhookSecret, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported shipping carrier %q", cfg.Shipping.Carrier)
+	}
+}
+
+// Function to initialize the ID generator on the backend selected by configuration
+func initIDGen(cfg *config.Config, mainDB *mongo.Client, redisClient *redis.Client) (domainidgen.Service, error) {
+	orderCodes, err := domainidgen.NewCodeFormat(cfg.OrderCode.Prefix, cfg.OrderCode.Alphabet, cfg.OrderCode.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	mongoRepo := idgenrepository.NewMongoRepository(mainDB)
+
+	var allocator domainidgen.BlockAllocator
+	switch cfg.IDGen.Backend {
+	case "mongo":
+		allocator = mongoRepo
+	case "redis":
+		// Continue from the Mongo counters so no ID is issued twice
+		allocator = idgenrepository.NewRedisRepository(redisClient, mongoRepo)
+	default:
+		return nil, fmt.Errorf("unsupported ID generator backend %q", cfg.IDGen.Backend)
+	}
+
+	idgenRepo := idgenrepository.NewBlockRepository(allocator, int64(cfg.IDGen.BlockSize))
+	return idgenservice.NewIDGenService(idgenRepo, orderCodes), nil
+}
+
+// Function to initialize gRPC server
+func initGrpcServer(appCtx appctx.AppContext, cfg *config.Config, orderUseCase *orderusecase.UseCase, watchHub *watch.Hub) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+
+	// Register the order service on the use case the REST API uses
+	server := grpc.NewServer()
+	orderpb.RegisterOrderServiceServer(server, ordergrpchandler.NewGrpcHandler(appCtx, orderUseCase, watchHub))
+
+	go func() {
+		log.Printf("Starting gRPC server on port %s", cfg.GRPC.Port)
+		if err := server.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve gRPC: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Function to start the outbox relay that publishes pending order events
+func startOutboxRelay(ctx context.Context, cfg *config.Config, appCtx appctx.AppContext) {
+	relay := outboxusecase.NewRelay(
+		outboxrepository.NewMongoRepository(appCtx.GetMainDBConnection()),
+		appCtx.GetKafkaProducer(),
+		outboxusecase.RelayConfig{
+			PollInterval: cfg.Outbox.PollInterval,
+			BatchSize:    cfg.Outbox.BatchSize,
+			MaxAttempts:  cfg.Outbox.MaxAttempts,
+		},
+	)
+
+	go relay.Start(ctx)
+}
+
+// Function to start the worker that expires unpaid pending orders
+func startOrderExpiry(ctx context.Context, cfg *config.Config, appCtx appctx.AppContext, expirer expiryusecase.OrderExpirer) {
+	orderRepo := orderrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	worker := expiryusecase.NewWorker(
+		orderservice.NewOrderService(orderRepo),
+		expirer,
+		expiryusecase.WorkerConfig{
+			Interval:  cfg.OrderExpiry.Interval,
+			TTL:       cfg.OrderExpiry.PendingTTL,
+			BatchSize: cfg.OrderExpiry.BatchSize,
+		},
+	)
+
+	go worker.Start(ctx)
+}
+
+// Function to initialize the orchestrator that runs and resumes sagas
+func initSagaOrchestrator(cfg *config.Config, appCtx appctx.AppContext) *sagausecase.Orchestrator {
+	sagaRepo := sagarepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	return sagausecase.NewOrchestrator(
+		sagaservice.NewSagaService(sagaRepo),
+		sagausecase.Config{
+			StepTimeout:             cfg.Saga.StepTimeout,
+			LeaseDuration:           cfg.Saga.LeaseDuration,
+			MaxCompensationAttempts: cfg.Saga.MaxCompensationAttempts,
+			RecoveryInterval:        cfg.Saga.RecoveryInterval,
+			RecoveryBatchSize:       cfg.Saga.RecoveryBatchSize,
+		},
+	)
+}
+
+// Function to initialize the engine enforcing the order rules, with the rules
+// loaded so that no order is placed before them
+func initRuleEngine(cfg *config.Config, appCtx appctx.AppContext) (*ruleusecase.Engine, error) {
+	ruleRepo := rulerepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+	engine := ruleusecase.NewEngine(
+		ruleservice.NewRuleService(ruleRepo),
+		ruleusecase.EngineConfig{
+			ReloadInterval: cfg.OrderRules.ReloadInterval,
+		},
+	)
+	if err := engine.Reload(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to load order rules: %v", err)
+	}
+	return engine, nil
+}
+
+// Function to initialize the risk assessor selected by configuration; nil
+// places orders without assessing them
+func initRiskAssessor(cfg *config.Config, appCtx appctx.AppContext) (port.RiskAssessor, error) {
+	switch cfg.Risk.Assessor {
+	case "heuristic":
+		orderRepo := orderrepository.NewMongoRepository(appCtx.GetMainDBConnection(), appCtx.GetReadMainDBConnection())
+		return riskusecase.NewHeuristicScorer(
+			riskrepository.NewRedisRepository(appCtx.GetRedisClient()),
+			orderservice.NewOrderService(orderRepo),
+			riskusecase.ScorerConfig{
+				VelocityWindow: cfg.Risk.VelocityWindow,
+				VelocityLimit:  cfg.Risk.VelocityLimit,
+				OutlierFactor:  float64(cfg.Risk.OutlierFactor),
+				NewAccountAge:  cfg.Risk.NewAccountAge,
+				Thresholds: domainrisk.Thresholds{
+					Medium: cfg.Risk.MediumScore,
+					High:   cfg.Risk.HighScore,
+				},
+			},
+		), nil
+	case "none":
+		log.Printf("Risk assessment is disabled, no order is held for review")
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported risk assessor %q", cfg.Risk.Assessor)
+	}
+}
+
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+		return
+	}
 
 	// Initialize infrastructure
 	mainDB, err := initMainDB(cfg)
@@ -183,6 +429,47 @@ func main() {
 		return
 	}
 
+	if err := orderrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create order indexes: %v", err)
+		return
+	}
+	if err := historyrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create order history indexes: %v", err)
+		return
+	}
+	if err := paymentrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create payment indexes: %v", err)
+		return
+	}
+	if err := sagarepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create saga indexes: %v", err)
+		return
+	}
+	if err := shipmentrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create shipment indexes: %v", err)
+		return
+	}
+	if err := rulerepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create order rule indexes: %v", err)
+		return
+	}
+	if err := inventoryrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create inventory indexes: %v", err)
+		return
+	}
+	if err := productrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create product indexes: %v", err)
+		return
+	}
+	if err := voucherrepository.EnsureIndexes(context.Background(), mainDB); err != nil {
+		log.Fatalf("Failed to create voucher indexes: %v", err)
+		return
+	}
+	if err := outboxrepository.EnsureIndexes(context.Background(), mainDB, cfg.Outbox.SentRetention); err != nil {
+		log.Fatalf("Failed to create outbox indexes: %v", err)
+		return
+	}
+
 	readDB, err := initReadDB(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize read database: %v", err)
@@ -216,6 +503,24 @@ func main() {
 		return
 	}
 
+	paymentGateway, refundProvider, err := initPaymentGateway(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize payment gateway: %v", err)
+		return
+	}
+
+	carrier, err := initCarrier(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize shipping carrier: %v", err)
+		return
+	}
+
+	idgenService, err := initIDGen(cfg, mainDB, redisClient)
+	if err != nil {
+		log.Fatalf("Failed to initialize ID generator: %v", err)
+		return
+	}
+
 	// Initialize application context
 	appctx := appctx.NewAppContext(
 		mainDB,
@@ -224,8 +529,30 @@ func main() {
 		nil,
 		redisClient,
 		customerClient,
+		paymentGateway,
+		refundProvider,
+		carrier,
 	)
 
+	// Start background workers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startOutboxRelay(ctx, cfg, appctx)
+
+	ruleEngine, err := initRuleEngine(cfg, appctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize order rules: %v", err)
+		return
+	}
+	go ruleEngine.Start(ctx)
+
+	riskAssessor, err := initRiskAssessor(cfg, appctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize risk assessment: %v", err)
+		return
+	}
+
 	// Initialize Echo framework
 	e := echo.New()
 
@@ -239,10 +566,43 @@ func main() {
 	})
 
 	// Initialize handlers
-	orderHandler := order.NewHandler(appctx)
+	orchestrator := initSagaOrchestrator(cfg, appctx)
+	orderHandler := order.NewHandler(appctx, cfg, idgenService, orchestrator, ruleEngine, riskAssessor)
+	productHandler := product.NewHandler(appctx)
+	inventoryHandler := inventory.NewHandler(appctx)
+	voucherHandler := voucher.NewHandler(appctx)
+	paymentHandler := payment.NewHandler(appctx, orderHandler.OrderUseCase())
+	sagaHandler := saga.NewHandler(appctx)
+	cartHandler := cart.NewHandler(appctx, cfg, orderHandler.OrderUseCase())
+	shipmentHandler := shipment.NewHandler(appctx, cfg, orderHandler.OrderUseCase())
+	ruleHandler := rule.NewHandler(appctx, ruleEngine)
+
+	// Sagas are resumed once every saga type is registered by the handlers
+	go orchestrator.Start(ctx)
+
+	startOrderExpiry(ctx, cfg, appctx, orderHandler.OrderUseCase())
+
+	watchHub := watch.NewHub()
+	if err := startOrderWatchConsumer(ctx, cfg, watchHub); err != nil {
+		log.Fatalf("Failed to start order watch consumer: %v", err)
+		return
+	}
+
+	if err := initGrpcServer(appctx, cfg, orderHandler.OrderUseCase(), watchHub); err != nil {
+		log.Fatalf("Failed to initialize gRPC server: %v", err)
+		return
+	}
 
 	// Register routes
 	order.RegisterRoutes(e, orderHandler)
+	product.RegisterRoutes(e, productHandler)
+	inventory.RegisterRoutes(e, inventoryHandler)
+	voucher.RegisterRoutes(e, voucherHandler)
+	payment.RegisterRoutes(e, paymentHandler, cfg.DevMode)
+	saga.RegisterRoutes(e, sagaHandler)
+	cart.RegisterRoutes(e, cartHandler)
+	shipment.RegisterRoutes(e, shipmentHandler, cfg.DevMode)
+	rule.RegisterRoutes(e, ruleHandler)
 
 	// Print all registered routes for debugging
 	middleware.PrintRegisteredRoutes(e)
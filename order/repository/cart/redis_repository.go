@@ -0,0 +1,95 @@
+package cart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domaincart "github.com/DuongVu089x/interview/order/domain/cart"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "cart:"
+
+	// maxUpdateAttempts bounds the retries of an update racing other updates
+	maxUpdateAttempts = 5
+)
+
+type RedisRepository struct {
+	client *redis.Client
+}
+
+func NewRedisRepository(client *redis.Client) domaincart.Repository {
+	return &RedisRepository{
+		client: client,
+	}
+}
+
+func (r *RedisRepository) GetCart(ctx context.Context, userID string) (*domaincart.Cart, error) {
+	return getCart(ctx, r.client, userID)
+}
+
+// UpdateCart watches the cart key so that the update is dropped and retried
+// when another one saves the cart first
+func (r *RedisRepository) UpdateCart(ctx context.Context, userID string, ttl time.Duration, fn func(cart *domaincart.Cart) error) (*domaincart.Cart, error) {
+	key := keyPrefix + userID
+
+	var updated *domaincart.Cart
+	txf := func(tx *redis.Tx) error {
+		cart, err := getCart(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if cart == nil {
+			cart = domaincart.NewCart(userID)
+		}
+		if err := fn(cart); err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(cart)
+		if err != nil {
+			return fmt.Errorf("failed to marshal cart: %w", err)
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, value, ttl)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		updated = cart
+		return nil
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+	return nil, domaincart.ErrCartBusy
+}
+
+func getCart(ctx context.Context, client redis.Cmdable, userID string) (*domaincart.Cart, error) {
+	value, err := client.Get(ctx, keyPrefix+userID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	var cart domaincart.Cart
+	if err := json.Unmarshal(value, &cart); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cart: %w", err)
+	}
+	return &cart, nil
+}
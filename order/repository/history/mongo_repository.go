@@ -0,0 +1,54 @@
+package history
+
+import (
+	"context"
+
+	domainhistory "github.com/DuongVu089x/interview/order/domain/history"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName   = "orders"
+	collectionName = "order_history"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainhistory.Repository {
+	return &MongoRepository{
+		BaseAdapter: mongodb.NewBaseAdapter(writeDB, readDB, databaseName),
+	}
+}
+
+func (r *MongoRepository) CreateEntry(ctx context.Context, entry *domainhistory.Entry) error {
+	return r.GetWriteDB().Insert(ctx, collectionName, entry)
+}
+
+func (r *MongoRepository) GetEntries(ctx context.Context, orderID int64) ([]domainhistory.Entry, error) {
+	// History is read straight after a change by support tooling, so read from the primary
+	var entries []domainhistory.Entry
+	err := r.GetWriteDB().Query(
+		ctx,
+		collectionName,
+		bson.M{"order_id": orderID},
+		&entries,
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// EnsureIndexes creates the indexes the history queries rely on
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	)
+}
@@ -0,0 +1,58 @@
+package id_gen
+
+import (
+	"sync"
+
+	domainidgen "github.com/DuongVu089x/interview/order/domain/id_gen"
+)
+
+// block is the part of a leased range not handed out yet
+type block struct {
+	next int64
+	last int64
+}
+
+// BlockRepository hands out IDs from blocks leased from an allocator, one
+// round trip per block instead of per ID. IDs left in a block are lost when
+// the process stops, so IDs have gaps and are only increasing per process.
+type BlockRepository struct {
+	allocator domainidgen.BlockAllocator
+	blockSize int64
+
+	mu     sync.Mutex
+	blocks map[string]*block
+}
+
+func NewBlockRepository(allocator domainidgen.BlockAllocator, blockSize int64) *BlockRepository {
+	if blockSize < 1 {
+		blockSize = 1
+	}
+
+	return &BlockRepository{
+		allocator: allocator,
+		blockSize: blockSize,
+		blocks:    make(map[string]*block),
+	}
+}
+
+func (r *BlockRepository) GenerateID(key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.blocks[key]
+	if current == nil || current.next > current.last {
+		// Callers wait for the lease, which happens once per block
+		last, err := r.allocator.AllocateBlock(key, r.blockSize)
+		if err != nil {
+			return 0, err
+		}
+		current = &block{next: last - r.blockSize + 1, last: last}
+		r.blocks[key] = current
+	}
+
+	id := current.next
+	current.next++
+	return id, nil
+}
+
+var _ domainidgen.Repository = (*BlockRepository)(nil)
@@ -0,0 +1,83 @@
+package id_gen
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAllocator leases consecutive blocks per key like the real backends
+type fakeAllocator struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	leases   int
+	err      error
+}
+
+func (a *fakeAllocator) AllocateBlock(key string, size int64) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.err != nil {
+		return 0, a.err
+	}
+	a.leases++
+	a.counters[key] += size
+	return a.counters[key], nil
+}
+
+func TestGenerateIDLeasesBlocks(t *testing.T) {
+	allocator := &fakeAllocator{counters: map[string]int64{"ORDER": 41}}
+	repo := NewBlockRepository(allocator, 10)
+
+	for want := int64(42); want <= 61; want++ {
+		id, err := repo.GenerateID("ORDER")
+		assert.NoError(t, err)
+		assert.Equal(t, want, id)
+	}
+	assert.Equal(t, 2, allocator.leases)
+
+	id, err := repo.GenerateID("USER")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
+
+func TestGenerateIDIsUniqueUnderConcurrency(t *testing.T) {
+	allocator := &fakeAllocator{counters: map[string]int64{}}
+	repo := NewBlockRepository(allocator, 7)
+
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				id, err := repo.GenerateID("ORDER")
+				assert.NoError(t, err)
+				mu.Lock()
+				assert.False(t, seen[id], "duplicate ID %d", id)
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 1000)
+}
+
+func TestGenerateIDRetriesAfterAFailedLease(t *testing.T) {
+	allocator := &fakeAllocator{counters: map[string]int64{}, err: errors.New("primary unavailable")}
+	repo := NewBlockRepository(allocator, 10)
+
+	_, err := repo.GenerateID("ORDER")
+	assert.Error(t, err)
+
+	allocator.err = nil
+	id, err := repo.GenerateID("ORDER")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
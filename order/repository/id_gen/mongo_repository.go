@@ -2,6 +2,7 @@ package id_gen
 
 import (
 	"context"
+	"errors"
 
 	domainidgen "github.com/DuongVu089x/interview/order/domain/id_gen"
 	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
@@ -19,7 +20,7 @@ const (
 	collectionName = "id_gen"
 )
 
-func NewMongoRepository(writeDB *mongo.Client) domainidgen.Repository {
+func NewMongoRepository(writeDB *mongo.Client) *MongoRepository {
 	writeAdapter := mongodb.NewMongoAdapter(writeDB, databaseName)
 
 	return &MongoRepository{
@@ -28,13 +29,17 @@ func NewMongoRepository(writeDB *mongo.Client) domainidgen.Repository {
 }
 
 func (r *MongoRepository) GenerateID(key string) (int64, error) {
+	return r.AllocateBlock(key, 1)
+}
+
+func (r *MongoRepository) AllocateBlock(key string, size int64) (int64, error) {
 	var idGen domainidgen.IDGen
 
 	err := r.writeDB.FindOneAndUpdate(
 		context.Background(),
 		collectionName,
 		bson.M{"key": key},
-		bson.M{"$inc": bson.M{"value": 1}},
+		bson.M{"$inc": bson.M{"value": size}},
 		&idGen,
 		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
 	)
@@ -44,3 +49,23 @@ func (r *MongoRepository) GenerateID(key string) (int64, error) {
 
 	return idGen.Value, nil
 }
+
+// CurrentID returns the last ID allocated for the key, or 0 if none was
+func (r *MongoRepository) CurrentID(key string) (int64, error) {
+	var idGen domainidgen.IDGen
+
+	err := r.writeDB.QueryOne(context.Background(), collectionName, bson.M{"key": key}, &idGen)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return idGen.Value, nil
+}
+
+var (
+	_ domainidgen.Repository     = (*MongoRepository)(nil)
+	_ domainidgen.BlockAllocator = (*MongoRepository)(nil)
+)
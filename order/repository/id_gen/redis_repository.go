@@ -0,0 +1,84 @@
+package id_gen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	domainidgen "github.com/DuongVu089x/interview/order/domain/id_gen"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "id_gen:"
+
+// raiseTo lifts a counter to at least ARGV[1] without ever lowering it
+var raiseTo = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+if current < tonumber(ARGV[1]) then
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// RedisRepository allocates IDs with INCRBY. Redis must persist its data (AOF)
+// as a lost counter would restart below IDs already issued.
+type RedisRepository struct {
+	client *redis.Client
+
+	// seed holds the counters used before Redis; every key is raised above
+	// its seed before the first allocation of this process
+	seed   *MongoRepository
+	mu     sync.Mutex
+	seeded map[string]bool
+}
+
+func NewRedisRepository(client *redis.Client, seed *MongoRepository) *RedisRepository {
+	return &RedisRepository{
+		client: client,
+		seed:   seed,
+		seeded: make(map[string]bool),
+	}
+}
+
+func (r *RedisRepository) GenerateID(key string) (int64, error) {
+	return r.AllocateBlock(key, 1)
+}
+
+func (r *RedisRepository) AllocateBlock(key string, size int64) (int64, error) {
+	ctx := context.Background()
+
+	if err := r.ensureSeeded(ctx, key); err != nil {
+		return 0, err
+	}
+
+	last, err := r.client.IncrBy(ctx, keyPrefix+key, size).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate IDs for %s: %w", key, err)
+	}
+	return last, nil
+}
+
+func (r *RedisRepository) ensureSeeded(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.seeded[key] || r.seed == nil {
+		return nil
+	}
+
+	floor, err := r.seed.CurrentID(key)
+	if err != nil {
+		return fmt.Errorf("failed to read the seed of %s: %w", key, err)
+	}
+	if err := raiseTo.Run(ctx, r.client, []string{keyPrefix + key}, floor).Err(); err != nil {
+		return fmt.Errorf("failed to seed IDs for %s: %w", key, err)
+	}
+
+	r.seeded[key] = true
+	return nil
+}
+
+var (
+	_ domainidgen.Repository     = (*RedisRepository)(nil)
+	_ domainidgen.BlockAllocator = (*RedisRepository)(nil)
+)
@@ -0,0 +1,197 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName              = "orders"
+	stockCollectionName       = "inventory_stocks"
+	reservationCollectionName = "inventory_reservations"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domaininventory.Repository {
+	baseAdapter := mongodb.NewBaseAdapter(writeDB, readDB, databaseName)
+	return &MongoRepository{
+		BaseAdapter: baseAdapter,
+	}
+}
+
+func (r *MongoRepository) GetStock(ctx context.Context, productID string) (*domaininventory.Stock, error) {
+	var stock domaininventory.Stock
+	err := r.GetReadDB().QueryOne(ctx, stockCollectionName, bson.M{"product_id": productID}, &stock)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domaininventory.ErrStockNotFound
+		}
+		return nil, err
+	}
+	return &stock, nil
+}
+
+func (r *MongoRepository) GetStocks(ctx context.Context) ([]domaininventory.Stock, error) {
+	var stocks []domaininventory.Stock
+	err := r.GetReadDB().Query(
+		ctx,
+		stockCollectionName,
+		bson.M{},
+		&stocks,
+		options.Find().SetSort(bson.M{"product_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+func (r *MongoRepository) SetAvailable(ctx context.Context, productID string, available int) (*domaininventory.Stock, error) {
+	now := time.Now()
+
+	var stock domaininventory.Stock
+	err := r.GetWriteDB().FindOneAndUpdate(
+		ctx,
+		stockCollectionName,
+		bson.M{"product_id": productID},
+		bson.M{
+			"$set":         bson.M{"available": available, "updated_at": now},
+			"$setOnInsert": bson.M{"reserved": 0, "created_at": now},
+		},
+		&stock,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
+
+func (r *MongoRepository) AdjustAvailable(ctx context.Context, productID string, delta int) (*domaininventory.Stock, error) {
+	filter := bson.M{"product_id": productID}
+	if delta < 0 {
+		filter["available"] = bson.M{"$gte": -delta}
+	}
+
+	var stock domaininventory.Stock
+	err := r.GetWriteDB().FindOneAndUpdate(
+		ctx,
+		stockCollectionName,
+		filter,
+		bson.M{
+			"$inc": bson.M{"available": delta},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+		&stock,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			if _, getErr := r.GetStock(ctx, productID); getErr != nil {
+				return nil, getErr
+			}
+			return nil, domaininventory.ErrInsufficientStock
+		}
+		return nil, err
+	}
+	return &stock, nil
+}
+
+func (r *MongoRepository) Reserve(ctx context.Context, productID string, quantity int) error {
+	return r.move(ctx, productID, quantity, "available", "reserved")
+}
+
+func (r *MongoRepository) Release(ctx context.Context, productID string, quantity int) error {
+	return r.move(ctx, productID, quantity, "reserved", "available")
+}
+
+func (r *MongoRepository) Commit(ctx context.Context, productID string, quantity int) error {
+	return r.move(ctx, productID, quantity, "reserved", "")
+}
+
+// move takes quantity from one counter and, unless to is empty, adds it to
+// another in a single conditional update so that from never goes negative
+func (r *MongoRepository) move(ctx context.Context, productID string, quantity int, from, to string) error {
+	inc := bson.M{from: -quantity}
+	if to != "" {
+		inc[to] = quantity
+	}
+
+	var stock domaininventory.Stock
+	err := r.GetWriteDB().FindOneAndUpdate(
+		ctx,
+		stockCollectionName,
+		bson.M{"product_id": productID, from: bson.M{"$gte": quantity}},
+		bson.M{
+			"$inc": inc,
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+		&stock,
+	)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("%w: product %s", domaininventory.ErrInsufficientStock, productID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *MongoRepository) CreateReservations(ctx context.Context, reservations []domaininventory.Reservation) error {
+	documents := make([]any, len(reservations))
+	for i := range reservations {
+		documents[i] = reservations[i]
+	}
+	return r.GetWriteDB().Insert(ctx, reservationCollectionName, documents...)
+}
+
+func (r *MongoRepository) GetReservationsByOrder(ctx context.Context, orderID int64, status domaininventory.ReservationStatus) ([]domaininventory.Reservation, error) {
+	filter := bson.M{"order_id": orderID}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	// Reservations are read from the primary since they are usually read
+	// right before being released or committed
+	var reservations []domaininventory.Reservation
+	err := r.GetWriteDB().Query(ctx, reservationCollectionName, filter, &reservations)
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+func (r *MongoRepository) UpdateReservationsStatus(ctx context.Context, orderID int64, from, to domaininventory.ReservationStatus) error {
+	_, err := r.GetWriteDB().UpdateMany(
+		ctx,
+		reservationCollectionName,
+		bson.M{"order_id": orderID, "status": from},
+		bson.M{"$set": bson.M{"status": to, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+// EnsureIndexes creates the indexes of the inventory collections: one stock
+// document per product, and reservations looked up by order
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	if err := adapter.CreateIndexes(ctx, stockCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "product_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	); err != nil {
+		return err
+	}
+	return adapter.CreateIndexes(ctx, reservationCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "status", Value: 1}}},
+	)
+}
@@ -2,6 +2,7 @@ package order
 
 import (
 	"context"
+	"errors"
 
 	domainorder "github.com/DuongVu089x/interview/order/domain/order"
 	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
@@ -26,39 +27,73 @@ func NewMongoRepository(writeDB, readDB *mongo.Client) domainorder.Repository {
 	}
 }
 
-func (r *MongoRepository) GetOrder(id int64) (*domainorder.Order, error) {
+func (r *MongoRepository) GetOrder(ctx context.Context, id int64) (*domainorder.Order, error) {
+	return r.getOrder(ctx, r.GetReadDB(), id)
+}
+
+func (r *MongoRepository) GetOrderByCode(ctx context.Context, code string) (*domainorder.Order, error) {
 	var order domainorder.Order
 	err := r.GetReadDB().QueryOne(
-		context.Background(),
+		ctx,
+		collectionName,
+		bson.M{"order_code": code},
+		&order,
+	)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainorder.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *MongoRepository) GetOrderForUpdate(ctx context.Context, id int64) (*domainorder.Order, error) {
+	return r.getOrder(ctx, r.GetWriteDB(), id)
+}
+
+func (r *MongoRepository) getOrder(ctx context.Context, db *mongodb.MongoAdapter, id int64) (*domainorder.Order, error) {
+	var order domainorder.Order
+	err := db.QueryOne(
+		ctx,
 		collectionName,
 		bson.M{"order_id": id},
 		&order,
 	)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainorder.ErrOrderNotFound
+		}
 		return nil, err
 	}
 	return &order, nil
 }
 
-func (r *MongoRepository) GetOrders(conditions domainorder.Order) ([]domainorder.Order, error) {
-	filter := bson.M{}
-	if conditions.OrderID != 0 {
-		filter["order_id"] = conditions.OrderID
+func (r *MongoRepository) GetOrders(ctx context.Context, filter domainorder.Filter) ([]domainorder.Order, error) {
+	sortField := "created_at"
+	if filter.SortBy == domainorder.SortByTotalAmount {
+		sortField = "total_amount.amount"
 	}
-	if conditions.UserID != "" {
-		filter["user_id"] = conditions.UserID
+	direction := -1
+	if filter.Ascending {
+		direction = 1
 	}
-	if conditions.Status != "" {
-		filter["status"] = conditions.Status
+
+	opts := options.Find().SetSort(bson.D{
+		{Key: sortField, Value: direction},
+		{Key: "order_id", Value: direction},
+	})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
 	}
 
 	var orders []domainorder.Order
 	err := r.GetReadDB().Query(
-		context.Background(),
+		ctx,
 		collectionName,
-		filter,
+		toQuery(filter, sortField),
 		&orders,
-		options.Find().SetSort(bson.M{"created_at": -1}),
+		opts,
 	)
 	if err != nil {
 		return nil, err
@@ -67,17 +102,114 @@ func (r *MongoRepository) GetOrders(conditions domainorder.Order) ([]domainorder
 	return orders, nil
 }
 
-func (r *MongoRepository) CreateOrder(order *domainorder.Order) error {
-	return r.GetWriteDB().Insert(context.Background(), collectionName, order)
+// toQuery translates the filter into a MongoDB query on orders sorted by sortField
+func toQuery(filter domainorder.Filter, sortField string) bson.M {
+	query := bson.M{}
+	if filter.UserID != "" {
+		query["user_id"] = filter.UserID
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	createdAt := bson.M{}
+	if !filter.CreatedFrom.IsZero() {
+		createdAt["$gte"] = filter.CreatedFrom
+	}
+	if !filter.CreatedTo.IsZero() {
+		createdAt["$lte"] = filter.CreatedTo
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	var and bson.A
+	// Orders approved after a review wait for payment from their approval, see Order.PendingSince
+	if !filter.PendingBefore.IsZero() {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"risk.reviewed_at": bson.M{"$exists": false}, "created_at": bson.M{"$lt": filter.PendingBefore}},
+			bson.M{"risk.reviewed_at": bson.M{"$lt": filter.PendingBefore}},
+		}})
+	}
+
+	amount := bson.M{}
+	if filter.MinAmount != nil {
+		amount["$gte"] = filter.MinAmount.Amount
+		query["total_amount.currency"] = filter.MinAmount.Currency
+	}
+	if filter.MaxAmount != nil {
+		amount["$lte"] = filter.MaxAmount.Amount
+		query["total_amount.currency"] = filter.MaxAmount.Currency
+	}
+	if len(amount) > 0 {
+		query["total_amount.amount"] = amount
+	}
+
+	// Keyset pagination: continue strictly after the cursor in sort order
+	if cursor := filter.After; cursor != nil {
+		op := "$lt"
+		if filter.Ascending {
+			op = "$gt"
+		}
+		var value any = cursor.CreatedAt
+		if filter.SortBy == domainorder.SortByTotalAmount {
+			value = cursor.TotalAmount
+		}
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{sortField: bson.M{op: value}},
+			bson.M{sortField: value, "order_id": bson.M{op: cursor.OrderID}},
+		}})
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+
+	return query
 }
 
-func (r *MongoRepository) UpdateOrder(order *domainorder.Order) error {
-	filter := bson.M{"order_id": order.OrderID}
-	update := bson.M{"$set": order}
-	return r.GetWriteDB().Update(context.Background(), collectionName, filter, update)
+func (r *MongoRepository) CreateOrder(ctx context.Context, order *domainorder.Order) error {
+	return r.GetWriteDB().Insert(ctx, collectionName, order)
 }
 
-func (r *MongoRepository) DeleteOrder(id string) error {
+func (r *MongoRepository) UpdateOrder(ctx context.Context, order *domainorder.Order) error {
+	filter := bson.M{"order_id": order.OrderID, "version": order.Version}
+	if order.Version == 0 {
+		// Orders created before versioning have no version field yet
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	expected := order.Version
+	order.Version++
+
+	var updated domainorder.Order
+	err := r.GetWriteDB().FindOneAndUpdate(ctx, collectionName, filter, bson.M{"$set": order}, &updated)
+	if err == nil {
+		return nil
+	}
+
+	order.Version = expected
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	if _, err := r.GetOrderForUpdate(ctx, order.OrderID); err != nil {
+		return err
+	}
+	return &domainorder.VersionConflictError{OrderID: order.OrderID, Version: expected}
+}
+
+func (r *MongoRepository) DeleteOrder(ctx context.Context, id int64) error {
 	filter := bson.M{"order_id": id}
-	return r.GetWriteDB().Delete(context.Background(), collectionName, filter)
+	return r.GetWriteDB().Delete(ctx, collectionName, filter)
+}
+
+// EnsureIndexes creates the indexes the order queries rely on
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "order_code", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "order_id", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "total_amount.amount", Value: -1}, {Key: "order_id", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+	)
 }
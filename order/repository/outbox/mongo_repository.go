@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainoutbox "github.com/DuongVu089x/interview/order/domain/outbox"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	writeDB *mongodb.MongoAdapter
+}
+
+const (
+	databaseName   = "orders"
+	collectionName = "outbox"
+)
+
+func NewMongoRepository(writeDB *mongo.Client) domainoutbox.Repository {
+	return &MongoRepository{
+		writeDB: mongodb.NewMongoAdapter(writeDB, databaseName),
+	}
+}
+
+func (r *MongoRepository) CreateMessage(ctx context.Context, message *domainoutbox.Message) error {
+	return r.writeDB.Insert(ctx, collectionName, message)
+}
+
+func (r *MongoRepository) ClaimPending(ctx context.Context, now time.Time, leaseUntil time.Time) (*domainoutbox.Message, error) {
+	var message domainoutbox.Message
+	err := r.writeDB.FindOneAndUpdate(
+		ctx,
+		collectionName,
+		bson.M{
+			"status":          domainoutbox.StatusPending,
+			"next_attempt_at": bson.M{"$lte": now},
+		},
+		bson.M{"$set": bson.M{
+			"next_attempt_at": leaseUntil,
+			"updated_at":      now,
+		}},
+		&message,
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainoutbox.ErrNoPendingMessage
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *MongoRepository) MarkAsSent(ctx context.Context, id primitive.ObjectID, sentAt time.Time) error {
+	return r.writeDB.Update(ctx, collectionName, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"status":     domainoutbox.StatusSent,
+		"sent_at":    sentAt,
+		"updated_at": sentAt,
+	}})
+}
+
+func (r *MongoRepository) ScheduleRetry(ctx context.Context, id primitive.ObjectID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return r.writeDB.Update(ctx, collectionName, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+		"updated_at":      time.Now(),
+	}})
+}
+
+// EnsureIndexes creates the index relay claims run on and a TTL index
+// deleting messages once they have been sent for longer than sentRetention.
+// Pending and failed messages have no sent_at and are kept.
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client, sentRetention time.Duration) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "sent_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(sentRetention.Seconds())),
+		},
+	)
+}
+
+func (r *MongoRepository) MarkAsFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string) error {
+	return r.writeDB.Update(ctx, collectionName, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"status":     domainoutbox.StatusFailed,
+		"attempts":   attempts,
+		"last_error": lastError,
+		"updated_at": time.Now(),
+	}})
+}
@@ -0,0 +1,131 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName          = "orders"
+	intentCollectionName  = "payment_intents"
+	webhookCollectionName = "payment_webhook_events"
+	refundCollectionName  = "refunds"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainpayment.Repository {
+	return &MongoRepository{
+		BaseAdapter: mongodb.NewBaseAdapter(writeDB, readDB, databaseName),
+	}
+}
+
+func (r *MongoRepository) CreateIntent(ctx context.Context, intent *domainpayment.Intent) error {
+	return r.GetWriteDB().Insert(ctx, intentCollectionName, intent)
+}
+
+func (r *MongoRepository) GetIntent(ctx context.Context, intentID string) (*domainpayment.Intent, error) {
+	var intent domainpayment.Intent
+	err := r.GetWriteDB().QueryOne(ctx, intentCollectionName, bson.M{"intent_id": intentID}, &intent)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainpayment.ErrIntentNotFound
+		}
+		return nil, err
+	}
+	return &intent, nil
+}
+
+func (r *MongoRepository) GetIntentsByOrder(ctx context.Context, orderID int64) ([]domainpayment.Intent, error) {
+	var intents []domainpayment.Intent
+	err := r.GetWriteDB().Query(
+		ctx,
+		intentCollectionName,
+		bson.M{"order_id": orderID},
+		&intents,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return intents, nil
+}
+
+func (r *MongoRepository) UpdateIntent(ctx context.Context, intent *domainpayment.Intent) error {
+	return r.GetWriteDB().Update(ctx, intentCollectionName, bson.M{"intent_id": intent.IntentID}, bson.M{"$set": bson.M{
+		"status":         intent.Status,
+		"failure_reason": intent.FailureReason,
+		"refund_due":     intent.RefundDue,
+		"updated_at":     intent.UpdatedAt,
+	}})
+}
+
+func (r *MongoRepository) RecordWebhookEvent(ctx context.Context, event *domainpayment.WebhookEvent) error {
+	err := r.GetWriteDB().Insert(ctx, webhookCollectionName, event)
+	if mongo.IsDuplicateKeyError(err) {
+		return domainpayment.ErrDuplicateWebhook
+	}
+	return err
+}
+
+func (r *MongoRepository) CreateRefund(ctx context.Context, refund *domainpayment.Refund) error {
+	return r.GetWriteDB().Insert(ctx, refundCollectionName, refund)
+}
+
+func (r *MongoRepository) GetRefundByKey(ctx context.Context, idempotencyKey string) (*domainpayment.Refund, error) {
+	var refund domainpayment.Refund
+	err := r.GetWriteDB().QueryOne(ctx, refundCollectionName, bson.M{"idempotency_key": idempotencyKey}, &refund)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainpayment.ErrRefundNotFound
+		}
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *MongoRepository) GetRefundsByOrder(ctx context.Context, orderID int64) ([]domainpayment.Refund, error) {
+	var refunds []domainpayment.Refund
+	err := r.GetWriteDB().Query(
+		ctx,
+		refundCollectionName,
+		bson.M{"order_id": orderID},
+		&refunds,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// EnsureIndexes creates the indexes the payment queries, webhook
+// deduplication and refund idempotency rely on
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	err := adapter.CreateIndexes(ctx, intentCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "intent_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}}},
+	)
+	if err != nil {
+		return err
+	}
+	err = adapter.CreateIndexes(ctx, webhookCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "provider", Value: 1}, {Key: "event_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	)
+	if err != nil {
+		return err
+	}
+	return adapter.CreateIndexes(ctx, refundCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "idempotency_key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}}},
+	)
+}
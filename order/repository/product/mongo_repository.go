@@ -0,0 +1,99 @@
+package product
+
+import (
+	"context"
+	"errors"
+
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName   = "orders"
+	collectionName = "products"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainproduct.Repository {
+	baseAdapter := mongodb.NewBaseAdapter(writeDB, readDB, databaseName)
+	return &MongoRepository{
+		BaseAdapter: baseAdapter,
+	}
+}
+
+func (r *MongoRepository) GetProduct(ctx context.Context, sku string) (*domainproduct.Product, error) {
+	var product domainproduct.Product
+	err := r.GetReadDB().QueryOne(ctx, collectionName, bson.M{"sku": sku}, &product)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainproduct.ErrProductNotFound
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *MongoRepository) GetProducts(ctx context.Context, filter domainproduct.Filter) ([]domainproduct.Product, error) {
+	query := bson.M{}
+	if filter.Active != nil {
+		query["active"] = *filter.Active
+	}
+
+	var products []domainproduct.Product
+	err := r.GetReadDB().Query(
+		ctx,
+		collectionName,
+		query,
+		&products,
+		options.Find().SetSort(bson.M{"sku": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *MongoRepository) GetProductsBySKUs(ctx context.Context, skus []string) ([]domainproduct.Product, error) {
+	var products []domainproduct.Product
+	err := r.GetReadDB().Query(ctx, collectionName, bson.M{"sku": bson.M{"$in": skus}}, &products)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *MongoRepository) CreateProduct(ctx context.Context, product *domainproduct.Product) error {
+	err := r.GetWriteDB().Insert(ctx, collectionName, product)
+	if mongo.IsDuplicateKeyError(err) {
+		return domainproduct.ErrProductAlreadyExists
+	}
+	return err
+}
+
+func (r *MongoRepository) UpdateProduct(ctx context.Context, product *domainproduct.Product) error {
+	return r.GetWriteDB().Update(ctx, collectionName, bson.M{"sku": product.SKU}, bson.M{"$set": bson.M{
+		"name":       product.Name,
+		"price":      product.Price,
+		"active":     product.Active,
+		"updated_at": product.UpdatedAt,
+	}})
+}
+
+func (r *MongoRepository) DeleteProduct(ctx context.Context, sku string) error {
+	return r.GetWriteDB().Delete(ctx, collectionName, bson.M{"sku": sku})
+}
+
+// EnsureIndexes creates the indexes of the products collection: one product
+// per SKU
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "sku", Value: 1}}, Options: options.Index().SetUnique(true)},
+	)
+}
@@ -0,0 +1,44 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	domainrisk "github.com/DuongVu089x/interview/order/domain/risk"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "risk:velocity:"
+
+// RedisRepository keeps the orders of each user in a sorted set scored by
+// the time they were placed
+type RedisRepository struct {
+	client *redis.Client
+}
+
+func NewRedisRepository(client *redis.Client) domainrisk.VelocityRepository {
+	return &RedisRepository{
+		client: client,
+	}
+}
+
+func (r *RedisRepository) RecordOrder(ctx context.Context, userID string, orderID int64, at time.Time, window time.Duration) (int64, error) {
+	key := keyPrefix + userID
+	since := at.Add(-window).UnixMilli()
+
+	var count *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// NX keeps the time an order was first recorded
+		pipe.ZAddNX(ctx, key, redis.Z{Score: float64(at.UnixMilli()), Member: strconv.FormatInt(orderID, 10)})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", fmt.Sprintf("(%d", since))
+		count = pipe.ZCard(ctx, key)
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record order velocity: %w", err)
+	}
+	return count.Val(), nil
+}
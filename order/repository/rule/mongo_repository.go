@@ -0,0 +1,84 @@
+package rule
+
+import (
+	"context"
+	"errors"
+
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName   = "orders"
+	collectionName = "order_rules"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainrule.Repository {
+	baseAdapter := mongodb.NewBaseAdapter(writeDB, readDB, databaseName)
+	return &MongoRepository{
+		BaseAdapter: baseAdapter,
+	}
+}
+
+func (r *MongoRepository) GetRule(ctx context.Context, ruleID string) (*domainrule.Rule, error) {
+	var rule domainrule.Rule
+	err := r.GetReadDB().QueryOne(ctx, collectionName, bson.M{"rule_id": ruleID}, &rule)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainrule.ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *MongoRepository) GetRules(ctx context.Context) ([]domainrule.Rule, error) {
+	var rules []domainrule.Rule
+	err := r.GetReadDB().Query(
+		ctx,
+		collectionName,
+		bson.M{},
+		&rules,
+		options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "rule_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *MongoRepository) SaveRule(ctx context.Context, rule *domainrule.Rule) error {
+	// Every field is set so that a saved rule replaces the stored one whole
+	return r.GetWriteDB().Upsert(ctx, collectionName, bson.M{"rule_id": rule.RuleID}, bson.M{"$set": bson.M{
+		"type":         rule.Type,
+		"description":  rule.Description,
+		"priority":     rule.Priority,
+		"enabled":      rule.Enabled,
+		"tiers":        rule.Tiers,
+		"product_ids":  rule.ProductIDs,
+		"max_quantity": rule.MaxQuantity,
+		"amount":       rule.Amount,
+		"min_items":    rule.MinItems,
+		"created_at":   rule.CreatedAt,
+		"updated_at":   rule.UpdatedAt,
+	}})
+}
+
+func (r *MongoRepository) DeleteRule(ctx context.Context, ruleID string) error {
+	return r.GetWriteDB().Delete(ctx, collectionName, bson.M{"rule_id": ruleID})
+}
+
+// EnsureIndexes creates the index the rule lookups rely on
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "rule_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	)
+}
@@ -0,0 +1,125 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName   = "orders"
+	collectionName = "sagas"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainsaga.Repository {
+	return &MongoRepository{
+		BaseAdapter: mongodb.NewBaseAdapter(writeDB, readDB, databaseName),
+	}
+}
+
+func (r *MongoRepository) CreateSaga(ctx context.Context, saga *domainsaga.Saga) error {
+	err := r.GetWriteDB().Insert(ctx, collectionName, saga)
+	if mongo.IsDuplicateKeyError(err) {
+		return domainsaga.ErrSagaExists
+	}
+	return err
+}
+
+// GetSaga reads from the primary, as sagas are inspected while they run
+func (r *MongoRepository) GetSaga(ctx context.Context, sagaID string) (*domainsaga.Saga, error) {
+	var saga domainsaga.Saga
+	err := r.GetWriteDB().QueryOne(ctx, collectionName, bson.M{"saga_id": sagaID}, &saga)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainsaga.ErrSagaNotFound
+		}
+		return nil, err
+	}
+	return &saga, nil
+}
+
+func (r *MongoRepository) GetSagas(ctx context.Context, filter domainsaga.Filter) ([]domainsaga.Saga, error) {
+	query := bson.M{}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if len(filter.Statuses) > 0 {
+		query["status"] = bson.M{"$in": filter.Statuses}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	var sagas []domainsaga.Saga
+	if err := r.GetReadDB().Query(ctx, collectionName, query, &sagas, opts); err != nil {
+		return nil, err
+	}
+	return sagas, nil
+}
+
+func (r *MongoRepository) UpdateSaga(ctx context.Context, saga *domainsaga.Saga) error {
+	expected := saga.Version
+	saga.Version++
+
+	var updated domainsaga.Saga
+	err := r.GetWriteDB().FindOneAndUpdate(
+		ctx,
+		collectionName,
+		bson.M{"saga_id": saga.SagaID, "version": expected},
+		bson.M{"$set": saga},
+		&updated,
+	)
+	if err != nil {
+		saga.Version = expected
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domainsaga.ErrLeaseLost
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *MongoRepository) GetAbandonedSagas(ctx context.Context, now time.Time, limit int) ([]domainsaga.Saga, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "lease_until", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	var sagas []domainsaga.Saga
+	err := r.GetWriteDB().Query(
+		ctx,
+		collectionName,
+		bson.M{
+			"status":      bson.M{"$in": bson.A{domainsaga.StatusRunning, domainsaga.StatusCompensating}},
+			"lease_until": bson.M{"$lt": now},
+		},
+		&sagas,
+		opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return sagas, nil
+}
+
+// EnsureIndexes creates the indexes the saga queries rely on
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "saga_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "lease_until", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "type", Value: 1}, {Key: "created_at", Value: -1}}},
+	)
+}
@@ -0,0 +1,97 @@
+package shipment
+
+import (
+	"context"
+	"errors"
+
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName   = "orders"
+	collectionName = "shipments"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainshipment.Repository {
+	return &MongoRepository{
+		BaseAdapter: mongodb.NewBaseAdapter(writeDB, readDB, databaseName),
+	}
+}
+
+func (r *MongoRepository) CreateShipment(ctx context.Context, shipment *domainshipment.Shipment) error {
+	return r.GetWriteDB().Insert(ctx, collectionName, shipment)
+}
+
+func (r *MongoRepository) GetShipmentByTracking(ctx context.Context, carrier, trackingNumber string) (*domainshipment.Shipment, error) {
+	var shipment domainshipment.Shipment
+	err := r.GetWriteDB().QueryOne(ctx, collectionName, bson.M{"carrier": carrier, "tracking_number": trackingNumber}, &shipment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainshipment.ErrShipmentNotFound
+		}
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+func (r *MongoRepository) GetShipmentsByOrder(ctx context.Context, orderID int64) ([]domainshipment.Shipment, error) {
+	var shipments []domainshipment.Shipment
+	err := r.GetWriteDB().Query(
+		ctx,
+		collectionName,
+		bson.M{"order_id": orderID},
+		&shipments,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+func (r *MongoRepository) UpdateShipment(ctx context.Context, shipment *domainshipment.Shipment) error {
+	expected := shipment.Version
+	shipment.Version++
+
+	var updated domainshipment.Shipment
+	err := r.GetWriteDB().FindOneAndUpdate(
+		ctx,
+		collectionName,
+		bson.M{"shipment_id": shipment.ShipmentID, "version": expected},
+		bson.M{"$set": bson.M{
+			"status":       shipment.Status,
+			"events":       shipment.Events,
+			"shipped_at":   shipment.ShippedAt,
+			"delivered_at": shipment.DeliveredAt,
+			"updated_at":   shipment.UpdatedAt,
+			"version":      shipment.Version,
+		}},
+		&updated,
+	)
+	if err != nil {
+		shipment.Version = expected
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domainshipment.ErrVersionConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// EnsureIndexes creates the indexes the shipment lookups rely on
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	return adapter.CreateIndexes(ctx, collectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "shipment_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "carrier", Value: 1}, {Key: "tracking_number", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}}},
+	)
+}
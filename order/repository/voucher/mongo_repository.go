@@ -0,0 +1,189 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+	"github.com/DuongVu089x/interview/order/infrastructure/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRepository struct {
+	*mongodb.BaseAdapter
+}
+
+const (
+	databaseName             = "orders"
+	voucherCollectionName    = "vouchers"
+	redemptionCollectionName = "voucher_redemptions"
+)
+
+func NewMongoRepository(writeDB, readDB *mongo.Client) domainvoucher.Repository {
+	baseAdapter := mongodb.NewBaseAdapter(writeDB, readDB, databaseName)
+	return &MongoRepository{
+		BaseAdapter: baseAdapter,
+	}
+}
+
+func (r *MongoRepository) GetVoucher(ctx context.Context, code string) (*domainvoucher.Voucher, error) {
+	var voucher domainvoucher.Voucher
+	err := r.GetReadDB().QueryOne(ctx, voucherCollectionName, bson.M{"code": code}, &voucher)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainvoucher.ErrVoucherNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *MongoRepository) GetVouchers(ctx context.Context) ([]domainvoucher.Voucher, error) {
+	var vouchers []domainvoucher.Voucher
+	err := r.GetReadDB().Query(
+		ctx,
+		voucherCollectionName,
+		bson.M{},
+		&vouchers,
+		options.Find().SetSort(bson.M{"created_at": -1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *MongoRepository) CreateVoucher(ctx context.Context, voucher *domainvoucher.Voucher) error {
+	err := r.GetWriteDB().Insert(ctx, voucherCollectionName, voucher)
+	if mongo.IsDuplicateKeyError(err) {
+		return domainvoucher.ErrVoucherAlreadyExists
+	}
+	return err
+}
+
+func (r *MongoRepository) UpdateVoucher(ctx context.Context, voucher *domainvoucher.Voucher) error {
+	// used_count is only ever changed by IncrementUsage
+	return r.GetWriteDB().Update(ctx, voucherCollectionName, bson.M{"code": voucher.Code}, bson.M{"$set": bson.M{
+		"description":        voucher.Description,
+		"type":               voucher.Type,
+		"active":             voucher.Active,
+		"percentage":         voucher.Percentage,
+		"amount":             voucher.Amount,
+		"max_discount":       voucher.MaxDiscount,
+		"free_product_id":    voucher.FreeProductID,
+		"free_quantity":      voucher.FreeQuantity,
+		"min_spend":          voucher.MinSpend,
+		"usage_limit":        voucher.UsageLimit,
+		"per_customer_limit": voucher.PerCustomerLimit,
+		"starts_at":          voucher.StartsAt,
+		"ends_at":            voucher.EndsAt,
+		"updated_at":         voucher.UpdatedAt,
+	}})
+}
+
+func (r *MongoRepository) IncrementUsage(ctx context.Context, code string, now time.Time) (*domainvoucher.Voucher, error) {
+	filter := bson.M{
+		"code":   code,
+		"active": true,
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"usage_limit": 0},
+				bson.M{"$expr": bson.M{"$lt": bson.A{"$used_count", "$usage_limit"}}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"starts_at": bson.M{"$exists": false}},
+				bson.M{"starts_at": bson.M{"$lte": now}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"ends_at": bson.M{"$exists": false}},
+				bson.M{"ends_at": bson.M{"$gte": now}},
+			}},
+		},
+	}
+
+	var voucher domainvoucher.Voucher
+	err := r.GetWriteDB().FindOneAndUpdate(
+		ctx,
+		voucherCollectionName,
+		filter,
+		bson.M{
+			"$inc": bson.M{"used_count": 1},
+			"$set": bson.M{"updated_at": now},
+		},
+		&voucher,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainvoucher.ErrUsageLimitReached
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *MongoRepository) DecrementUsage(ctx context.Context, code string, now time.Time) error {
+	return r.GetWriteDB().Update(ctx, voucherCollectionName,
+		bson.M{"code": code, "used_count": bson.M{"$gt": 0}},
+		bson.M{
+			"$inc": bson.M{"used_count": -1},
+			"$set": bson.M{"updated_at": now},
+		},
+	)
+}
+
+func (r *MongoRepository) CountRedemptions(ctx context.Context, code string, userID string) (int64, error) {
+	// Counted on the primary so that it sees redemptions of the current transaction
+	var redemptions []domainvoucher.Redemption
+	err := r.GetWriteDB().Query(
+		ctx,
+		redemptionCollectionName,
+		bson.M{"voucher_code": code, "user_id": userID},
+		&redemptions,
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(redemptions)), nil
+}
+
+func (r *MongoRepository) CreateRedemption(ctx context.Context, redemption *domainvoucher.Redemption) error {
+	return r.GetWriteDB().Insert(ctx, redemptionCollectionName, redemption)
+}
+
+// GetRedemptionByOrder reads from the primary, as it is checked within the
+// transaction that redeems
+func (r *MongoRepository) GetRedemptionByOrder(ctx context.Context, orderID int64) (*domainvoucher.Redemption, error) {
+	var redemption domainvoucher.Redemption
+	err := r.GetWriteDB().QueryOne(ctx, redemptionCollectionName, bson.M{"order_id": orderID}, &redemption)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainvoucher.ErrRedemptionNotFound
+		}
+		return nil, err
+	}
+	return &redemption, nil
+}
+
+func (r *MongoRepository) DeleteRedemption(ctx context.Context, orderID int64) error {
+	return r.GetWriteDB().Delete(ctx, redemptionCollectionName, bson.M{"order_id": orderID})
+}
+
+// EnsureIndexes creates the indexes of the voucher collections: one voucher
+// per code, and redemptions looked up by order and counted per customer
+func EnsureIndexes(ctx context.Context, writeDB *mongo.Client) error {
+	adapter := mongodb.NewMongoAdapter(writeDB, databaseName)
+	if err := adapter.CreateIndexes(ctx, voucherCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "code", Value: 1}}, Options: options.Index().SetUnique(true)},
+	); err != nil {
+		return err
+	}
+	return adapter.CreateIndexes(ctx, redemptionCollectionName,
+		mongo.IndexModel{Keys: bson.D{{Key: "order_id", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "voucher_code", Value: 1}, {Key: "user_id", Value: 1}}},
+	)
+}
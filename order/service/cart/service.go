@@ -0,0 +1,61 @@
+package cart
+
+import (
+	"context"
+	"time"
+
+	domaincart "github.com/DuongVu089x/interview/order/domain/cart"
+)
+
+type Service struct {
+	cartRepo domaincart.Repository
+	// ttl is how long a cart is kept after its last change
+	ttl time.Duration
+}
+
+func NewCartService(cartRepo domaincart.Repository, ttl time.Duration) domaincart.Service {
+	return &Service{cartRepo: cartRepo, ttl: ttl}
+}
+
+func (s *Service) GetCart(ctx context.Context, userID string) (*domaincart.Cart, error) {
+	cart, err := s.cartRepo.GetCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		return domaincart.NewCart(userID), nil
+	}
+	return cart, nil
+}
+
+func (s *Service) AddItem(ctx context.Context, userID, productID string, quantity int) (*domaincart.Cart, error) {
+	return s.cartRepo.UpdateCart(ctx, userID, s.ttl, func(cart *domaincart.Cart) error {
+		return cart.AddItem(productID, quantity, time.Now())
+	})
+}
+
+func (s *Service) SetQuantity(ctx context.Context, userID, productID string, quantity int) (*domaincart.Cart, error) {
+	return s.cartRepo.UpdateCart(ctx, userID, s.ttl, func(cart *domaincart.Cart) error {
+		return cart.SetQuantity(productID, quantity, time.Now())
+	})
+}
+
+func (s *Service) RemoveItem(ctx context.Context, userID, productID string) (*domaincart.Cart, error) {
+	return s.cartRepo.UpdateCart(ctx, userID, s.ttl, func(cart *domaincart.Cart) error {
+		return cart.RemoveItem(productID, time.Now())
+	})
+}
+
+func (s *Service) Clear(ctx context.Context, userID string) (*domaincart.Cart, error) {
+	return s.cartRepo.UpdateCart(ctx, userID, s.ttl, func(cart *domaincart.Cart) error {
+		cart.Clear(time.Now())
+		return nil
+	})
+}
+
+func (s *Service) Subtract(ctx context.Context, userID string, ordered map[string]int) (*domaincart.Cart, error) {
+	return s.cartRepo.UpdateCart(ctx, userID, s.ttl, func(cart *domaincart.Cart) error {
+		cart.Subtract(ordered, time.Now())
+		return nil
+	})
+}
@@ -0,0 +1,100 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	domaininventory "github.com/DuongVu089x/interview/order/domain/inventory"
+)
+
+type Service struct {
+	inventoryRepo domaininventory.Repository
+}
+
+func NewInventoryService(inventoryRepo domaininventory.Repository) domaininventory.Service {
+	return &Service{inventoryRepo: inventoryRepo}
+}
+
+func (s *Service) GetStock(ctx context.Context, productID string) (*domaininventory.Stock, error) {
+	return s.inventoryRepo.GetStock(ctx, productID)
+}
+
+func (s *Service) GetStocks(ctx context.Context) ([]domaininventory.Stock, error) {
+	return s.inventoryRepo.GetStocks(ctx)
+}
+
+func (s *Service) SetStock(ctx context.Context, productID string, available int) (*domaininventory.Stock, error) {
+	if available < 0 {
+		return nil, domaininventory.ErrInvalidQuantity
+	}
+	return s.inventoryRepo.SetAvailable(ctx, productID, available)
+}
+
+func (s *Service) AdjustStock(ctx context.Context, productID string, delta int) (*domaininventory.Stock, error) {
+	return s.inventoryRepo.AdjustAvailable(ctx, productID, delta)
+}
+
+func (s *Service) ReserveForOrder(ctx context.Context, orderID int64, items []domaininventory.ReservationItem) error {
+	// Merge duplicated products so each stock document is updated once
+	quantities := make(map[string]int)
+	var productIDs []string
+	for _, item := range items {
+		if _, ok := quantities[item.ProductID]; !ok {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+
+	now := time.Now()
+	reservations := make([]domaininventory.Reservation, 0, len(productIDs))
+	for _, productID := range productIDs {
+		if err := s.inventoryRepo.Reserve(ctx, productID, quantities[productID]); err != nil {
+			return err
+		}
+		reservations = append(reservations, domaininventory.Reservation{
+			OrderID:   orderID,
+			ProductID: productID,
+			Quantity:  quantities[productID],
+			Status:    domaininventory.ReservationReserved,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	if len(reservations) == 0 {
+		return nil
+	}
+	return s.inventoryRepo.CreateReservations(ctx, reservations)
+}
+
+func (s *Service) ReleaseForOrder(ctx context.Context, orderID int64) error {
+	return s.settle(ctx, orderID, domaininventory.ReservationReleased, s.inventoryRepo.Release)
+}
+
+func (s *Service) CommitForOrder(ctx context.Context, orderID int64) error {
+	return s.settle(ctx, orderID, domaininventory.ReservationCommitted, s.inventoryRepo.Commit)
+}
+
+// settle applies fn to every active reservation of an order and then moves the
+// reservations to the given status
+func (s *Service) settle(ctx context.Context, orderID int64, to domaininventory.ReservationStatus, fn func(ctx context.Context, productID string, quantity int) error) error {
+	reservations, err := s.inventoryRepo.GetReservationsByOrder(ctx, orderID, domaininventory.ReservationReserved)
+	if err != nil {
+		return err
+	}
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	for _, reservation := range reservations {
+		if err := fn(ctx, reservation.ProductID, reservation.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return s.inventoryRepo.UpdateReservationsStatus(ctx, orderID, domaininventory.ReservationReserved, to)
+}
+
+func (s *Service) GetReservationsByOrder(ctx context.Context, orderID int64) ([]domaininventory.Reservation, error) {
+	return s.inventoryRepo.GetReservationsByOrder(ctx, orderID, "")
+}
@@ -1,8 +1,10 @@
 package order
 
 import (
+	"context"
 	"errors"
 
+	"github.com/DuongVu089x/interview/order/domain/money"
 	domainorder "github.com/DuongVu089x/interview/order/domain/order"
 )
 
@@ -14,53 +16,80 @@ func NewOrderService(orderRepo domainorder.Repository) domainorder.Service {
 	return &Service{orderRepo: orderRepo}
 }
 
-func (s *Service) GetOrder(id int64) (*domainorder.Order, error) {
-	return s.orderRepo.GetOrder(id)
+func (s *Service) GetOrder(ctx context.Context, id int64) (*domainorder.Order, error) {
+	return s.orderRepo.GetOrder(ctx, id)
 }
 
-func (s *Service) GetOrderByCustomerID(customerID string, conditions map[string]any) ([]domainorder.Order, error) {
+func (s *Service) GetOrderByCode(ctx context.Context, code string) (*domainorder.Order, error) {
+	return s.orderRepo.GetOrderByCode(ctx, code)
+}
+
+func (s *Service) GetOrderForUpdate(ctx context.Context, id int64) (*domainorder.Order, error) {
+	return s.orderRepo.GetOrderForUpdate(ctx, id)
+}
 
-	query := domainorder.Order{
-		UserID: customerID,
+func (s *Service) GetOrders(ctx context.Context, filter domainorder.Filter) ([]domainorder.Order, *domainorder.Cursor, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
 	}
 
-	if conditions["status"] != "" && conditions["status"] != nil {
-		query.Status = domainorder.OrderStatus(conditions["status"].(string))
+	// Fetch one extra order to find out whether there is a next page
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit = limit + 1
+	}
+
+	orders, err := s.orderRepo.GetOrders(ctx, filter)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return s.orderRepo.GetOrders(query)
+	if limit > 0 && len(orders) > limit {
+		orders = orders[:limit]
+		return orders, domainorder.CursorOf(&orders[limit-1], filter), nil
+	}
+	return orders, nil, nil
 }
 
-func (s *Service) CalculateTotal(items []domainorder.OrderItem) float64 {
-	total := 0.0
+func (s *Service) CalculateTotal(items []domainorder.OrderItem) (money.Money, error) {
+	var total money.Money
 	for _, item := range items {
-		total += item.Price * float64(item.Quantity)
+		var err error
+		if total, err = total.Add(item.Price.Multiply(item.Quantity)); err != nil {
+			return money.Money{}, err
+		}
 	}
-	return total
+	return total, nil
 }
 
-func (s *Service) CreateOrder(order *domainorder.Order) error {
-	return s.orderRepo.CreateOrder(order)
+func (s *Service) CreateOrder(ctx context.Context, order *domainorder.Order) error {
+	order.Version = 1
+	return s.orderRepo.CreateOrder(ctx, order)
 }
 
-func (s *Service) UpdateOrder(order *domainorder.Order) error {
-	return s.orderRepo.UpdateOrder(order)
+func (s *Service) UpdateOrder(ctx context.Context, order *domainorder.Order) error {
+	return s.orderRepo.UpdateOrder(ctx, order)
 }
 
-func (s *Service) DeleteOrder(id string) error {
-	return s.orderRepo.DeleteOrder(id)
+func (s *Service) DeleteOrder(ctx context.Context, id int64) error {
+	return s.orderRepo.DeleteOrder(ctx, id)
 }
 
-func (s *Service) CalculateTotalOfCustomer(customerID string, status domainorder.OrderStatus) (float64, error) {
-	total := 0.0
+func (s *Service) CalculateTotalOfCustomer(ctx context.Context, customerID string, status domainorder.OrderStatus) (money.Money, error) {
+	var total money.Money
 
-	orders, err := s.GetOrderByCustomerID(customerID, map[string]any{"status": status})
+	orders, _, err := s.GetOrders(ctx, domainorder.Filter{
+		UserID:   customerID,
+		Statuses: []domainorder.OrderStatus{status},
+	})
 	if err != nil {
-		return 0, err
+		return money.Money{}, err
 	}
 
 	for _, order := range orders {
-		total += order.TotalAmount
+		if total, err = total.Add(order.TotalAmount); err != nil {
+			return money.Money{}, err
+		}
 	}
 	return total, nil
 }
@@ -85,8 +114,13 @@ func (s *Service) ValidateOrder(order *domainorder.Order) error {
 
 	}
 
-	if order.TotalAmount <= 0 {
-		return errors.New("total amount must be greater than 0")
+	if !order.Subtotal.IsPositive() {
+		return errors.New("subtotal must be greater than 0")
+	}
+
+	// A voucher may discount the whole order, but never below zero
+	if order.TotalAmount.IsNegative() {
+		return errors.New("total amount must not be negative")
 	}
 
 	return nil
@@ -0,0 +1,79 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainpayment "github.com/DuongVu089x/interview/order/domain/payment"
+)
+
+type Service struct {
+	paymentRepo domainpayment.Repository
+}
+
+func NewPaymentService(paymentRepo domainpayment.Repository) domainpayment.Service {
+	return &Service{paymentRepo: paymentRepo}
+}
+
+func (s *Service) CreateIntent(ctx context.Context, intent *domainpayment.Intent) error {
+	return s.paymentRepo.CreateIntent(ctx, intent)
+}
+
+func (s *Service) GetIntent(ctx context.Context, intentID string) (*domainpayment.Intent, error) {
+	return s.paymentRepo.GetIntent(ctx, intentID)
+}
+
+func (s *Service) GetIntentsByOrder(ctx context.Context, orderID int64) ([]domainpayment.Intent, error) {
+	return s.paymentRepo.GetIntentsByOrder(ctx, orderID)
+}
+
+func (s *Service) GetOpenIntent(ctx context.Context, orderID int64) (*domainpayment.Intent, error) {
+	intents, err := s.paymentRepo.GetIntentsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, intent := range intents {
+		if intent.IsOpen() {
+			return &intent, nil
+		}
+	}
+	return nil, domainpayment.ErrIntentNotFound
+}
+
+func (s *Service) UpdateIntent(ctx context.Context, intent *domainpayment.Intent) error {
+	return s.paymentRepo.UpdateIntent(ctx, intent)
+}
+
+func (s *Service) CreateRefund(ctx context.Context, refund *domainpayment.Refund) error {
+	return s.paymentRepo.CreateRefund(ctx, refund)
+}
+
+func (s *Service) GetRefundByKey(ctx context.Context, idempotencyKey string) (*domainpayment.Refund, error) {
+	return s.paymentRepo.GetRefundByKey(ctx, idempotencyKey)
+}
+
+func (s *Service) GetRefundsByOrder(ctx context.Context, orderID int64) ([]domainpayment.Refund, error) {
+	return s.paymentRepo.GetRefundsByOrder(ctx, orderID)
+}
+
+func (s *Service) RefundedAmount(ctx context.Context, intent *domainpayment.Intent) (money.Money, error) {
+	refunds, err := s.paymentRepo.GetRefundsByOrder(ctx, intent.OrderID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	refunded := money.New(0, intent.Amount.Currency)
+	for _, refund := range refunds {
+		if refund.IntentID != intent.IntentID {
+			continue
+		}
+		if refunded, err = refunded.Add(refund.Amount); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return refunded, nil
+}
+
+func (s *Service) RecordWebhookEvent(ctx context.Context, event *domainpayment.WebhookEvent) error {
+	return s.paymentRepo.RecordWebhookEvent(ctx, event)
+}
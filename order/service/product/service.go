@@ -0,0 +1,115 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainproduct "github.com/DuongVu089x/interview/order/domain/product"
+)
+
+type Service struct {
+	productRepo domainproduct.Repository
+}
+
+func NewProductService(productRepo domainproduct.Repository) domainproduct.Service {
+	return &Service{productRepo: productRepo}
+}
+
+func (s *Service) GetProduct(ctx context.Context, sku string) (*domainproduct.Product, error) {
+	return s.productRepo.GetProduct(ctx, sku)
+}
+
+func (s *Service) GetProducts(ctx context.Context, filter domainproduct.Filter) ([]domainproduct.Product, error) {
+	return s.productRepo.GetProducts(ctx, filter)
+}
+
+func (s *Service) GetSellableProducts(ctx context.Context, skus []string) (map[string]domainproduct.Product, error) {
+	products, err := s.productRepo.GetProductsBySKUs(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]domainproduct.Product, len(products))
+	for _, product := range products {
+		if product.Active {
+			catalog[product.SKU] = product
+		}
+	}
+
+	for _, sku := range skus {
+		if _, ok := catalog[sku]; !ok {
+			return nil, fmt.Errorf("%w: %s", domainproduct.ErrProductUnavailable, sku)
+		}
+	}
+	return catalog, nil
+}
+
+func (s *Service) GetProductsBySKUs(ctx context.Context, skus []string) (map[string]domainproduct.Product, error) {
+	products, err := s.productRepo.GetProductsBySKUs(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]domainproduct.Product, len(products))
+	for _, product := range products {
+		catalog[product.SKU] = product
+	}
+	return catalog, nil
+}
+
+func (s *Service) CreateProduct(ctx context.Context, product *domainproduct.Product) error {
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+
+	_, err := s.productRepo.GetProduct(ctx, product.SKU)
+	if err == nil {
+		return domainproduct.ErrProductAlreadyExists
+	}
+	if !errors.Is(err, domainproduct.ErrProductNotFound) {
+		return err
+	}
+
+	now := time.Now()
+	product.CreatedAt = now
+	product.UpdatedAt = now
+	return s.productRepo.CreateProduct(ctx, product)
+}
+
+func (s *Service) UpdateProduct(ctx context.Context, product *domainproduct.Product) error {
+	if err := validateProduct(product); err != nil {
+		return err
+	}
+	if _, err := s.productRepo.GetProduct(ctx, product.SKU); err != nil {
+		return err
+	}
+
+	product.UpdatedAt = time.Now()
+	return s.productRepo.UpdateProduct(ctx, product)
+}
+
+func (s *Service) DeleteProduct(ctx context.Context, sku string) error {
+	if _, err := s.productRepo.GetProduct(ctx, sku); err != nil {
+		return err
+	}
+	return s.productRepo.DeleteProduct(ctx, sku)
+}
+
+func validateProduct(product *domainproduct.Product) error {
+	if product.SKU == "" {
+		return fmt.Errorf("%w: SKU is required", domainproduct.ErrInvalidProduct)
+	}
+	if product.Name == "" {
+		return fmt.Errorf("%w: name is required", domainproduct.ErrInvalidProduct)
+	}
+	if !product.Price.IsPositive() {
+		return fmt.Errorf("%w: price must be greater than 0", domainproduct.ErrInvalidProduct)
+	}
+	if err := money.ValidateCurrency(product.Price.Currency); err != nil {
+		return fmt.Errorf("%w: %v", domainproduct.ErrInvalidProduct, err)
+	}
+	return nil
+}
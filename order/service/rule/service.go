@@ -0,0 +1,51 @@
+package rule
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainrule "github.com/DuongVu089x/interview/order/domain/rule"
+)
+
+type Service struct {
+	ruleRepo domainrule.Repository
+}
+
+func NewRuleService(ruleRepo domainrule.Repository) domainrule.Service {
+	return &Service{ruleRepo: ruleRepo}
+}
+
+func (s *Service) GetRule(ctx context.Context, ruleID string) (*domainrule.Rule, error) {
+	return s.ruleRepo.GetRule(ctx, ruleID)
+}
+
+func (s *Service) GetRules(ctx context.Context) ([]domainrule.Rule, error) {
+	return s.ruleRepo.GetRules(ctx)
+}
+
+func (s *Service) SaveRule(ctx context.Context, rule *domainrule.Rule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	existing, err := s.ruleRepo.GetRule(ctx, rule.RuleID)
+	switch {
+	case err == nil:
+		rule.CreatedAt = existing.CreatedAt
+	case errors.Is(err, domainrule.ErrRuleNotFound):
+		rule.CreatedAt = now
+	default:
+		return err
+	}
+	rule.UpdatedAt = now
+	return s.ruleRepo.SaveRule(ctx, rule)
+}
+
+func (s *Service) DeleteRule(ctx context.Context, ruleID string) error {
+	if _, err := s.ruleRepo.GetRule(ctx, ruleID); err != nil {
+		return err
+	}
+	return s.ruleRepo.DeleteRule(ctx, ruleID)
+}
@@ -0,0 +1,48 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainsaga "github.com/DuongVu089x/interview/order/domain/saga"
+)
+
+// maxListLimit caps the number of sagas listed at once
+const maxListLimit = 100
+
+type Service struct {
+	sagaRepo domainsaga.Repository
+}
+
+func NewSagaService(sagaRepo domainsaga.Repository) domainsaga.Service {
+	return &Service{sagaRepo: sagaRepo}
+}
+
+func (s *Service) CreateSaga(ctx context.Context, saga *domainsaga.Saga) error {
+	return s.sagaRepo.CreateSaga(ctx, saga)
+}
+
+func (s *Service) GetSaga(ctx context.Context, sagaID string) (*domainsaga.Saga, error) {
+	return s.sagaRepo.GetSaga(ctx, sagaID)
+}
+
+func (s *Service) GetSagas(ctx context.Context, filter domainsaga.Filter) ([]domainsaga.Saga, error) {
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: unknown status %q", domainsaga.ErrInvalidSagaFilter, status)
+		}
+	}
+	if filter.Limit <= 0 || filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	return s.sagaRepo.GetSagas(ctx, filter)
+}
+
+func (s *Service) UpdateSaga(ctx context.Context, saga *domainsaga.Saga) error {
+	return s.sagaRepo.UpdateSaga(ctx, saga)
+}
+
+func (s *Service) GetAbandonedSagas(ctx context.Context, now time.Time, limit int) ([]domainsaga.Saga, error) {
+	return s.sagaRepo.GetAbandonedSagas(ctx, now, limit)
+}
@@ -0,0 +1,31 @@
+package shipment
+
+import (
+	"context"
+
+	domainshipment "github.com/DuongVu089x/interview/order/domain/shipment"
+)
+
+type Service struct {
+	shipmentRepo domainshipment.Repository
+}
+
+func NewShipmentService(shipmentRepo domainshipment.Repository) domainshipment.Service {
+	return &Service{shipmentRepo: shipmentRepo}
+}
+
+func (s *Service) CreateShipment(ctx context.Context, shipment *domainshipment.Shipment) error {
+	return s.shipmentRepo.CreateShipment(ctx, shipment)
+}
+
+func (s *Service) GetShipmentByTracking(ctx context.Context, carrier, trackingNumber string) (*domainshipment.Shipment, error) {
+	return s.shipmentRepo.GetShipmentByTracking(ctx, carrier, trackingNumber)
+}
+
+func (s *Service) GetShipmentsByOrder(ctx context.Context, orderID int64) ([]domainshipment.Shipment, error) {
+	return s.shipmentRepo.GetShipmentsByOrder(ctx, orderID)
+}
+
+func (s *Service) UpdateShipment(ctx context.Context, shipment *domainshipment.Shipment) error {
+	return s.shipmentRepo.UpdateShipment(ctx, shipment)
+}
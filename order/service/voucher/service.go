@@ -0,0 +1,147 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DuongVu089x/interview/order/domain/money"
+	domainvoucher "github.com/DuongVu089x/interview/order/domain/voucher"
+)
+
+type Service struct {
+	voucherRepo domainvoucher.Repository
+}
+
+func NewVoucherService(voucherRepo domainvoucher.Repository) domainvoucher.Service {
+	return &Service{voucherRepo: voucherRepo}
+}
+
+func (s *Service) GetVoucher(ctx context.Context, code string) (*domainvoucher.Voucher, error) {
+	return s.voucherRepo.GetVoucher(ctx, code)
+}
+
+func (s *Service) GetVouchers(ctx context.Context) ([]domainvoucher.Voucher, error) {
+	return s.voucherRepo.GetVouchers(ctx)
+}
+
+func (s *Service) CreateVoucher(ctx context.Context, voucher *domainvoucher.Voucher) error {
+	if err := voucher.Validate(); err != nil {
+		return err
+	}
+
+	_, err := s.voucherRepo.GetVoucher(ctx, voucher.Code)
+	if err == nil {
+		return domainvoucher.ErrVoucherAlreadyExists
+	}
+	if !errors.Is(err, domainvoucher.ErrVoucherNotFound) {
+		return err
+	}
+
+	now := time.Now()
+	voucher.UsedCount = 0
+	voucher.CreatedAt = now
+	voucher.UpdatedAt = now
+	return s.voucherRepo.CreateVoucher(ctx, voucher)
+}
+
+func (s *Service) UpdateVoucher(ctx context.Context, voucher *domainvoucher.Voucher) error {
+	if err := voucher.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.voucherRepo.GetVoucher(ctx, voucher.Code); err != nil {
+		return err
+	}
+
+	voucher.UpdatedAt = time.Now()
+	return s.voucherRepo.UpdateVoucher(ctx, voucher)
+}
+
+func (s *Service) CalculateDiscount(ctx context.Context, code string, userID string, subtotal money.Money, items []domainvoucher.PricedItem) (money.Money, error) {
+	voucher, err := s.voucherRepo.GetVoucher(ctx, code)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	if err := voucher.CheckApplicable(time.Now(), subtotal); err != nil {
+		return money.Money{}, err
+	}
+
+	if voucher.PerCustomerLimit > 0 {
+		used, err := s.voucherRepo.CountRedemptions(ctx, code, userID)
+		if err != nil {
+			return money.Money{}, err
+		}
+		if used >= int64(voucher.PerCustomerLimit) {
+			return money.Money{}, domainvoucher.ErrCustomerUsageLimitReached
+		}
+	}
+
+	return voucher.CalculateDiscount(subtotal, items)
+}
+
+func (s *Service) Redeem(ctx context.Context, code string, userID string, orderID int64, discount money.Money) error {
+	now := time.Now()
+
+	if _, err := s.voucherRepo.GetRedemptionByOrder(ctx, orderID); err == nil {
+		return nil
+	} else if !errors.Is(err, domainvoucher.ErrRedemptionNotFound) {
+		return err
+	}
+
+	// Incrementing the voucher document makes concurrent redemptions of the same
+	// voucher conflict, so the per customer count below cannot be raced either
+	voucher, err := s.voucherRepo.IncrementUsage(ctx, code, now)
+	if err != nil {
+		if errors.Is(err, domainvoucher.ErrUsageLimitReached) {
+			return s.unavailableReason(ctx, code, now, err)
+		}
+		return err
+	}
+
+	if voucher.PerCustomerLimit > 0 {
+		used, err := s.voucherRepo.CountRedemptions(ctx, code, userID)
+		if err != nil {
+			return err
+		}
+		if used >= int64(voucher.PerCustomerLimit) {
+			return domainvoucher.ErrCustomerUsageLimitReached
+		}
+	}
+
+	return s.voucherRepo.CreateRedemption(ctx, &domainvoucher.Redemption{
+		VoucherCode: code,
+		UserID:      userID,
+		OrderID:     orderID,
+		Discount:    discount,
+		CreatedAt:   now,
+	})
+}
+
+func (s *Service) Release(ctx context.Context, orderID int64) error {
+	redemption, err := s.voucherRepo.GetRedemptionByOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, domainvoucher.ErrRedemptionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := s.voucherRepo.DeleteRedemption(ctx, orderID); err != nil {
+		return err
+	}
+	return s.voucherRepo.DecrementUsage(ctx, redemption.VoucherCode, time.Now())
+}
+
+// unavailableReason explains why a voucher could not be redeemed, falling back
+// to err when the voucher looks applicable
+func (s *Service) unavailableReason(ctx context.Context, code string, now time.Time, err error) error {
+	voucher, getErr := s.voucherRepo.GetVoucher(ctx, code)
+	if getErr != nil {
+		return getErr
+	}
+	if reason := voucher.CheckApplicable(now, voucher.MinSpend); reason != nil {
+		return reason
+	}
+	return err
+}